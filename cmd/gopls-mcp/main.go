@@ -14,9 +14,10 @@ import (
 )
 
 var (
-	goplsPath     string
-	workspaceRoot string
-	logLevel      string
+	goplsPath       string
+	workspaceRoot   string
+	logLevel        string
+	unusedAllowlist []string
 )
 
 var rootCmd = &cobra.Command{
@@ -36,9 +37,10 @@ All tools return structured JSON responses with precise location information.`,
 		configureLogging(logLevel)
 
 		config := types.Config{
-			GoplsPath:     goplsPath,
-			WorkspaceRoot: workspaceRoot,
-			LogLevel:      logLevel,
+			GoplsPath:       goplsPath,
+			WorkspaceRoot:   workspaceRoot,
+			LogLevel:        logLevel,
+			UnusedAllowlist: unusedAllowlist,
 		}
 
 		// Ensure the workspace root is a valid directory
@@ -71,6 +73,7 @@ func init() {
 	rootCmd.Flags().StringVar(&goplsPath, "gopls-path", "gopls", "Path to the gopls binary")
 	rootCmd.Flags().StringVar(&workspaceRoot, "workspace-root", ".", "Root directory of the Go workspace")
 	rootCmd.Flags().StringVar(&logLevel, "log-level", "info", "Log level (debug, info, warn, error)")
+	rootCmd.Flags().StringSliceVar(&unusedAllowlist, "unused-allowlist", nil, "Patterns of symbols that find_unused_symbols never reports, like public API packages (e.g. example.com/project/api/...)")
 }
 
 // configureLogging sets up structured logging with the specified log level
@@ -8,9 +8,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -247,6 +249,382 @@ func validateRenameSymbolByAnchorToolResult(t *testing.T, jsonContent string, ex
 	}
 }
 
+// validateListSymbolsInPackageToolResult validates the structure of a list symbols in package result
+func validateListSymbolsInPackageToolResult(t *testing.T, jsonContent string) {
+	var result results.ListSymbolsInPackageToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal list symbols in package tool result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.Equal(t, "main", result.PackageName, "Package name should match")
+	assert.Equal(t, "testdata/example", result.ImportPath, "Import path should match")
+	assert.Len(t, result.Files, 4, "Should list all non-test files")
+	assert.Contains(t, result.ExportedAPI, "Calculator.Add", "Exported API should include methods of exported types")
+
+	// Methods are grouped under their receiver types, rather than listed at the top level
+	var calculator *results.PackageSymbol
+	for i, symbol := range result.PackageSymbols {
+		assert.NotEqual(t, results.SymbolKindMethod, symbol.Kind, "Methods should be grouped under their types")
+		if symbol.Name == "Calculator" {
+			calculator = &result.PackageSymbols[i]
+		}
+	}
+	if assert.NotNil(t, calculator, "Should have found the Calculator struct") {
+		assert.NotEmpty(t, calculator.Methods, "Calculator should have methods")
+		for _, method := range calculator.Methods {
+			assert.True(t, method.Anchor.IsValid(), "Method anchor should be valid")
+			assert.NotEmpty(t, method.SemanticAnchor, "Method semantic anchor should not be empty")
+		}
+	}
+}
+
+// validateListPackagesToolResult validates the structure of a list packages result
+func validateListPackagesToolResult(t *testing.T, jsonContent string) {
+	var result results.ListPackagesToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal list packages tool result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.Len(t, result.Packages, 1, "Should have exactly one workspace package")
+
+	if len(result.Packages) > 0 {
+		pkg := result.Packages[0]
+		assert.Equal(t, "testdata/example", pkg.ImportPath, "Import path should match")
+		assert.Equal(t, "testdata/example", pkg.ModulePath, "Module path should match")
+		assert.Equal(t, ".", pkg.Directory, "Directory should be the workspace root")
+		assert.False(t, pkg.External, "Workspace package should not be external")
+		assert.Contains(t, pkg.Files, "calculator.go", "Files should include calculator.go")
+	}
+
+	if assert.Len(t, result.Modules, 1, "Should have exactly one module") {
+		assert.True(t, result.Modules[0].Workspace, "Module should be part of the workspace")
+	}
+}
+
+// validateGetDocumentationToolResult validates the structure of a get documentation result
+func validateGetDocumentationToolResult(t *testing.T, jsonContent string, expectedSignature string, expectedExternal bool) {
+	var result results.GetDocumentationToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal get documentation tool result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.NotEmpty(t, result.PackageName, "Package name should not be empty")
+	assert.Equal(t, expectedExternal, result.External, "External flag should match")
+
+	if assert.NotNil(t, result.Symbol, "Symbol documentation should be set") {
+		assert.Equal(t, expectedSignature, result.Symbol.Signature, "Symbol signature should match")
+		assert.NotEmpty(t, result.Symbol.Doc, "Symbol doc should not be empty")
+		assert.True(t, result.Symbol.Anchor.IsValid(), "Symbol anchor should be valid")
+	}
+}
+
+// validateGoToDefinitionByPositionToolResult validates the structure of a go to definition by position result
+func validateGoToDefinitionByPositionToolResult(t *testing.T, jsonContent string, expectedName string, expectedExternal bool) {
+	var result results.GoToDefinitionByPositionToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal go to definition by position result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.Len(t, result.Definitions, 1, "Should have exactly one definition")
+
+	if len(result.Definitions) > 0 {
+		definition := result.Definitions[0]
+		assert.Equal(t, expectedName, definition.Name, "Definition name should match")
+		assert.Equal(t, expectedExternal, definition.External, "Definition external flag should match")
+		assert.True(t, definition.Anchor.IsValid(), "Definition anchor should be valid")
+		if expectedExternal {
+			assert.True(t, filepath.IsAbs(definition.Location.File), "External definitions should have absolute paths")
+			assert.True(t, definition.ReadOnly, "Standard library definitions should be read-only")
+		}
+	}
+}
+
+// validateGoToTypeDefinitionByPositionToolResult validates the structure of a go to type definition by position result
+func validateGoToTypeDefinitionByPositionToolResult(t *testing.T, jsonContent string, expectedName string, expectedType string) {
+	var result results.GoToTypeDefinitionByPositionToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal go to type definition by position result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.Len(t, result.TypeDefinitions, 1, "Should have exactly one type definition")
+
+	if assert.NotNil(t, result.ExpressionType, "Expression type should be set") {
+		assert.Equal(t, expectedType, result.ExpressionType.Type, "Expression type should match")
+	}
+
+	if len(result.TypeDefinitions) > 0 {
+		typeDefinition := result.TypeDefinitions[0]
+		assert.Equal(t, expectedName, typeDefinition.Name, "Type definition name should match")
+		assert.True(t, typeDefinition.Anchor.IsValid(), "Type definition anchor should be valid")
+		assert.NotEmpty(t, typeDefinition.Signature, "Type definition signature should not be empty")
+	}
+}
+
+// validateGetSignatureHelpToolResult validates the structure of a get signature help result
+func validateGetSignatureHelpToolResult(t *testing.T, jsonContent string, expectedSource results.SignatureSource) {
+	var result results.GetSignatureHelpToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal get signature help result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.Equal(t, expectedSource, result.Source, "Signature source should match")
+	assert.Len(t, result.Signatures, 1, "Should have exactly one signature")
+
+	if len(result.Signatures) > 0 {
+		signature := result.Signatures[0]
+		assert.Equal(t, []results.CallParameter{{Name: "x", Type: "float64"}}, signature.Parameters, "Parameters should match")
+		assert.Equal(t, []results.CallParameter{{Type: "float64"}}, signature.Results, "Results should match")
+		assert.NotEmpty(t, signature.Doc, "Signature doc should not be empty")
+		if expectedSource == results.SignatureSourceCall && assert.NotNil(t, signature.ActiveParameter, "Active parameter should be set") {
+			assert.Equal(t, 0, *signature.ActiveParameter, "Active parameter should be the first parameter")
+		}
+	}
+}
+
+// validateGetCompletionsToolResult validates the structure of a get completions result
+func validateGetCompletionsToolResult(t *testing.T, jsonContent string, expectedLabel string, expectedKind results.CompletionKind) {
+	var result results.GetCompletionsToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal get completions result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.NotEmpty(t, result.Completions, "Should have completions")
+	assert.Equal(t, len(result.Completions), result.Total, "All completions should fit in one page")
+
+	found := false
+	for i, completion := range result.Completions {
+		assert.Equal(t, i+1, completion.Rank, "Completions should be ranked in order")
+		assert.NotEmpty(t, completion.InsertText, "Completion insert text should not be empty")
+		if completion.Label == expectedLabel {
+			found = true
+			assert.Equal(t, expectedKind, completion.Kind, "Completion kind should match")
+			assert.NotEmpty(t, completion.Detail, "Completion detail should not be empty")
+		}
+	}
+	assert.True(t, found, "Should find completion %s", expectedLabel)
+}
+
+// validateListCodeActionsToolResult validates the structure of a list code actions result, returning the first code action
+func validateListCodeActionsToolResult(t *testing.T, jsonContent string, expectedKind string) *results.CodeActionInfo {
+	var result results.ListCodeActionsToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal list code actions result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.NotNil(t, result.Diagnostics, "Diagnostics should not be nil")
+	assert.NotEmpty(t, result.CodeActions, "Should have code actions")
+
+	for _, action := range result.CodeActions {
+		assert.NotEmpty(t, action.Title, "Code action title should not be empty")
+		assert.True(t, strings.HasPrefix(action.Kind, expectedKind), "Code action kind should match")
+	}
+
+	if len(result.CodeActions) == 0 {
+		return nil
+	}
+	return &result.CodeActions[0]
+}
+
+// validateApplyCodeActionToolResult validates the structure of a dry run apply code action result
+func validateApplyCodeActionToolResult(t *testing.T, jsonContent string, expectedTitle string, expectedFile string) {
+	var result results.ApplyCodeActionToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal apply code action result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.False(t, result.Applied, "Dry run should not apply changes")
+	if assert.NotNil(t, result.CodeAction, "Code action should be set") {
+		assert.Equal(t, expectedTitle, result.CodeAction.Title, "Code action title should match")
+	}
+	assert.Len(t, result.FileChanges, 1, "Should change exactly one file")
+
+	if len(result.FileChanges) > 0 {
+		change := result.FileChanges[0]
+		assert.Equal(t, expectedFile, change.File, "Changed file should match")
+		assert.Equal(t, results.FileOperationModify, change.Operation, "File should be modified")
+		assert.Contains(t, change.Diff, "--- a/"+expectedFile, "Diff should have a header")
+		assert.Contains(t, change.Diff, "+", "Diff should add lines")
+	}
+}
+
+// validateFormatFileToolResult validates the structure of a dry run format file result for an already formatted file
+func validateFormatFileToolResult(t *testing.T, jsonContent string) {
+	var result results.FormatFileToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal format file result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.True(t, result.Arguments.DryRun, "Dry run argument should be set")
+	assert.False(t, result.Applied, "Dry run should not apply changes")
+	assert.Equal(t, 1, result.FilesChecked, "Should have formatted exactly one file")
+	assert.Empty(t, result.Failures, "Should not have failures")
+	assert.Empty(t, result.FileChanges, "Formatted file should not change")
+}
+
+// validateExtractToolResult validates the structure of a dry run extract result
+func validateExtractToolResult(t *testing.T, jsonContent string, expectedName string, expectedFile string) {
+	var result results.ExtractToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal extract result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.False(t, result.Applied, "Dry run should not apply changes")
+	assert.NotNil(t, result.CodeAction, "Code action should be set")
+	assert.NotEmpty(t, result.GeneratedName, "Generated name should not be empty")
+	assert.Len(t, result.FileChanges, 1, "Should change exactly one file")
+
+	if len(result.FileChanges) > 0 {
+		change := result.FileChanges[0]
+		assert.Equal(t, expectedFile, change.File, "Changed file should match")
+		assert.Equal(t, results.FileOperationModify, change.Operation, "File should be modified")
+		assert.Contains(t, change.Diff, expectedName, "Diff should use the new name")
+		if result.GeneratedName != expectedName {
+			assert.NotContains(t, change.Diff, "+"+result.GeneratedName, "Generated name should be renamed")
+		}
+	}
+}
+
+// validateInlineCallToolResult validates the structure of a dry run inline call result for a single call site
+func validateInlineCallToolResult(t *testing.T, jsonContent string, expectedFile string) {
+	var result results.InlineCallToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal inline call result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.False(t, result.Applied, "Dry run should not apply changes")
+	assert.Len(t, result.CallSites, 1, "Should have exactly one call site")
+	assert.Len(t, result.FileChanges, 1, "Should change exactly one file")
+
+	if len(result.CallSites) > 0 {
+		site := result.CallSites[0]
+		assert.True(t, site.Inlined, "Call site should be inlined: %s", site.Error)
+		assert.Equal(t, expectedFile, site.Location.File, "Call site file should match")
+	}
+	if len(result.FileChanges) > 0 {
+		change := result.FileChanges[0]
+		assert.Equal(t, expectedFile, change.File, "Changed file should match")
+		assert.Contains(t, change.Diff, "-\tresult := calc.Add(5.0)", "Diff should remove the call")
+	}
+}
+
+// validateChangeSignatureToolResult validates the structure of a dry run change signature result
+func validateChangeSignatureToolResult(t *testing.T, jsonContent string, expectedFunction string, expectedParameters string) {
+	var result results.ChangeSignatureToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal change signature result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.False(t, result.Applied, "Dry run should not apply changes")
+	assert.Equal(t, expectedFunction, result.Function, "Function name should match")
+	assert.Equal(t, expectedParameters, result.NewParameters, "New parameters should match")
+	assert.NotEmpty(t, result.Strategy, "Strategy should not be empty")
+	assert.GreaterOrEqual(t, result.CallSites, 1, "Should have at least one call site")
+	assert.NotEmpty(t, result.FileChanges, "Should change at least one file")
+
+	for _, change := range result.FileChanges {
+		assert.NotEmpty(t, change.File, "Changed file should not be empty")
+		assert.NotEmpty(t, change.Diff, "Diff should not be empty")
+	}
+}
+
+// validateMoveSymbolToolResult validates the structure of a dry run move symbol result within a package
+func validateMoveSymbolToolResult(t *testing.T, jsonContent string, expectedSymbol string, expectedDestination string) {
+	var result results.MoveSymbolToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal move symbol result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.False(t, result.Applied, "Dry run should not apply changes")
+	assert.Equal(t, expectedSymbol, result.Symbol, "Symbol should match")
+	assert.Equal(t, []string{expectedSymbol}, result.MovedDeclarations, "Should move only the symbol")
+	assert.Equal(t, expectedDestination, result.Destination, "Destination should match")
+	assert.Equal(t, result.FromPackage, result.ToPackage, "Symbol should stay in its package")
+	assert.NotEmpty(t, result.WorkspaceEdit.DocumentChanges, "Workspace edit should not be empty")
+
+	operations := make(map[string]results.FileOperation)
+	for _, change := range result.FileChanges {
+		operations[change.File] = change.Operation
+	}
+	assert.Equal(t, results.FileOperationCreate, operations[expectedDestination], "Destination file should be created")
+	assert.Equal(t, results.FileOperationModify, operations["utils.go"], "Source file should be modified")
+}
+
+// validateSafeDeleteToolResult validates the structure of a dry run safe delete result of an unreferenced symbol
+func validateSafeDeleteToolResult(t *testing.T, jsonContent string, expectedSymbol string) {
+	var result results.SafeDeleteToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal safe delete result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.False(t, result.Applied, "Dry run should not apply changes")
+	assert.Equal(t, expectedSymbol, result.Symbol, "Symbol should match")
+	assert.True(t, result.Deleted, "Unreferenced symbol should be deleted")
+	assert.Equal(t, []string{expectedSymbol}, result.DeletedDeclarations, "Should delete only the symbol")
+	assert.Zero(t, result.ReferenceCount, "Symbol should have no references")
+	assert.Empty(t, result.References, "References should only be listed when requested")
+	assert.Len(t, result.FileChanges, 1, "Should change only the declaring file")
+}
+
+// validateFindUnusedSymbolsToolResult validates the structure of a find unused symbols result that reports a symbol, but not an allowlisted one
+func validateFindUnusedSymbolsToolResult(t *testing.T, jsonContent string, expectedSymbol string, allowlistedSymbol string) {
+	var result results.FindUnusedSymbolsToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal find unused symbols result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.Positive(t, result.PackagesChecked, "Should check at least one package")
+	assert.Positive(t, result.SymbolsChecked, "Should check at least one symbol")
+	assert.Equal(t, len(result.UnusedSymbols), result.Total, "All unused symbols should fit on one page")
+
+	var found *results.UnusedSymbol
+	for i, symbol := range result.UnusedSymbols {
+		assert.NotEmpty(t, symbol.Anchor, "Unused symbol should have an anchor")
+		assert.NotEqual(t, "main", symbol.Name, "main should never be reported")
+		assert.NotEqual(t, allowlistedSymbol, symbol.Name, "Allowlisted symbol should not be reported")
+		assert.NotEqual(t, results.SymbolKindMethod, symbol.Kind, "Methods should only be reported by unusedfunc")
+		if symbol.Name == expectedSymbol {
+			found = &result.UnusedSymbols[i]
+		}
+	}
+	if assert.NotNil(t, found, "Should report %s", expectedSymbol) {
+		assert.Equal(t, results.UnusedSourceReferences, found.Source, "Symbol should be found by its references")
+	}
+}
+
+// validateImplementInterfaceToolResult validates the structure of a dry run implement interface result that generates a method
+func validateImplementInterfaceToolResult(t *testing.T, jsonContent string, expectedType string, expectedInterface string, expectedMethod string) {
+	var result results.ImplementInterfaceToolResult
+	err := json.Unmarshal([]byte(jsonContent), &result)
+	assert.NoError(t, err, "Should be able to unmarshal implement interface result")
+
+	// Validate basic structure
+	assert.NotEmpty(t, result.Message, "Message should not be empty")
+	assert.False(t, result.Applied, "Dry run should not apply changes")
+	assert.Equal(t, expectedType, result.Type, "Type should match")
+	assert.Equal(t, expectedInterface, result.Interface, "Interface should match")
+	assert.Contains(t, result.Methods, expectedMethod, "Should generate the missing method")
+	assert.Contains(t, result.Code, "func (", "Code should contain the generated methods")
+	assert.Empty(t, result.Assertion, "Assertion should only be kept when requested")
+	assert.Len(t, result.FileChanges, 1, "Should change only the file declaring the type")
+	assert.NotEmpty(t, result.WorkspaceEdit.DocumentChanges, "Workspace edit should not be empty")
+}
+
 // validateListSymbolsInFileToolResult validates the structure of a list symbols in file result
 func validateListSymbolsInFileToolResult(t *testing.T, jsonContent string) {
 	var result results.ListSymbolsInFileToolResult
@@ -349,7 +727,25 @@ func TestMCPServerIntegration(t *testing.T) {
 			"find_symbol_definitions_by_name",
 			"find_symbol_references_by_anchor",
 			"list_symbols_in_file",
+			"list_symbols_in_package",
+			"list_packages",
+			"get_documentation",
 			"rename_symbol_by_anchor",
+			"go_to_definition_by_position",
+			"go_to_type_definition_by_position",
+			"get_signature_help",
+			"get_completions",
+			"list_code_actions",
+			"apply_code_action",
+			"format_file",
+			"extract_function",
+			"extract_variable",
+			"inline_call",
+			"change_signature",
+			"move_symbol",
+			"safe_delete",
+			"find_unused_symbols",
+			"implement_interface",
 		}
 
 		assert.Len(t, tools, len(expectedTools), "Should have exactly %d tools", len(expectedTools))
@@ -465,6 +861,551 @@ func TestMCPServerIntegration(t *testing.T) {
 		t.Logf("File symbols content: %v", contentStr)
 	})
 
+	t.Run("PackageSymbols", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      11,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "list_symbols_in_package",
+				"arguments": map[string]any{
+					"package": "testdata/example",
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Package symbols should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal package symbols result")
+
+		contentStr := parseToolResult(t, result)
+		validateListSymbolsInPackageToolResult(t, contentStr)
+
+		t.Logf("Package symbols content: %v", contentStr)
+	})
+
+	t.Run("ListPackages", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      12,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "list_packages",
+				"arguments": map[string]any{
+					"pattern": "testdata/...",
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "List packages should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal list packages result")
+
+		contentStr := parseToolResult(t, result)
+		validateListPackagesToolResult(t, contentStr)
+
+		t.Logf("List packages content: %v", contentStr)
+	})
+
+	t.Run("GetDocumentation", func(t *testing.T) {
+		tests := []struct {
+			name              string
+			pkg               string
+			symbol            string
+			expectedSignature string
+			expectedExternal  bool
+		}{
+			{"Workspace symbol", "./testdata/example", "Calculator.Add", "func (c *Calculator) Add(x float64) float64", false},
+			{"Standard library symbol", "strings", "ToUpper", "func ToUpper(s string) string", true},
+		}
+
+		for i, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := MCPRequest{
+					JSONRPC: "2.0",
+					ID:      13 + i,
+					Method:  "tools/call",
+					Params: map[string]any{
+						"name": "get_documentation",
+						"arguments": map[string]any{
+							"package": tt.pkg,
+							"symbol":  tt.symbol,
+						},
+					},
+				}
+
+				resp := server.sendRequest(t, req)
+				assert.Nil(t, resp.Error, "Get documentation should not return an error")
+
+				var result map[string]any
+				err := json.Unmarshal(resp.Result, &result)
+				assert.NoError(t, err, "Should be able to unmarshal get documentation result")
+
+				contentStr := parseToolResult(t, result)
+				validateGetDocumentationToolResult(t, contentStr, tt.expectedSignature, tt.expectedExternal)
+
+				t.Logf("Get documentation content: %v", contentStr)
+			})
+		}
+	})
+
+	t.Run("GoToDefinitionByPosition", func(t *testing.T) {
+		tests := []struct {
+			name             string
+			line             int
+			column           int
+			expectedName     string
+			expectedExternal bool
+		}{
+			{"Workspace definition", 10, 10, "NewCalculator", false}, // NewCalculator call in main.go
+			{"Standard library definition", 11, 6, "Printf", true},   // fmt.Printf call in main.go
+		}
+
+		for i, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := MCPRequest{
+					JSONRPC: "2.0",
+					ID:      8 + i,
+					Method:  "tools/call",
+					Params: map[string]any{
+						"name": "go_to_definition_by_position",
+						"arguments": map[string]any{
+							"file_path": "main.go",
+							"line":      tt.line,
+							"column":    tt.column,
+						},
+					},
+				}
+
+				resp := server.sendRequest(t, req)
+				assert.Nil(t, resp.Error, "Go to definition by position should not return an error")
+
+				var result map[string]any
+				err := json.Unmarshal(resp.Result, &result)
+				assert.NoError(t, err, "Should be able to unmarshal go to definition result")
+
+				contentStr := parseToolResult(t, result)
+				validateGoToDefinitionByPositionToolResult(t, contentStr, tt.expectedName, tt.expectedExternal)
+
+				t.Logf("Go to definition by position content: %v", contentStr)
+			})
+		}
+	})
+
+	t.Run("GoToTypeDefinitionByPosition", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      10,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "go_to_type_definition_by_position",
+				"arguments": map[string]any{
+					"file_path": "main.go",
+					"line":      10, // calc variable in main.go
+					"column":    2,
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Go to type definition by position should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal go to type definition result")
+
+		contentStr := parseToolResult(t, result)
+		validateGoToTypeDefinitionByPositionToolResult(t, contentStr, "Calculator", "*Calculator")
+
+		t.Logf("Go to type definition by position content: %v", contentStr)
+	})
+
+	t.Run("GetSignatureHelp", func(t *testing.T) {
+		tests := []struct {
+			name           string
+			arguments      map[string]any
+			expectedSource results.SignatureSource
+		}{
+			{"Inside call", map[string]any{"file_path": "main.go", "line": 14, "column": 21}, results.SignatureSourceCall},             // calc.Add(5.0) argument in main.go
+			{"Function anchor", map[string]any{"symbol_anchor": "go://testdata/example#Calculator.Add"}, results.SignatureSourceHover}, // Calculator.Add definition
+		}
+
+		for i, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := MCPRequest{
+					JSONRPC: "2.0",
+					ID:      15 + i,
+					Method:  "tools/call",
+					Params: map[string]any{
+						"name":      "get_signature_help",
+						"arguments": tt.arguments,
+					},
+				}
+
+				resp := server.sendRequest(t, req)
+				assert.Nil(t, resp.Error, "Get signature help should not return an error")
+
+				var result map[string]any
+				err := json.Unmarshal(resp.Result, &result)
+				assert.NoError(t, err, "Should be able to unmarshal get signature help result")
+
+				contentStr := parseToolResult(t, result)
+				validateGetSignatureHelpToolResult(t, contentStr, tt.expectedSource)
+
+				t.Logf("Get signature help content: %v", contentStr)
+			})
+		}
+	})
+
+	t.Run("GetCompletions", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      17,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "get_completions",
+				"arguments": map[string]any{
+					"file_path": "main.go",
+					"line":      14,
+					"column":    17, // After calc. in main.go
+					"limit":     100,
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Get completions should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal get completions result")
+
+		contentStr := parseToolResult(t, result)
+		validateGetCompletionsToolResult(t, contentStr, "Add", results.CompletionKindMethod)
+
+		t.Logf("Get completions content: %v", contentStr)
+	})
+
+	t.Run("CodeActions", func(t *testing.T) {
+		mainFile := filepath.Join(workspaceRoot, "main.go")
+		original, err := os.ReadFile(mainFile)
+		assert.NoError(t, err, "Should be able to read main.go")
+
+		// Select calc.Add(5.0) in main.go
+		selection := map[string]any{"file_path": "main.go", "line": 14, "column": 12, "end_line": 14, "end_column": 25}
+
+		listArguments := map[string]any{"kinds": []string{"refactor.extract"}}
+		maps.Copy(listArguments, selection)
+		listReq := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      18,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name":      "list_code_actions",
+				"arguments": listArguments,
+			},
+		}
+
+		resp := server.sendRequest(t, listReq)
+		assert.Nil(t, resp.Error, "List code actions should not return an error")
+
+		var result map[string]any
+		err = json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal list code actions result")
+
+		contentStr := parseToolResult(t, result)
+		action := validateListCodeActionsToolResult(t, contentStr, "refactor.extract")
+		t.Logf("List code actions content: %v", contentStr)
+		if action == nil {
+			return
+		}
+
+		applyArguments := map[string]any{"title": action.Title, "kind": action.Kind, "dry_run": true}
+		maps.Copy(applyArguments, selection)
+		applyReq := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      19,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name":      "apply_code_action",
+				"arguments": applyArguments,
+			},
+		}
+
+		resp = server.sendRequest(t, applyReq)
+		assert.Nil(t, resp.Error, "Apply code action should not return an error")
+
+		err = json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal apply code action result")
+
+		contentStr = parseToolResult(t, result)
+		validateApplyCodeActionToolResult(t, contentStr, action.Title, "main.go")
+		t.Logf("Apply code action content: %v", contentStr)
+
+		current, err := os.ReadFile(mainFile)
+		assert.NoError(t, err, "Should be able to read main.go")
+		assert.Equal(t, string(original), string(current), "Dry run should not modify main.go")
+	})
+
+	t.Run("FormatFile", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      20,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "format_file",
+				"arguments": map[string]any{
+					"file_path": "main.go",
+					"dry_run":   true,
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Format file should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal format file result")
+
+		contentStr := parseToolResult(t, result)
+		validateFormatFileToolResult(t, contentStr)
+
+		t.Logf("Format file content: %v", contentStr)
+	})
+
+	t.Run("ExtractFunction", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      21,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "extract_function",
+				"arguments": map[string]any{
+					"file_path":  "main.go",
+					"line":       48,
+					"column":     2,
+					"end_line":   49,
+					"end_column": 53,
+					"name":       "printFibonacci",
+					"dry_run":    true,
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Extract function should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal extract function result")
+
+		contentStr := parseToolResult(t, result)
+		validateExtractToolResult(t, contentStr, "printFibonacci", "main.go")
+
+		t.Logf("Extract function content: %v", contentStr)
+	})
+
+	t.Run("ExtractVariable", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      22,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "extract_variable",
+				"arguments": map[string]any{
+					"symbol_anchor": "go://main.go#14:12", // calc.Add(5.0)
+					"end_line":      14,
+					"end_column":    25,
+					"name":          "added",
+					"dry_run":       true,
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Extract variable should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal extract variable result")
+
+		contentStr := parseToolResult(t, result)
+		validateExtractToolResult(t, contentStr, "added", "main.go")
+
+		t.Logf("Extract variable content: %v", contentStr)
+	})
+
+	t.Run("InlineCall", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      23,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "inline_call",
+				"arguments": map[string]any{
+					"symbol_anchor": "go://main.go#14:17", // Add in calc.Add(5.0)
+					"dry_run":       true,
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Inline call should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal inline call result")
+
+		contentStr := parseToolResult(t, result)
+		validateInlineCallToolResult(t, contentStr, "main.go")
+
+		t.Logf("Inline call content: %v", contentStr)
+	})
+
+	t.Run("ChangeSignature", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      24,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "change_signature",
+				"arguments": map[string]any{
+					"symbol_anchor": "go://main.go#41:20", // Max in utils.Max(10.5, 7.3)
+					"parameters":    []string{"y", "x"},
+					"dry_run":       true,
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Change signature should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal change signature result")
+
+		contentStr := parseToolResult(t, result)
+		validateChangeSignatureToolResult(t, contentStr, "Max", "(y float64, x float64)")
+
+		t.Logf("Change signature content: %v", contentStr)
+	})
+
+	t.Run("MoveSymbol", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      25,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "move_symbol",
+				"arguments": map[string]any{
+					"symbol_anchor": "go://utils.go#43:6", // Factorial function definition
+					"destination":   "factorial.go",
+					"dry_run":       true,
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Move symbol should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal move symbol result")
+
+		contentStr := parseToolResult(t, result)
+		validateMoveSymbolToolResult(t, contentStr, "Factorial", "factorial.go")
+
+		t.Logf("Move symbol content: %v", contentStr)
+	})
+
+	t.Run("SafeDelete", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      26,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "safe_delete",
+				"arguments": map[string]any{
+					"symbol_anchor": "go://utils.go#26:22", // Round method, which is never called
+					"dry_run":       true,
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Safe delete should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal safe delete result")
+
+		contentStr := parseToolResult(t, result)
+		validateSafeDeleteToolResult(t, contentStr, "(*MathUtils).Round")
+
+		t.Logf("Safe delete content: %v", contentStr)
+	})
+
+	t.Run("FindUnusedSymbols", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      27,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "find_unused_symbols",
+				"arguments": map[string]any{
+					"allowlist": []string{"MathUtils.Min"},
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Find unused symbols should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal find unused symbols result")
+
+		contentStr := parseToolResult(t, result)
+		validateFindUnusedSymbolsToolResult(t, contentStr, "clamp", "(*MathUtils).Min")
+
+		t.Logf("Find unused symbols content: %v", contentStr)
+	})
+
+	t.Run("ImplementInterface", func(t *testing.T) {
+		req := MCPRequest{
+			JSONRPC: "2.0",
+			ID:      28,
+			Method:  "tools/call",
+			Params: map[string]any{
+				"name": "implement_interface",
+				"arguments": map[string]any{
+					"symbol_anchor": "go://calculator.go#6:6", // Calculator struct definition (display coordinates)
+					"interface":     "io.Reader",
+					"dry_run":       true,
+				},
+			},
+		}
+
+		resp := server.sendRequest(t, req)
+		assert.Nil(t, resp.Error, "Implement interface should not return an error")
+
+		var result map[string]any
+		err := json.Unmarshal(resp.Result, &result)
+		assert.NoError(t, err, "Should be able to unmarshal implement interface result")
+
+		contentStr := parseToolResult(t, result)
+		validateImplementInterfaceToolResult(t, contentStr, "Calculator", "io.Reader", "Read")
+
+		t.Logf("Implement interface content: %v", contentStr)
+	})
+
 	t.Run("RenameSymbolByAnchor", func(t *testing.T) {
 		// Test rename symbol by anchor using Calculator struct anchor
 		req := MCPRequest{
@@ -7,6 +7,8 @@ import (
 	"io"
 	"log/slog"
 	"os/exec"
+	"sync"
+	"unicode/utf16"
 
 	"github.com/averycrespi/gopls-mcp/internal/transport"
 	"github.com/averycrespi/gopls-mcp/pkg/project"
@@ -19,12 +21,34 @@ const (
 
 var _ types.Client = &GoplsClient{}
 
+// goplsSettings are sent to gopls on initialization and in response to workspace/configuration requests.
+// They are never changed at runtime: gopls applies settings asynchronously and reloads its view on every change,
+// so tools filter workspace symbols themselves instead of switching the symbol matcher per query.
+// See: https://github.com/golang/tools/blob/master/gopls/doc/settings.md
+var goplsSettings = map[string]any{
+	"symbolMatcher": "FastFuzzy",
+	"symbolStyle":   "Dynamic",
+	"symbolScope":   "all",
+}
+
 // GoplsClient implements the Client interface for the Gopls LSP server
 type GoplsClient struct {
 	goplsPath string
 	cmd       *exec.Cmd
 	stderr    io.ReadCloser
 	transport types.Transport
+
+	// Diagnostics published by gopls with textDocument/publishDiagnostics, by document URI
+	diagnostics        map[string][]types.Diagnostic
+	published          map[string]bool // Documents whose diagnostics were published since they were last opened
+	diagnosticsChanged chan struct{}   // Closed and replaced whenever diagnostics are published
+	diagnosticsMu      sync.Mutex
+
+	// Workspace edits sent by gopls with workspace/applyEdit while a command is executed
+	commandEdits   []types.WorkspaceEdit
+	capturingEdits bool
+	editsMu        sync.Mutex
+	commandMu      sync.Mutex // Serializes commands, so that edits are captured for the right command
 }
 
 // NewGoplsClient creates a new Gopls client
@@ -36,7 +60,10 @@ func NewGoplsClient(goplsPath string) *GoplsClient {
 	slog.Debug("Creating new Gopls client", "gopls_path", goplsPath)
 
 	return &GoplsClient{
-		goplsPath: goplsPath,
+		goplsPath:          goplsPath,
+		diagnostics:        make(map[string][]types.Diagnostic),
+		published:          make(map[string]bool),
+		diagnosticsChanged: make(chan struct{}),
 	}
 }
 
@@ -63,6 +90,9 @@ func (c *GoplsClient) Start(ctx context.Context, workspaceRoot string) error {
 
 	c.stderr = stderr
 	c.transport = transport.NewJsonRpcTransport(stdin, stdout)
+	c.transport.HandleRequest("workspace/configuration", c.handleConfiguration)
+	c.transport.HandleRequest("workspace/applyEdit", c.handleApplyEdit)
+	c.transport.HandleNotification("textDocument/publishDiagnostics", c.handlePublishDiagnostics)
 
 	if err := c.cmd.Start(); err != nil {
 		return fmt.Errorf("failed to start gopls command: %w", err)
@@ -91,15 +121,61 @@ func (c *GoplsClient) initialize(rootURI string) error {
 			"name":    project.Name,
 			"version": project.Version,
 		},
-		"rootUri": rootURI,
+		"rootUri":               rootURI,
+		"initializationOptions": goplsSettings,
 		"capabilities": map[string]any{
+			"workspace": map[string]any{
+				"configuration": true,
+				"applyEdit":     true,
+				"workspaceEdit": map[string]any{
+					"documentChanges":    true,
+					"resourceOperations": []string{"create", "rename", "delete"},
+				},
+			},
 			"textDocument": map[string]any{
 				"documentSymbol": map[string]any{
 					"hierarchicalDocumentSymbolSupport": true,
 				},
+				"signatureHelp": map[string]any{
+					"signatureInformation": map[string]any{
+						"documentationFormat": []string{"plaintext"},
+						"parameterInformation": map[string]any{
+							"labelOffsetSupport": true,
+						},
+						"activeParameterSupport": true,
+					},
+				},
+				"completion": map[string]any{
+					"completionItem": map[string]any{
+						"snippetSupport":      false,
+						"documentationFormat": []string{"plaintext"},
+						"deprecatedSupport":   true,
+						"resolveSupport": map[string]any{
+							"properties": []string{"documentation", "detail", "additionalTextEdits"},
+						},
+					},
+				},
 				"rename": map[string]any{
 					"prepareSupport": true,
 				},
+				"codeAction": map[string]any{
+					"codeActionLiteralSupport": map[string]any{
+						"codeActionKind": map[string]any{
+							"valueSet": []string{"quickfix", "refactor", "refactor.extract", "refactor.inline", "refactor.rewrite", "source", "source.organizeImports", "source.fixAll"},
+						},
+					},
+					"isPreferredSupport": true,
+					"disabledSupport":    true,
+					"dataSupport":        true,
+					"resolveSupport": map[string]any{
+						"properties": []string{"edit"},
+					},
+				},
+				"publishDiagnostics": map[string]any{
+					"tagSupport": map[string]any{
+						"valueSet": []int{1, 2},
+					},
+				},
 			},
 		},
 	}
@@ -116,6 +192,76 @@ func (c *GoplsClient) initialize(rootURI string) error {
 	return nil
 }
 
+// handleConfiguration responds to workspace/configuration requests with the fixed settings for every requested item
+func (c *GoplsClient) handleConfiguration(params json.RawMessage) (any, error) {
+	var configParams struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(params, &configParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal configuration params: %w", err)
+	}
+
+	configs := make([]map[string]any, len(configParams.Items))
+	for i := range configs {
+		configs[i] = goplsSettings
+	}
+
+	slog.Debug("Responding to configuration request", "item_count", len(configs))
+	return configs, nil
+}
+
+// handleApplyEdit responds to workspace/applyEdit requests, capturing the edit if a command is being executed.
+// Captured edits are reported as applied, since the tools that execute commands apply the edits themselves.
+func (c *GoplsClient) handleApplyEdit(params json.RawMessage) (any, error) {
+	var applyParams struct {
+		Label string              `json:"label"`
+		Edit  types.WorkspaceEdit `json:"edit"`
+	}
+	if err := json.Unmarshal(params, &applyParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal apply edit params: %w", err)
+	}
+
+	c.editsMu.Lock()
+	defer c.editsMu.Unlock()
+
+	if !c.capturingEdits {
+		slog.Debug("Rejecting workspace edit outside of a command", "label", applyParams.Label)
+		return map[string]any{
+			"applied":       false,
+			"failureReason": "workspace edits are only accepted while executing a command",
+		}, nil
+	}
+
+	slog.Debug("Captured workspace edit", "label", applyParams.Label)
+	c.commandEdits = append(c.commandEdits, applyParams.Edit)
+	return map[string]any{"applied": true}, nil
+}
+
+// handlePublishDiagnostics stores the diagnostics published for a document, replacing any previous diagnostics
+func (c *GoplsClient) handlePublishDiagnostics(params json.RawMessage) {
+	var diagnosticsParams struct {
+		URI         string             `json:"uri"`
+		Diagnostics []types.Diagnostic `json:"diagnostics"`
+	}
+	if err := json.Unmarshal(params, &diagnosticsParams); err != nil {
+		slog.Error("Failed to unmarshal published diagnostics", "error", err)
+		return
+	}
+
+	c.diagnosticsMu.Lock()
+	defer c.diagnosticsMu.Unlock()
+
+	if len(diagnosticsParams.Diagnostics) == 0 {
+		delete(c.diagnostics, diagnosticsParams.URI)
+	} else {
+		c.diagnostics[diagnosticsParams.URI] = diagnosticsParams.Diagnostics
+	}
+	c.published[diagnosticsParams.URI] = true
+	close(c.diagnosticsChanged)
+	c.diagnosticsChanged = make(chan struct{})
+	slog.Debug("Received diagnostics", "uri", diagnosticsParams.URI, "count", len(diagnosticsParams.Diagnostics))
+}
+
 func (c *GoplsClient) Stop(ctx context.Context) error {
 	_, err := c.transport.SendRequest("shutdown", nil)
 	if err != nil {
@@ -157,30 +303,56 @@ func (c *GoplsClient) GoToDefinition(ctx context.Context, uri string, position t
 		return nil, fmt.Errorf("failed to get definition: %w", err)
 	}
 
-	// LSP definition response can be null, Location, or Location[]
-	var rawResponse json.RawMessage
-	if err := json.Unmarshal(response, &rawResponse); err != nil {
+	locations, err := unmarshalLocations(response)
+	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal definition response: %w", err)
 	}
 
+	slog.Debug("Found symbol definitions", "count", len(locations), "uri", uri)
+	return locations, nil
+}
+
+func (c *GoplsClient) GoToTypeDefinition(ctx context.Context, uri string, position types.Position) ([]types.Location, error) {
+	slog.Debug("Getting type definition", "uri", uri, "line", position.Line, "character", position.Character)
+
+	params := map[string]any{
+		"textDocument": map[string]any{
+			"uri": uri,
+		},
+		"position": position,
+	}
+
+	response, err := c.transport.SendRequest("textDocument/typeDefinition", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get type definition: %w", err)
+	}
+
+	locations, err := unmarshalLocations(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal type definition response: %w", err)
+	}
+
+	slog.Debug("Found type definitions", "count", len(locations), "uri", uri)
+	return locations, nil
+}
+
+// unmarshalLocations unmarshals an LSP response that can be null, Location, or Location[]
+func unmarshalLocations(response json.RawMessage) ([]types.Location, error) {
 	// Handle null response
-	if string(rawResponse) == "null" {
-		slog.Debug("No definitions found", "uri", uri)
+	if len(response) == 0 || string(response) == "null" {
 		return []types.Location{}, nil
 	}
 
 	// Try to unmarshal as array first
 	var locations []types.Location
-	if err := json.Unmarshal(rawResponse, &locations); err != nil {
+	if err := json.Unmarshal(response, &locations); err != nil {
 		// If that fails, try to unmarshal as single location
 		var location types.Location
-		if err := json.Unmarshal(rawResponse, &location); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal definition response: %w", err)
+		if err := json.Unmarshal(response, &location); err != nil {
+			return nil, err
 		}
 		locations = []types.Location{location}
 	}
-
-	slog.Debug("Found symbol definitions", "count", len(locations), "uri", uri)
 	return locations, nil
 }
 
@@ -223,6 +395,42 @@ func (c *GoplsClient) FindReferences(ctx context.Context, uri string, position t
 	return locations, nil
 }
 
+func (c *GoplsClient) GetDocumentHighlights(ctx context.Context, uri string, position types.Position) ([]types.DocumentHighlight, error) {
+	slog.Debug("Getting document highlights", "uri", uri, "line", position.Line, "character", position.Character)
+
+	params := map[string]any{
+		"textDocument": map[string]any{
+			"uri": uri,
+		},
+		"position": position,
+	}
+
+	response, err := c.transport.SendRequest("textDocument/documentHighlight", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document highlights: %w", err)
+	}
+
+	// LSP documentHighlight response can be null or DocumentHighlight[]
+	var rawResponse json.RawMessage
+	if err := json.Unmarshal(response, &rawResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal document highlight response: %w", err)
+	}
+
+	// Handle null response
+	if string(rawResponse) == "null" {
+		slog.Debug("No document highlights found", "uri", uri)
+		return []types.DocumentHighlight{}, nil
+	}
+
+	var highlights []types.DocumentHighlight
+	if err := json.Unmarshal(rawResponse, &highlights); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal document highlight response: %w", err)
+	}
+
+	slog.Debug("Found document highlights", "count", len(highlights), "uri", uri)
+	return highlights, nil
+}
+
 func (c *GoplsClient) GetHoverInfo(ctx context.Context, uri string, position types.Position) (string, error) {
 	params := map[string]any{
 		"textDocument": map[string]any{
@@ -256,8 +464,200 @@ func (c *GoplsClient) GetHoverInfo(ctx context.Context, uri string, position typ
 	return fmt.Sprintf("%v", hover.Contents), nil
 }
 
+func (c *GoplsClient) GetSignatureHelp(ctx context.Context, uri string, position types.Position) (*types.SignatureHelp, error) {
+	slog.Debug("Getting signature help", "uri", uri, "line", position.Line, "character", position.Character)
+
+	params := map[string]any{
+		"textDocument": map[string]any{
+			"uri": uri,
+		},
+		"position": position,
+	}
+
+	response, err := c.transport.SendRequest("textDocument/signatureHelp", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signature help: %w", err)
+	}
+
+	// Handle null response, e.g. when the position isn't inside a call
+	if len(response) == 0 || string(response) == "null" {
+		slog.Debug("No signature help found", "uri", uri)
+		return &types.SignatureHelp{}, nil
+	}
+
+	var rawHelp struct {
+		Signatures []struct {
+			Label         string          `json:"label"`
+			Documentation json.RawMessage `json:"documentation"`
+			Parameters    []struct {
+				Label         json.RawMessage `json:"label"`
+				Documentation json.RawMessage `json:"documentation"`
+			} `json:"parameters"`
+			ActiveParameter *int `json:"activeParameter"`
+		} `json:"signatures"`
+		ActiveSignature int  `json:"activeSignature"`
+		ActiveParameter *int `json:"activeParameter"`
+	}
+	if err := json.Unmarshal(response, &rawHelp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signature help response: %w", err)
+	}
+
+	help := &types.SignatureHelp{
+		Signatures:      make([]types.SignatureInformation, 0, len(rawHelp.Signatures)),
+		ActiveSignature: rawHelp.ActiveSignature,
+		ActiveParameter: rawHelp.ActiveParameter,
+	}
+	for _, rawSignature := range rawHelp.Signatures {
+		signature := types.SignatureInformation{
+			Label:           rawSignature.Label,
+			Documentation:   unmarshalDocumentation(rawSignature.Documentation),
+			ActiveParameter: rawSignature.ActiveParameter,
+		}
+		for _, rawParameter := range rawSignature.Parameters {
+			signature.Parameters = append(signature.Parameters, types.ParameterInformation{
+				Label:         unmarshalParameterLabel(rawParameter.Label, rawSignature.Label),
+				Documentation: unmarshalDocumentation(rawParameter.Documentation),
+			})
+		}
+		help.Signatures = append(help.Signatures, signature)
+	}
+
+	slog.Debug("Found signatures", "count", len(help.Signatures), "uri", uri)
+	return help, nil
+}
+
+// unmarshalDocumentation unmarshals LSP documentation that can be a string or MarkupContent
+func unmarshalDocumentation(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+
+	var markup struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &markup); err == nil {
+		return markup.Value
+	}
+	return ""
+}
+
+// unmarshalParameterLabel unmarshals an LSP parameter label that can be a string, or UTF-16 offsets into the signature label
+func unmarshalParameterLabel(raw json.RawMessage, signatureLabel string) string {
+	var label string
+	if err := json.Unmarshal(raw, &label); err == nil {
+		return label
+	}
+
+	var offsets [2]int
+	if err := json.Unmarshal(raw, &offsets); err != nil {
+		return ""
+	}
+	units := utf16.Encode([]rune(signatureLabel))
+	if offsets[0] < 0 || offsets[0] > offsets[1] || offsets[1] > len(units) {
+		return ""
+	}
+	return string(utf16.Decode(units[offsets[0]:offsets[1]]))
+}
+
+func (c *GoplsClient) GetCompletions(ctx context.Context, uri string, position types.Position) (*types.CompletionList, error) {
+	slog.Debug("Getting completions", "uri", uri, "line", position.Line, "character", position.Character)
+
+	params := map[string]any{
+		"textDocument": map[string]any{
+			"uri": uri,
+		},
+		"position": position,
+	}
+
+	response, err := c.transport.SendRequest("textDocument/completion", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completions: %w", err)
+	}
+
+	// Handle null response, e.g. when there are no candidates at the position
+	if len(response) == 0 || string(response) == "null" {
+		slog.Debug("No completions found", "uri", uri)
+		return &types.CompletionList{}, nil
+	}
+
+	// The response can be a CompletionList, or a bare array of CompletionItems
+	var rawList struct {
+		IsIncomplete bool                `json:"isIncomplete"`
+		Items        []rawCompletionItem `json:"items"`
+	}
+	if err := json.Unmarshal(response, &rawList.Items); err != nil {
+		if err := json.Unmarshal(response, &rawList); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal completion response: %w", err)
+		}
+	}
+
+	list := &types.CompletionList{
+		IsIncomplete: rawList.IsIncomplete,
+		Items:        make([]types.CompletionItem, 0, len(rawList.Items)),
+	}
+	for _, rawItem := range rawList.Items {
+		list.Items = append(list.Items, rawItem.normalize())
+	}
+
+	slog.Debug("Found completions", "count", len(list.Items), "incomplete", list.IsIncomplete, "uri", uri)
+	return list, nil
+}
+
+func (c *GoplsClient) ResolveCompletionItem(ctx context.Context, item types.CompletionItem) (*types.CompletionItem, error) {
+	slog.Debug("Resolving completion item", "label", item.Label)
+
+	response, err := c.transport.SendRequest("completionItem/resolve", item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve completion item: %w", err)
+	}
+
+	var rawItem rawCompletionItem
+	if err := json.Unmarshal(response, &rawItem); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal completion item: %w", err)
+	}
+
+	resolved := rawItem.normalize()
+	return &resolved, nil
+}
+
+// rawCompletionItem represents a completion item as sent by the language server
+type rawCompletionItem struct {
+	types.CompletionItem
+	Documentation json.RawMessage `json:"documentation"`
+	TextEdit      json.RawMessage `json:"textEdit"`
+}
+
+// normalize converts the documentation to a plain string, and an InsertReplaceEdit to a TextEdit of its replace range
+func (r rawCompletionItem) normalize() types.CompletionItem {
+	item := r.CompletionItem
+	item.Documentation = unmarshalDocumentation(r.Documentation)
+	item.TextEdit = nil
+
+	if len(r.TextEdit) > 0 && string(r.TextEdit) != "null" {
+		var edit struct {
+			Range   *types.Range `json:"range"`
+			Replace *types.Range `json:"replace"`
+			NewText string       `json:"newText"`
+		}
+		if err := json.Unmarshal(r.TextEdit, &edit); err == nil {
+			switch {
+			case edit.Range != nil:
+				item.TextEdit = &types.TextEdit{Range: *edit.Range, NewText: edit.NewText}
+			case edit.Replace != nil:
+				item.TextEdit = &types.TextEdit{Range: *edit.Replace, NewText: edit.NewText}
+			}
+		}
+	}
+	return item
+}
+
 func (c *GoplsClient) FuzzyFindSymbol(ctx context.Context, query string) ([]types.SymbolInformation, error) {
-	slog.Debug("Fuzzy finding symbols", "query", query)
+	slog.Debug("Finding workspace symbols", "query", query)
 
 	params := map[string]any{
 		"query": query,
@@ -289,7 +689,9 @@ func (c *GoplsClient) FuzzyFindSymbol(ctx context.Context, query string) ([]type
 	return symbols, nil
 }
 
-func (c *GoplsClient) FormatDocument(ctx context.Context, uri string) ([]json.RawMessage, error) {
+func (c *GoplsClient) FormatDocument(ctx context.Context, uri string) ([]types.TextEdit, error) {
+	slog.Debug("Formatting document", "uri", uri)
+
 	params := map[string]any{
 		"textDocument": map[string]any{
 			"uri": uri,
@@ -305,14 +707,78 @@ func (c *GoplsClient) FormatDocument(ctx context.Context, uri string) ([]json.Ra
 		return nil, fmt.Errorf("failed to format document: %w", err)
 	}
 
-	var edits []json.RawMessage
+	// Handle null response, e.g. when the document is already formatted
+	if len(response) == 0 || string(response) == "null" {
+		slog.Debug("No formatting edits", "uri", uri)
+		return []types.TextEdit{}, nil
+	}
+
+	var edits []types.TextEdit
 	if err := json.Unmarshal(response, &edits); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal formatting response: %w", err)
 	}
 
+	slog.Debug("Found formatting edits", "count", len(edits), "uri", uri)
 	return edits, nil
 }
 
+// OpenDocument opens a document with the given content, which gopls uses instead of the file on disk until the document is closed
+func (c *GoplsClient) OpenDocument(ctx context.Context, uri string, text string) error {
+	slog.Debug("Opening document", "uri", uri)
+
+	// gopls always publishes the diagnostics of newly opened documents, which WaitForDiagnostics waits for
+	c.diagnosticsMu.Lock()
+	delete(c.published, uri)
+	c.diagnosticsMu.Unlock()
+
+	params := map[string]any{
+		"textDocument": map[string]any{
+			"uri":        uri,
+			"languageId": "go",
+			"version":    1,
+			"text":       text,
+		},
+	}
+	if err := c.transport.SendNotification("textDocument/didOpen", params); err != nil {
+		return fmt.Errorf("failed to send document open notification: %w", err)
+	}
+	return nil
+}
+
+// ChangeDocument replaces the content of an open document. Versions must increase with every change.
+func (c *GoplsClient) ChangeDocument(ctx context.Context, uri string, version int, text string) error {
+	slog.Debug("Changing document", "uri", uri, "version", version)
+
+	params := map[string]any{
+		"textDocument": map[string]any{
+			"uri":     uri,
+			"version": version,
+		},
+		"contentChanges": []map[string]any{
+			{"text": text}, // A change without a range replaces the whole document
+		},
+	}
+	if err := c.transport.SendNotification("textDocument/didChange", params); err != nil {
+		return fmt.Errorf("failed to send document change notification: %w", err)
+	}
+	return nil
+}
+
+// CloseDocument closes an open document, so that gopls uses the file on disk again
+func (c *GoplsClient) CloseDocument(ctx context.Context, uri string) error {
+	slog.Debug("Closing document", "uri", uri)
+
+	params := map[string]any{
+		"textDocument": map[string]any{
+			"uri": uri,
+		},
+	}
+	if err := c.transport.SendNotification("textDocument/didClose", params); err != nil {
+		return fmt.Errorf("failed to send document close notification: %w", err)
+	}
+	return nil
+}
+
 func (c *GoplsClient) PrepareRename(ctx context.Context, uri string, position types.Position) (*types.PrepareRenameResult, error) {
 	slog.Debug("Preparing rename", "uri", uri, "line", position.Line, "character", position.Character)
 
@@ -412,6 +878,208 @@ func (c *GoplsClient) RenameSymbol(ctx context.Context, uri string, position typ
 	return &workspaceEdit, nil
 }
 
+// ExecuteCommand executes a gopls command with workspace/executeCommand, returning the raw result.
+// See: https://github.com/golang/tools/blob/master/gopls/doc/commands.md
+func (c *GoplsClient) ExecuteCommand(ctx context.Context, command string, arguments ...any) (json.RawMessage, error) {
+	slog.Debug("Executing command", "command", command)
+
+	if arguments == nil {
+		arguments = []any{}
+	}
+	params := map[string]any{
+		"command":   command,
+		"arguments": arguments,
+	}
+
+	response, err := c.transport.SendRequest("workspace/executeCommand", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute command %s: %w", command, err)
+	}
+
+	return response, nil
+}
+
+// ExecuteCommandEdits executes a command, returning the workspace edits gopls requested with workspace/applyEdit while executing it
+func (c *GoplsClient) ExecuteCommandEdits(ctx context.Context, command types.Command) ([]types.WorkspaceEdit, error) {
+	c.commandMu.Lock()
+	defer c.commandMu.Unlock()
+
+	c.editsMu.Lock()
+	c.commandEdits = nil
+	c.capturingEdits = true
+	c.editsMu.Unlock()
+
+	defer func() {
+		c.editsMu.Lock()
+		c.commandEdits = nil
+		c.capturingEdits = false
+		c.editsMu.Unlock()
+	}()
+
+	arguments := make([]any, 0, len(command.Arguments))
+	for _, argument := range command.Arguments {
+		arguments = append(arguments, argument)
+	}
+	if _, err := c.ExecuteCommand(ctx, command.Command, arguments...); err != nil {
+		return nil, err
+	}
+
+	// gopls waits for the responses to its workspace/applyEdit requests before completing the command
+	c.editsMu.Lock()
+	edits := c.commandEdits
+	c.editsMu.Unlock()
+
+	slog.Debug("Captured command edits", "command", command.Command, "count", len(edits))
+	return edits, nil
+}
+
+func (c *GoplsClient) GetDiagnostics(ctx context.Context, uri string) ([]types.Diagnostic, error) {
+	c.diagnosticsMu.Lock()
+	defer c.diagnosticsMu.Unlock()
+
+	diagnostics := append([]types.Diagnostic(nil), c.diagnostics[uri]...)
+	slog.Debug("Found diagnostics", "count", len(diagnostics), "uri", uri)
+	return diagnostics, nil
+}
+
+// WaitForDiagnostics waits until gopls has published the diagnostics of a document since it was last opened
+// (or at all, if it was never opened), and returns them
+func (c *GoplsClient) WaitForDiagnostics(ctx context.Context, uri string) ([]types.Diagnostic, error) {
+	for {
+		c.diagnosticsMu.Lock()
+		if c.published[uri] {
+			diagnostics := append([]types.Diagnostic(nil), c.diagnostics[uri]...)
+			c.diagnosticsMu.Unlock()
+			slog.Debug("Found published diagnostics", "count", len(diagnostics), "uri", uri)
+			return diagnostics, nil
+		}
+		changed := c.diagnosticsChanged
+		c.diagnosticsMu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("diagnostics of %s weren't published: %w", uri, ctx.Err())
+		}
+	}
+}
+
+func (c *GoplsClient) GetCodeActions(ctx context.Context, uri string, rng types.Range, actionContext types.CodeActionContext) ([]types.CodeAction, error) {
+	slog.Debug("Getting code actions", "uri", uri, "range", rng, "only", actionContext.Only)
+
+	if actionContext.Diagnostics == nil {
+		actionContext.Diagnostics = []types.Diagnostic{}
+	}
+	params := map[string]any{
+		"textDocument": map[string]any{
+			"uri": uri,
+		},
+		"range":   rng,
+		"context": actionContext,
+	}
+
+	response, err := c.transport.SendRequest("textDocument/codeAction", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code actions: %w", err)
+	}
+
+	// Handle null response
+	if len(response) == 0 || string(response) == "null" {
+		slog.Debug("No code actions found", "uri", uri)
+		return []types.CodeAction{}, nil
+	}
+
+	// The response is an array of CodeActions and bare Commands, which have a string command
+	var rawActions []json.RawMessage
+	if err := json.Unmarshal(response, &rawActions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal code action response: %w", err)
+	}
+
+	actions := make([]types.CodeAction, 0, len(rawActions))
+	for _, rawAction := range rawActions {
+		var command types.Command
+		if err := json.Unmarshal(rawAction, &command); err == nil && command.Command != "" {
+			actions = append(actions, types.CodeAction{Title: command.Title, Command: &command})
+			continue
+		}
+
+		var action types.CodeAction
+		if err := json.Unmarshal(rawAction, &action); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal code action: %w", err)
+		}
+		actions = append(actions, action)
+	}
+
+	slog.Debug("Found code actions", "count", len(actions), "uri", uri)
+	return actions, nil
+}
+
+func (c *GoplsClient) ResolveCodeAction(ctx context.Context, action types.CodeAction) (*types.CodeAction, error) {
+	slog.Debug("Resolving code action", "title", action.Title, "kind", action.Kind)
+
+	response, err := c.transport.SendRequest("codeAction/resolve", action)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve code action: %w", err)
+	}
+
+	var resolved types.CodeAction
+	if err := json.Unmarshal(response, &resolved); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resolved code action: %w", err)
+	}
+
+	return &resolved, nil
+}
+
+func (c *GoplsClient) DidChangeWatchedFiles(ctx context.Context, changes []types.FileEvent) error {
+	slog.Debug("Notifying file changes", "count", len(changes))
+
+	params := map[string]any{
+		"changes": changes,
+	}
+	if err := c.transport.SendNotification("workspace/didChangeWatchedFiles", params); err != nil {
+		return fmt.Errorf("failed to send watched files change notification: %w", err)
+	}
+	return nil
+}
+
+func (c *GoplsClient) ListKnownPackages(ctx context.Context, uri string) ([]string, error) {
+	slog.Debug("Listing known packages", "uri", uri)
+
+	response, err := c.ExecuteCommand(ctx, "gopls.list_known_packages", map[string]any{"URI": uri})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Packages []string `json:"Packages"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal known packages response: %w", err)
+	}
+
+	slog.Debug("Found known packages", "count", len(result.Packages), "uri", uri)
+	return result.Packages, nil
+}
+
+func (c *GoplsClient) GetPackages(ctx context.Context, args types.PackagesArgs) (*types.PackagesResult, error) {
+	slog.Debug("Getting packages", "files", args.Files, "recursive", args.Recursive, "mode", args.Mode)
+
+	response, err := c.ExecuteCommand(ctx, "gopls.packages", args)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.PackagesResult
+	if len(response) > 0 && string(response) != "null" {
+		if err := json.Unmarshal(response, &result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal packages response: %w", err)
+		}
+	}
+
+	slog.Debug("Found packages", "count", len(result.Packages))
+	return &result, nil
+}
+
 func (c *GoplsClient) GetDocumentSymbols(ctx context.Context, uri string) ([]types.DocumentSymbol, error) {
 	slog.Debug("Getting document symbols", "uri", uri)
 
@@ -0,0 +1,24 @@
+package results
+
+// ApplyCodeActionToolResult represents the result of the apply_code_action tool
+type ApplyCodeActionToolResult struct {
+	Message     string                  `json:"message"`
+	Arguments   ApplyCodeActionToolArgs `json:"arguments"`
+	CodeAction  *CodeActionInfo         `json:"code_action,omitempty"` // The applied code action, if one matched
+	Applied     bool                    `json:"applied"`               // Whether the changes were written to disk
+	FileChanges []FileChange            `json:"file_changes"`
+}
+
+// ApplyCodeActionToolArgs represents the arguments for the apply code action tool
+type ApplyCodeActionToolArgs struct {
+	SymbolAnchor string `json:"symbol_anchor,omitempty"`
+	FilePath     string `json:"file_path,omitempty"`
+	Line         int    `json:"line,omitempty"`
+	Column       int    `json:"column,omitempty"`
+	EndLine      int    `json:"end_line,omitempty"`
+	EndColumn    int    `json:"end_column,omitempty"`
+	EndAnchor    string `json:"end_anchor,omitempty"`
+	Title        string `json:"title"`
+	Kind         string `json:"kind,omitempty"`
+	DryRun       bool   `json:"dry_run,omitempty"`
+}
@@ -0,0 +1,33 @@
+package results
+
+// SignatureChangeStrategy represents how a signature change was computed
+type SignatureChangeStrategy string
+
+const (
+	SignatureChangeStrategyCodeAction SignatureChangeStrategy = "code_action" // A gopls code action, like removing an unused parameter
+	SignatureChangeStrategyReferences SignatureChangeStrategy = "references"  // Edits of the declaration and of the call sites found by its references
+)
+
+// ChangeSignatureToolResult represents the result of the change_signature tool
+type ChangeSignatureToolResult struct {
+	Message       string                  `json:"message"`
+	Arguments     ChangeSignatureToolArgs `json:"arguments"`
+	Function      string                  `json:"function"`
+	Declaration   SymbolLocation          `json:"declaration"`
+	OldParameters string                  `json:"old_parameters"` // Parameter list before the change, like "(a int, b string)"
+	NewParameters string                  `json:"new_parameters"` // Parameter list after the change
+	Strategy      SignatureChangeStrategy `json:"strategy"`
+	CallSites     int                     `json:"call_sites"` // Number of references to the function, excluding its declaration
+	Applied       bool                    `json:"applied"`    // Whether the changes were written to disk
+	FileChanges   []FileChange            `json:"file_changes"`
+}
+
+// ChangeSignatureToolArgs represents the arguments for the change signature tool
+type ChangeSignatureToolArgs struct {
+	SymbolAnchor string   `json:"symbol_anchor,omitempty"`
+	FilePath     string   `json:"file_path,omitempty"`
+	Line         int      `json:"line,omitempty"`
+	Column       int      `json:"column,omitempty"`
+	Parameters   []string `json:"parameters"`
+	DryRun       bool     `json:"dry_run,omitempty"`
+}
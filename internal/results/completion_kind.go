@@ -0,0 +1,73 @@
+package results
+
+// CompletionKind represents the type of a completion candidate as an enum
+type CompletionKind string
+
+const (
+	CompletionKindText          CompletionKind = "text"
+	CompletionKindMethod        CompletionKind = "method"
+	CompletionKindFunction      CompletionKind = "function"
+	CompletionKindConstructor   CompletionKind = "constructor"
+	CompletionKindField         CompletionKind = "field"
+	CompletionKindVariable      CompletionKind = "variable"
+	CompletionKindClass         CompletionKind = "class"
+	CompletionKindInterface     CompletionKind = "interface"
+	CompletionKindModule        CompletionKind = "module"
+	CompletionKindProperty      CompletionKind = "property"
+	CompletionKindUnit          CompletionKind = "unit"
+	CompletionKindValue         CompletionKind = "value"
+	CompletionKindEnum          CompletionKind = "enum"
+	CompletionKindKeyword       CompletionKind = "keyword"
+	CompletionKindSnippet       CompletionKind = "snippet"
+	CompletionKindColor         CompletionKind = "color"
+	CompletionKindFile          CompletionKind = "file"
+	CompletionKindReference     CompletionKind = "reference"
+	CompletionKindFolder        CompletionKind = "folder"
+	CompletionKindEnumMember    CompletionKind = "enum_member"
+	CompletionKindConstant      CompletionKind = "constant"
+	CompletionKindStruct        CompletionKind = "struct"
+	CompletionKindEvent         CompletionKind = "event"
+	CompletionKindOperator      CompletionKind = "operator"
+	CompletionKindTypeParameter CompletionKind = "type_parameter"
+
+	// This isn't a valid completion kind, but it's used to indicate that the completion kind is unknown
+	CompletionKindUnknown CompletionKind = "unknown"
+)
+
+// See: https://microsoft.github.io/language-server-protocol/specifications/lsp/3.17/specification/#completionItemKind
+var completionKindMap = map[int]CompletionKind{
+	1:  CompletionKindText,
+	2:  CompletionKindMethod,
+	3:  CompletionKindFunction,
+	4:  CompletionKindConstructor,
+	5:  CompletionKindField,
+	6:  CompletionKindVariable,
+	7:  CompletionKindClass,
+	8:  CompletionKindInterface,
+	9:  CompletionKindModule,
+	10: CompletionKindProperty,
+	11: CompletionKindUnit,
+	12: CompletionKindValue,
+	13: CompletionKindEnum,
+	14: CompletionKindKeyword,
+	15: CompletionKindSnippet,
+	16: CompletionKindColor,
+	17: CompletionKindFile,
+	18: CompletionKindReference,
+	19: CompletionKindFolder,
+	20: CompletionKindEnumMember,
+	21: CompletionKindConstant,
+	22: CompletionKindStruct,
+	23: CompletionKindEvent,
+	24: CompletionKindOperator,
+	25: CompletionKindTypeParameter,
+}
+
+// NewCompletionKind returns the CompletionKind for a given LSP completion item kind
+func NewCompletionKind(kind int) CompletionKind {
+	completionKind, ok := completionKindMap[kind]
+	if !ok {
+		return CompletionKindUnknown
+	}
+	return completionKind
+}
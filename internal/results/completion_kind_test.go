@@ -0,0 +1,62 @@
+package results
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCompletionKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    int
+		expected CompletionKind
+	}{
+		{
+			name:     "Method completion kind",
+			input:    2,
+			expected: CompletionKindMethod,
+		},
+		{
+			name:     "Function completion kind",
+			input:    3,
+			expected: CompletionKindFunction,
+		},
+		{
+			name:     "Field completion kind",
+			input:    5,
+			expected: CompletionKindField,
+		},
+		{
+			name:     "Module completion kind",
+			input:    9,
+			expected: CompletionKindModule,
+		},
+		{
+			name:     "Keyword completion kind",
+			input:    14,
+			expected: CompletionKindKeyword,
+		},
+		{
+			name:     "Type parameter completion kind",
+			input:    25,
+			expected: CompletionKindTypeParameter,
+		},
+		{
+			name:     "Unknown completion kind - zero",
+			input:    0,
+			expected: CompletionKindUnknown,
+		},
+		{
+			name:     "Unknown completion kind - too large",
+			input:    26,
+			expected: CompletionKindUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewCompletionKind(tt.input))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package results
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+const (
+	cursorVersion = "v1"
+)
+
+// Cursor represents an opaque continuation token for a paginated list of results.
+// It encodes the offset of the next result and a fingerprint of the query it belongs to.
+type Cursor string
+
+// NewCursor creates a new Cursor for a query and the offset of the next result
+func NewCursor(query string, offset int) Cursor {
+	raw := fmt.Sprintf("%s:%d:%s", cursorVersion, offset, queryFingerprint(query))
+	return Cursor(base64.RawURLEncoding.EncodeToString([]byte(raw)))
+}
+
+// String returns the string representation of the cursor
+func (c Cursor) String() string {
+	return string(c)
+}
+
+// Parse parses a Cursor into the offset of the next result, checking that it belongs to the query
+func (c Cursor) Parse(query string) (offset int, err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(string(c))
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor encoding: %s", c)
+	}
+
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 3 || parts[0] != cursorVersion {
+		return 0, fmt.Errorf("invalid cursor format: %s", c)
+	}
+
+	offset, err = strconv.Atoi(parts[1])
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor offset: %s", c)
+	}
+
+	if parts[2] != queryFingerprint(query) {
+		return 0, fmt.Errorf("cursor does not belong to this query; cursors can only be reused with the same arguments")
+	}
+
+	return offset, nil
+}
+
+// queryFingerprint returns a short hash identifying a query
+func queryFingerprint(query string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(query))
+	return strconv.FormatUint(uint64(h.Sum32()), 16)
+}
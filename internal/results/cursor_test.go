@@ -0,0 +1,82 @@
+package results
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	cursor := NewCursor("Calculator", 50)
+	offset, err := cursor.Parse("Calculator")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 50, offset)
+}
+
+func TestCursor_IsOpaque(t *testing.T) {
+	cursor := NewCursor("Calculator", 50)
+	assert.NotContains(t, cursor.String(), "Calculator")
+	assert.NotContains(t, cursor.String(), ":")
+}
+
+func TestCursor_Parse(t *testing.T) {
+	tests := []struct {
+		name          string
+		cursor        Cursor
+		query         string
+		expected      int
+		errorContains string
+	}{
+		{
+			name:     "valid cursor",
+			cursor:   NewCursor("query", 10),
+			query:    "query",
+			expected: 10,
+		},
+		{
+			name:     "zero offset",
+			cursor:   NewCursor("query", 0),
+			query:    "query",
+			expected: 0,
+		},
+		{
+			name:          "different query",
+			cursor:        NewCursor("query", 10),
+			query:         "other query",
+			errorContains: "cursor does not belong to this query",
+		},
+		{
+			name:          "invalid encoding",
+			cursor:        "not a cursor!",
+			query:         "query",
+			errorContains: "invalid cursor encoding",
+		},
+		{
+			name:          "invalid format",
+			cursor:        "aGVsbG8",
+			query:         "query",
+			errorContains: "invalid cursor format",
+		},
+		{
+			name:          "empty cursor",
+			cursor:        "",
+			query:         "query",
+			errorContains: "invalid cursor format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset, err := tt.cursor.Parse(tt.query)
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, offset)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package results
+
+// ExtractToolResult represents the result of the extract_function and extract_variable tools
+type ExtractToolResult struct {
+	Message       string          `json:"message"`
+	Arguments     ExtractToolArgs `json:"arguments"`
+	CodeAction    *CodeActionInfo `json:"code_action,omitempty"`    // The applied extract code action
+	GeneratedName string          `json:"generated_name,omitempty"` // Name that gopls generated for the extracted declaration, before it was renamed
+	Applied       bool            `json:"applied"`                  // Whether the changes were written to disk
+	FileChanges   []FileChange    `json:"file_changes"`
+}
+
+// ExtractToolArgs represents the arguments for the extract tools
+type ExtractToolArgs struct {
+	SymbolAnchor   string `json:"symbol_anchor,omitempty"`
+	FilePath       string `json:"file_path,omitempty"`
+	Line           int    `json:"line,omitempty"`
+	Column         int    `json:"column,omitempty"`
+	EndLine        int    `json:"end_line,omitempty"`
+	EndColumn      int    `json:"end_column,omitempty"`
+	EndAnchor      string `json:"end_anchor,omitempty"`
+	Name           string `json:"name"`
+	Method         bool   `json:"method,omitempty"`          // Only for extract_function
+	AllOccurrences bool   `json:"all_occurrences,omitempty"` // Only for extract_variable
+	DryRun         bool   `json:"dry_run,omitempty"`
+}
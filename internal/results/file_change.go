@@ -0,0 +1,19 @@
+package results
+
+// FileOperation represents how a file is changed by an edit
+type FileOperation string
+
+const (
+	FileOperationCreate FileOperation = "create"
+	FileOperationModify FileOperation = "modify"
+	FileOperationRename FileOperation = "rename"
+	FileOperationDelete FileOperation = "delete"
+)
+
+// FileChange represents a change to a single file, with a unified diff of its content
+type FileChange struct {
+	File      string        `json:"file"`               // Relative to the workspace root, or absolute outside of the workspace
+	OldFile   string        `json:"old_file,omitempty"` // Previous path of a renamed file
+	Operation FileOperation `json:"operation"`
+	Diff      string        `json:"diff,omitempty"` // Unified diff of the content; empty for renames without content changes
+}
@@ -4,21 +4,39 @@ package results
 type FindSymbolDefinitionsByNameToolResult struct {
 	Message     string                             `json:"message"`
 	Arguments   FindSymbolDefinitionByNameToolArgs `json:"arguments"`
+	Total       int                                `json:"total"`                 // Total number of definitions across all pages
+	Truncated   bool                               `json:"truncated"`             // Whether more definitions are available
+	NextCursor  Cursor                             `json:"next_cursor,omitempty"` // Cursor for the next page, if truncated
 	Definitions []SymbolDefinition                 `json:"definitions,omitempty"`
 }
 
 // FindSymbolDefinitionByNameToolArgs represents the arguments for the find symbol definitions by name tool
 type FindSymbolDefinitionByNameToolArgs struct {
-	SymbolName   string `json:"symbol_name"`
-	Limit        int    `json:"limit,omitempty"`
-	IncludeHover bool   `json:"include_hover,omitempty"`
+	SymbolName       string   `json:"symbol_name"`
+	MatchMode        string   `json:"match_mode,omitempty"`
+	Scope            string   `json:"scope,omitempty"`
+	Limit            int      `json:"limit,omitempty"`
+	Cursor           string   `json:"cursor,omitempty"`
+	IncludeHover     bool     `json:"include_hover,omitempty"`
+	Kinds            []string `json:"kinds,omitempty"`
+	PackagePattern   string   `json:"package_pattern,omitempty"`
+	FileGlob         string   `json:"file_glob,omitempty"`
+	ExportedOnly     bool     `json:"exported_only,omitempty"`
+	ExcludeTests     bool     `json:"exclude_tests,omitempty"`
+	ExcludeVendor    bool     `json:"exclude_vendor,omitempty"`
+	ExcludeGenerated bool     `json:"exclude_generated,omitempty"`
 }
 
 // SymbolDefinition represents a symbol definition result
 type SymbolDefinition struct {
-	Name      string         `json:"name"`
-	Kind      SymbolKind     `json:"kind"`
-	Location  SymbolLocation `json:"location"`
-	Anchor    SymbolAnchor   `json:"anchor"`
-	HoverInfo string         `json:"hover_info,omitempty"`
+	Name           string           `json:"name"`
+	Kind           SymbolKind       `json:"kind"`
+	GoKind         GoSymbolKind     `json:"go_kind"`
+	Exported       bool             `json:"exported"`
+	TestFunction   TestFunctionKind `json:"test_function,omitempty"` // Only for test, benchmark, example, and fuzz functions
+	Location       SymbolLocation   `json:"location"`
+	Anchor         SymbolAnchor     `json:"anchor"`
+	SemanticAnchor SymbolAnchor     `json:"semantic_anchor,omitempty"` // Only for package-level symbols and their members
+	MatchQuality   MatchQuality     `json:"match_quality"`
+	HoverInfo      *HoverInfo       `json:"hover_info,omitempty"`
 }
@@ -4,17 +4,50 @@ package results
 type FindSymbolReferencesByAnchorToolResult struct {
 	Message    string                               `json:"message"`
 	Arguments  FindSymbolReferencesByAnchorToolArgs `json:"arguments"`
+	Total      int                                  `json:"total"`                 // Total number of references across all pages, or of groups when summarizing groups
+	Truncated  bool                                 `json:"truncated"`             // Whether more references (or groups) are available
+	NextCursor Cursor                               `json:"next_cursor,omitempty"` // Cursor for the next page, if truncated
+	Groups     []ReferenceGroup                     `json:"groups,omitempty"`
 	References []SymbolReference                    `json:"references,omitempty"`
 }
 
 // FindSymbolReferencesByAnchorToolArgs represents the arguments for the find symbol references by anchor tool
 type FindSymbolReferencesByAnchorToolArgs struct {
-	SymbolAnchor string `json:"symbol_anchor"`
-	Limit        int    `json:"limit,omitempty"`
+	SymbolAnchor  string `json:"symbol_anchor"`
+	Limit         int    `json:"limit,omitempty"`
+	Cursor        string `json:"cursor,omitempty"`
+	IncludeSource bool   `json:"include_source,omitempty"`
+	ContextLines  int    `json:"context_lines,omitempty"`
+	GroupBy       string `json:"group_by,omitempty"`
+	Group         string `json:"group,omitempty"`
 }
 
 // SymbolReference represents a symbol reference
 type SymbolReference struct {
-	Location SymbolLocation `json:"location"`
-	Anchor   SymbolAnchor   `json:"anchor"`
+	Location        SymbolLocation `json:"location"`
+	Anchor          SymbolAnchor   `json:"anchor"`
+	Kind            ReferenceKind  `json:"kind,omitempty"`             // Only set when grouping references
+	InTestFile      bool           `json:"in_test_file,omitempty"`     // Only set when grouping references
+	EnclosingSymbol string         `json:"enclosing_symbol,omitempty"` // Function or method containing the reference
+	Source          []SourceLine   `json:"source,omitempty"`           // Source line of the reference, with optional context lines
+}
+
+// ReferenceKind represents how a symbol is used at a reference
+type ReferenceKind string
+
+const (
+	ReferenceKindDeclaration ReferenceKind = "declaration"
+	ReferenceKindRead        ReferenceKind = "read"
+	ReferenceKindWrite       ReferenceKind = "write"
+)
+
+// ReferenceGroup summarizes the references within a single package or file
+type ReferenceGroup struct {
+	Key            string `json:"key"`             // Package directory or file path, depending on the grouping
+	Total          int    `json:"total"`           // Total number of references in the group
+	Declarations   int    `json:"declarations"`    // Number of declaration references
+	Reads          int    `json:"reads"`           // Number of read references
+	Writes         int    `json:"writes"`          // Number of write references
+	TestReferences int    `json:"test_references"` // Number of references in test files
+	Files          int    `json:"files,omitempty"` // Number of files with references (only for package groups)
 }
@@ -0,0 +1,44 @@
+package results
+
+// UnusedSource represents how an unused symbol was found
+type UnusedSource string
+
+const (
+	UnusedSourceReferences   UnusedSource = "references"   // The symbol has no references outside its own declaration and test files
+	UnusedSourceUnusedParams UnusedSource = "unusedparams" // A diagnostic of the gopls unusedparams analyzer
+	UnusedSourceUnusedFunc   UnusedSource = "unusedfunc"   // A diagnostic of the gopls unusedfunc analyzer
+)
+
+// FindUnusedSymbolsToolResult represents the result of the find_unused_symbols tool
+type FindUnusedSymbolsToolResult struct {
+	Message         string                    `json:"message"`
+	Arguments       FindUnusedSymbolsToolArgs `json:"arguments"`
+	PackagesChecked int                       `json:"packages_checked"`
+	SymbolsChecked  int                       `json:"symbols_checked"`       // Number of declarations whose references were checked
+	Total           int                       `json:"total"`                 // Total number of unused symbols across all pages
+	Truncated       bool                      `json:"truncated"`             // Whether more unused symbols are available
+	NextCursor      Cursor                    `json:"next_cursor,omitempty"` // Cursor for the next page, if truncated
+	UnusedSymbols   []UnusedSymbol            `json:"unused_symbols"`
+}
+
+// FindUnusedSymbolsToolArgs represents the arguments for the find unused symbols tool
+type FindUnusedSymbolsToolArgs struct {
+	Pattern            string   `json:"pattern,omitempty"`
+	IncludeExported    bool     `json:"include_exported"`
+	IncludeDiagnostics bool     `json:"include_diagnostics"`
+	Allowlist          []string `json:"allowlist,omitempty"` // Patterns from the server configuration and the request
+	Limit              int      `json:"limit,omitempty"`
+	Cursor             string   `json:"cursor,omitempty"`
+}
+
+// UnusedSymbol represents an unused declaration, or an unused parameter or function reported by a gopls analyzer
+type UnusedSymbol struct {
+	Name           string         `json:"name,omitempty"` // Name of the declaration, like "Factorial" or "(*Calculator).Add"; empty for diagnostics
+	Kind           SymbolKind     `json:"kind,omitempty"`
+	Package        string         `json:"package"` // Import path of the package
+	Location       SymbolLocation `json:"location"`
+	Anchor         SymbolAnchor   `json:"anchor"`
+	Source         UnusedSource   `json:"source"`
+	TestReferences int            `json:"test_references,omitempty"` // Number of references in test files, which don't count as uses
+	Message        string         `json:"message,omitempty"`         // Message of the diagnostic, like "unused parameter: x"
+}
@@ -0,0 +1,26 @@
+package results
+
+// FormatFileToolResult represents the result of the format_file tool
+type FormatFileToolResult struct {
+	Message      string             `json:"message"`
+	Arguments    FormatFileToolArgs `json:"arguments"`
+	FilesChecked int                `json:"files_checked"` // Number of files that were formatted, including files that were already formatted
+	Applied      bool               `json:"applied"`       // Whether the changes were written to disk
+	FileChanges  []FileChange       `json:"file_changes"`
+	Failures     []FileFailure      `json:"failures,omitempty"` // Files that couldn't be formatted, e.g. because of syntax errors
+}
+
+// FormatFileToolArgs represents the arguments for the format file tool
+type FormatFileToolArgs struct {
+	FilePath        string `json:"file_path,omitempty"`
+	Package         string `json:"package,omitempty"`
+	ChangedFiles    bool   `json:"changed_files,omitempty"`
+	OrganizeImports bool   `json:"organize_imports"`
+	DryRun          bool   `json:"dry_run,omitempty"`
+}
+
+// FileFailure represents a file that an operation failed for
+type FileFailure struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
@@ -0,0 +1,82 @@
+package results
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+// GetCompletionsToolResult represents the result of the get_completions tool
+type GetCompletionsToolResult struct {
+	Message     string                 `json:"message"`
+	Arguments   GetCompletionsToolArgs `json:"arguments"`
+	Incomplete  bool                   `json:"incomplete"`            // Whether the language server cut the candidates off, so more of the name should be typed
+	Total       int                    `json:"total"`                 // Total number of candidates across all pages
+	Truncated   bool                   `json:"truncated"`             // Whether more candidates are available
+	NextCursor  Cursor                 `json:"next_cursor,omitempty"` // Cursor for the next page, if truncated
+	Completions []Completion           `json:"completions"`
+}
+
+// GetCompletionsToolArgs represents the arguments for the get completions tool
+type GetCompletionsToolArgs struct {
+	SymbolAnchor string `json:"symbol_anchor,omitempty"`
+	FilePath     string `json:"file_path,omitempty"`
+	Line         int    `json:"line,omitempty"`
+	Column       int    `json:"column,omitempty"`
+	Limit        int    `json:"limit,omitempty"`
+	Cursor       string `json:"cursor,omitempty"`
+}
+
+// Completion represents a completion candidate, in ranked order
+type Completion struct {
+	Rank            int              `json:"rank"` // Rank of the candidate, starting at 1 for the best candidate
+	Label           string           `json:"label"`
+	Kind            CompletionKind   `json:"kind"`
+	Detail          string           `json:"detail,omitempty"` // Type or signature of the candidate
+	Doc             string           `json:"doc,omitempty"`
+	InsertText      string           `json:"insert_text"`                // Text replacing the partial name at the position
+	Deprecated      bool             `json:"deprecated,omitempty"`       // Only included if the candidate is deprecated
+	Imports         []string         `json:"imports,omitempty"`          // Import paths added by the additional edits, for unimported packages
+	AdditionalEdits []CompletionEdit `json:"additional_edits,omitempty"` // Edits elsewhere in the file that must be applied with the candidate
+}
+
+// CompletionEdit represents a text edit attached to a completion candidate, using display coordinates
+type CompletionEdit struct {
+	StartLine   int    `json:"start_line"`   // Display line (starts at 1)
+	StartColumn int    `json:"start_column"` // Display column (starts at 1)
+	EndLine     int    `json:"end_line"`     // Display line (starts at 1)
+	EndColumn   int    `json:"end_column"`   // Display column (starts at 1)
+	NewText     string `json:"new_text"`
+}
+
+// NewCompletionEdit converts an LSP text edit to display coordinates
+func NewCompletionEdit(edit types.TextEdit) CompletionEdit {
+	return CompletionEdit{
+		StartLine:   edit.Range.Start.Line + 1,
+		StartColumn: edit.Range.Start.Character + 1,
+		EndLine:     edit.Range.End.Line + 1,
+		EndColumn:   edit.Range.End.Character + 1,
+		NewText:     edit.NewText,
+	}
+}
+
+// importPathPattern matches the quoted import paths in the text of an import edit
+var importPathPattern = regexp.MustCompile("\"(?:[^\"\\\\\\n]|\\\\.)*\"|`[^`]*`")
+
+// ImportPaths returns the import paths added by edits, like the additional edits of an unimported package candidate
+func ImportPaths(edits []types.TextEdit) []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, edit := range edits {
+		for _, quoted := range importPathPattern.FindAllString(edit.NewText, -1) {
+			path, err := strconv.Unquote(quoted)
+			if err != nil || path == "" || seen[path] {
+				continue
+			}
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,47 @@
+package results
+
+import (
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCompletionEdit(t *testing.T) {
+	edit := types.TextEdit{
+		Range: types.Range{
+			Start: types.Position{Line: 2, Character: 0},
+			End:   types.Position{Line: 2, Character: 4},
+		},
+		NewText: "\"strings\"\n",
+	}
+
+	expected := CompletionEdit{StartLine: 3, StartColumn: 1, EndLine: 3, EndColumn: 5, NewText: "\"strings\"\n"}
+	assert.Equal(t, expected, NewCompletionEdit(edit))
+}
+
+func TestImportPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		texts    []string
+		expected []string
+	}{
+		{"No edits", nil, nil},
+		{"Import spec in existing group", []string{"\n\t\"strings\""}, []string{"strings"}},
+		{"New import declaration", []string{"import \"encoding/json\"\n\n"}, []string{"encoding/json"}},
+		{"Named import", []string{"import (\n\tyaml \"gopkg.in/yaml.v3\"\n)\n"}, []string{"gopkg.in/yaml.v3"}},
+		{"Raw string import", []string{"import `fmt`\n"}, []string{"fmt"}},
+		{"Duplicate imports", []string{"\"fmt\"", "\"fmt\""}, []string{"fmt"}},
+		{"No quoted paths", []string{"import (\n"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var edits []types.TextEdit
+			for _, text := range tt.texts {
+				edits = append(edits, types.TextEdit{NewText: text})
+			}
+			assert.Equal(t, tt.expected, ImportPaths(edits))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package results
+
+// GetDocumentationToolResult represents the result of the get_documentation tool
+type GetDocumentationToolResult struct {
+	Message      string                   `json:"message"`
+	Arguments    GetDocumentationToolArgs `json:"arguments"`
+	ImportPath   string                   `json:"import_path"`
+	PackageName  string                   `json:"package_name"`
+	Directory    string                   `json:"directory"` // Relative to the workspace root, or absolute for packages outside the workspace
+	External     bool                     `json:"external"`  // Whether the package is outside the workspace, like the standard library or a dependency
+	Synopsis     string                   `json:"synopsis,omitempty"`
+	Doc          string                   `json:"doc,omitempty"`      // Package doc comment, only for packages
+	Symbol       *SymbolDocumentation     `json:"symbol,omitempty"`   // Only for symbols
+	Examples     []DocExample             `json:"examples,omitempty"` // Package examples, only for packages
+	Total        int                      `json:"total"`              // Total number of declarations across all pages, only for packages
+	Truncated    bool                     `json:"truncated"`          // Whether more declarations are available
+	NextCursor   Cursor                   `json:"next_cursor,omitempty"`
+	Declarations []DocDeclaration         `json:"declarations,omitempty"` // Only for packages
+}
+
+// GetDocumentationToolArgs represents the arguments for the get documentation tool
+type GetDocumentationToolArgs struct {
+	Package           string `json:"package"`
+	Symbol            string `json:"symbol,omitempty"`
+	IncludeUnexported bool   `json:"include_unexported,omitempty"`
+	Limit             int    `json:"limit,omitempty"`
+	Cursor            string `json:"cursor,omitempty"`
+}
+
+// DocDeclaration summarizes a package-level declaration, like "go doc" does for a package
+type DocDeclaration struct {
+	Name           string         `json:"name"`            // Name of the declaration, like "Calculator" or "Calculator.Add"
+	Names          []string       `json:"names,omitempty"` // All names declared by a const or var group
+	GoKind         GoSymbolKind   `json:"go_kind"`
+	Signature      string         `json:"signature"` // Declaration without its body, with long type and value lists elided
+	Synopsis       string         `json:"synopsis,omitempty"`
+	Location       SymbolLocation `json:"location"`
+	Anchor         SymbolAnchor   `json:"anchor"`
+	SemanticAnchor SymbolAnchor   `json:"semantic_anchor"`
+}
+
+// SymbolDocumentation represents the full documentation of a symbol, like "go doc" does for a symbol
+type SymbolDocumentation struct {
+	DocDeclaration
+	Doc      string           `json:"doc,omitempty"`
+	Methods  []DocDeclaration `json:"methods,omitempty"`  // Only for types, including constructors and their related constants and variables
+	Examples []DocExample     `json:"examples,omitempty"` // Examples for the symbol from the package's test files
+}
+
+// DocExample represents a testable example from a package's test files
+type DocExample struct {
+	Name   string `json:"name"` // Name of the example function, like "ExampleCalculator_Add"
+	Doc    string `json:"doc,omitempty"`
+	Code   string `json:"code"`
+	Output string `json:"output,omitempty"`
+}
@@ -0,0 +1,162 @@
+package results
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// GetSignatureHelpToolResult represents the result of the get_signature_help tool
+type GetSignatureHelpToolResult struct {
+	Message         string                   `json:"message"`
+	Arguments       GetSignatureHelpToolArgs `json:"arguments"`
+	Source          SignatureSource          `json:"source,omitempty"`
+	ActiveSignature int                      `json:"active_signature"` // Index of the signature being called
+	Signatures      []CallSignature          `json:"signatures"`
+}
+
+// GetSignatureHelpToolArgs represents the arguments for the get signature help tool
+type GetSignatureHelpToolArgs struct {
+	SymbolAnchor string `json:"symbol_anchor,omitempty"`
+	FilePath     string `json:"file_path,omitempty"`
+	Line         int    `json:"line,omitempty"`
+	Column       int    `json:"column,omitempty"`
+}
+
+// SignatureSource represents where signatures came from
+type SignatureSource string
+
+const (
+	SignatureSourceCall  SignatureSource = "call"  // The position is inside a call expression
+	SignatureSourceHover SignatureSource = "hover" // The position is on a function or method name, like a definition anchor
+)
+
+// CallSignature represents the signature of a function or method being called
+type CallSignature struct {
+	Label           string          `json:"label"` // Signature as shown by the language server, like "Add(x float64) float64"
+	Doc             string          `json:"doc,omitempty"`
+	Parameters      []CallParameter `json:"parameters"`
+	Results         []CallParameter `json:"results,omitempty"`
+	Variadic        bool            `json:"variadic"`
+	ActiveParameter *int            `json:"active_parameter,omitempty"` // Index of the parameter at the position, only for the active signature
+}
+
+// CallParameter represents a parameter or result of a signature
+type CallParameter struct {
+	Name string `json:"name,omitempty"` // Empty for unnamed parameters
+	Type string `json:"type"`
+	Doc  string `json:"doc,omitempty"`
+}
+
+// NewCallParameter parses a parameter label like "x float64", "args ...any", or "error" into a CallParameter.
+// Labels that can't be parsed are used as the type as-is.
+func NewCallParameter(label string, doc string) CallParameter {
+	parameters := parseFieldList("func(" + label + ")")
+	if len(parameters) != 1 {
+		return CallParameter{Type: label, Doc: doc}
+	}
+	parameters[0].Doc = doc
+	return parameters[0]
+}
+
+// NewCallSignature creates a CallSignature from a label like "Add(x float64) float64" or a declaration like
+// "func (c *Calculator) Add(x float64) float64". Parameters are parsed from the label unless given.
+func NewCallSignature(label string, doc string, parameters []CallParameter) CallSignature {
+	signature := CallSignature{
+		Label:      label,
+		Doc:        strings.TrimSpace(doc),
+		Parameters: parameters,
+	}
+
+	funcType := parseFuncType(label)
+	if funcType == nil {
+		if signature.Parameters == nil {
+			signature.Parameters = []CallParameter{}
+		}
+		return signature
+	}
+	if signature.Parameters == nil {
+		signature.Parameters = newCallParameters(funcType.Params)
+	}
+	signature.Results = newCallParameters(funcType.Results)
+	if n := len(signature.Parameters); n > 0 {
+		signature.Variadic = strings.HasPrefix(signature.Parameters[n-1].Type, "...")
+	}
+	return signature
+}
+
+// parseFuncType parses the function type of a label or declaration, or returns nil if it can't be parsed
+func parseFuncType(signature string) *ast.FuncType {
+	if strings.HasPrefix(signature, "func ") {
+		file, err := parser.ParseFile(token.NewFileSet(), "", "package p\n"+signature, 0)
+		if err != nil || len(file.Decls) == 0 {
+			return nil
+		}
+		if decl, ok := file.Decls[0].(*ast.FuncDecl); ok {
+			return decl.Type
+		}
+		return nil
+	}
+
+	// Labels start with the function name, and may have type parameters, like "Map[T any](s []T) []T"
+	i := strings.Index(signature, "(")
+	if i < 0 {
+		return nil
+	}
+	if j := strings.Index(signature, "["); j >= 0 && j < i {
+		file, err := parser.ParseFile(token.NewFileSet(), "", "package p\nfunc "+signature, 0)
+		if err != nil || len(file.Decls) == 0 {
+			return nil
+		}
+		if decl, ok := file.Decls[0].(*ast.FuncDecl); ok {
+			return decl.Type
+		}
+		return nil
+	}
+	expr, err := parser.ParseExpr("func" + signature[i:])
+	if err != nil {
+		return nil
+	}
+	funcType, _ := expr.(*ast.FuncType)
+	return funcType
+}
+
+// parseFieldList parses the parameters of a function type expression like "func(x, y int)"
+func parseFieldList(expr string) []CallParameter {
+	parsed, err := parser.ParseExpr(expr)
+	if err != nil {
+		return nil
+	}
+	funcType, ok := parsed.(*ast.FuncType)
+	if !ok {
+		return nil
+	}
+	return newCallParameters(funcType.Params)
+}
+
+// newCallParameters converts a field list, which may declare several names per type, into CallParameters
+func newCallParameters(fields *ast.FieldList) []CallParameter {
+	if fields == nil {
+		return nil
+	}
+
+	parameters := []CallParameter{}
+	for _, field := range fields.List {
+		var buf bytes.Buffer
+		if err := format.Node(&buf, token.NewFileSet(), field.Type); err != nil {
+			continue
+		}
+		typ := buf.String()
+		if len(field.Names) == 0 {
+			parameters = append(parameters, CallParameter{Type: typ})
+			continue
+		}
+		for _, name := range field.Names {
+			parameters = append(parameters, CallParameter{Name: name.Name, Type: typ})
+		}
+	}
+	return parameters
+}
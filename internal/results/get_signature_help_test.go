@@ -0,0 +1,83 @@
+package results
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCallParameter(t *testing.T) {
+	tests := []struct {
+		label    string
+		expected CallParameter
+	}{
+		{"x float64", CallParameter{Name: "x", Type: "float64"}},
+		{"args ...any", CallParameter{Name: "args", Type: "...any"}},
+		{"error", CallParameter{Type: "error"}},
+		{"fn func(a, b int) error", CallParameter{Name: "fn", Type: "func(a, b int) error"}},
+		{"x, y int", CallParameter{Type: "x, y int"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewCallParameter(tt.label, ""))
+		})
+	}
+}
+
+func TestNewCallSignature(t *testing.T) {
+	tests := []struct {
+		name     string
+		label    string
+		expected CallSignature
+	}{
+		{
+			name:  "Label",
+			label: "Divide(x float64) (float64, error)",
+			expected: CallSignature{
+				Label:      "Divide(x float64) (float64, error)",
+				Parameters: []CallParameter{{Name: "x", Type: "float64"}},
+				Results:    []CallParameter{{Type: "float64"}, {Type: "error"}},
+			},
+		},
+		{
+			name:  "Variadic label",
+			label: "Printf(format string, a ...any) (n int, err error)",
+			expected: CallSignature{
+				Label:      "Printf(format string, a ...any) (n int, err error)",
+				Parameters: []CallParameter{{Name: "format", Type: "string"}, {Name: "a", Type: "...any"}},
+				Results:    []CallParameter{{Name: "n", Type: "int"}, {Name: "err", Type: "error"}},
+				Variadic:   true,
+			},
+		},
+		{
+			name:  "Generic label",
+			label: "Map[T, U any](s []T, f func(T) U) []U",
+			expected: CallSignature{
+				Label:      "Map[T, U any](s []T, f func(T) U) []U",
+				Parameters: []CallParameter{{Name: "s", Type: "[]T"}, {Name: "f", Type: "func(T) U"}},
+				Results:    []CallParameter{{Type: "[]U"}},
+			},
+		},
+		{
+			name:  "Method declaration",
+			label: "func (c *Calculator) Add(a, b float64) float64",
+			expected: CallSignature{
+				Label:      "func (c *Calculator) Add(a, b float64) float64",
+				Parameters: []CallParameter{{Name: "a", Type: "float64"}, {Name: "b", Type: "float64"}},
+				Results:    []CallParameter{{Type: "float64"}},
+			},
+		},
+		{
+			name:     "Invalid label",
+			label:    "not a signature",
+			expected: CallSignature{Label: "not a signature", Parameters: []CallParameter{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewCallSignature(tt.label, "", nil))
+		})
+	}
+}
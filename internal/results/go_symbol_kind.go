@@ -0,0 +1,192 @@
+package results
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// GoSymbolKind represents the kind of a symbol in Go terms, rather than LSP terms
+type GoSymbolKind string
+
+const (
+	GoSymbolKindStruct          GoSymbolKind = "struct"
+	GoSymbolKindInterface       GoSymbolKind = "interface"
+	GoSymbolKindTypeAlias       GoSymbolKind = "type_alias"
+	GoSymbolKindGenericType     GoSymbolKind = "generic_type"
+	GoSymbolKindNamedType       GoSymbolKind = "named_type" // Named types that aren't structs or interfaces, like "type Celsius float64"
+	GoSymbolKindPointerMethod   GoSymbolKind = "pointer_method"
+	GoSymbolKindValueMethod     GoSymbolKind = "value_method"
+	GoSymbolKindInterfaceMethod GoSymbolKind = "interface_method"
+	GoSymbolKindMethod          GoSymbolKind = "method" // Methods whose receiver is unknown
+	GoSymbolKindField           GoSymbolKind = "field"
+	GoSymbolKindEmbeddedField   GoSymbolKind = "embedded_field"
+	GoSymbolKindConst           GoSymbolKind = "const"
+	GoSymbolKindVar             GoSymbolKind = "var"
+	GoSymbolKindFunc            GoSymbolKind = "func"
+	GoSymbolKindPackage         GoSymbolKind = "package"
+	GoSymbolKindTypeParameter   GoSymbolKind = "type_parameter"
+
+	// This isn't a valid Go symbol kind, but it's used to indicate that the Go symbol kind is unknown
+	GoSymbolKindUnknown GoSymbolKind = "unknown"
+)
+
+// TestFunctionKind represents the kind of a function that is run by "go test"
+type TestFunctionKind string
+
+const (
+	TestFunctionKindTest      TestFunctionKind = "test"
+	TestFunctionKindBenchmark TestFunctionKind = "benchmark"
+	TestFunctionKindExample   TestFunctionKind = "example"
+	TestFunctionKindFuzz      TestFunctionKind = "fuzz"
+)
+
+// See: https://pkg.go.dev/cmd/go#hdr-Testing_functions
+var testFunctionPrefixes = map[string]TestFunctionKind{
+	"Test":      TestFunctionKindTest,
+	"Benchmark": TestFunctionKindBenchmark,
+	"Example":   TestFunctionKindExample,
+	"Fuzz":      TestFunctionKindFuzz,
+}
+
+// NewGoSymbolKind returns the GoSymbolKind for a symbol, from its LSP symbol kind, name, and detail (e.g. from a DocumentSymbol).
+// The signature from hover information is optional, but it's needed to distinguish type aliases and generic types.
+func NewGoSymbolKind(kind int, name string, detail string, signature string) GoSymbolKind {
+	symbolKind := NewSymbolKind(kind)
+
+	// Document symbols name methods after their receiver, like "(*Calculator).Add"
+	if symbolKind == SymbolKindMethod {
+		switch {
+		case strings.HasPrefix(name, "(*"):
+			return GoSymbolKindPointerMethod
+		case strings.HasPrefix(name, "("):
+			return GoSymbolKindValueMethod
+		case !strings.Contains(name, "."):
+			// Methods without receivers are the children of interfaces
+			return GoSymbolKindInterfaceMethod
+		}
+	}
+
+	if goKind := goSymbolKindFromSignature(signature); goKind != GoSymbolKindUnknown {
+		return goKind
+	}
+
+	switch symbolKind {
+	case SymbolKindStruct:
+		return GoSymbolKindStruct
+	case SymbolKindInterface:
+		return GoSymbolKindInterface
+	case SymbolKindClass:
+		// gopls reports named types that aren't structs or interfaces as classes
+		return GoSymbolKindNamedType
+	case SymbolKindMethod:
+		return GoSymbolKindMethod
+	case SymbolKindField:
+		if isEmbeddedField(name, detail) {
+			return GoSymbolKindEmbeddedField
+		}
+		return GoSymbolKindField
+	case SymbolKindConstant:
+		return GoSymbolKindConst
+	case SymbolKindVariable:
+		return GoSymbolKindVar
+	case SymbolKindFunction:
+		return GoSymbolKindFunc
+	case SymbolKindPackage:
+		return GoSymbolKindPackage
+	case SymbolKindTypeParameter:
+		return GoSymbolKindTypeParameter
+	default:
+		return GoSymbolKindUnknown
+	}
+}
+
+// goSymbolKindFromSignature returns the GoSymbolKind of a declaration, or GoSymbolKindUnknown if it can't be parsed
+func goSymbolKindFromSignature(signature string) GoSymbolKind {
+	if signature == "" {
+		return GoSymbolKindUnknown
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package p\n"+signature, 0)
+	if err != nil || len(file.Decls) == 0 {
+		return GoSymbolKindUnknown
+	}
+
+	switch decl := file.Decls[0].(type) {
+	case *ast.FuncDecl:
+		if decl.Recv == nil || len(decl.Recv.List) == 0 {
+			return GoSymbolKindFunc
+		}
+		if _, ok := decl.Recv.List[0].Type.(*ast.StarExpr); ok {
+			return GoSymbolKindPointerMethod
+		}
+		return GoSymbolKindValueMethod
+	case *ast.GenDecl:
+		switch decl.Tok {
+		case token.CONST:
+			return GoSymbolKindConst
+		case token.VAR:
+			return GoSymbolKindVar
+		case token.TYPE:
+			typeSpec, ok := decl.Specs[0].(*ast.TypeSpec)
+			if !ok {
+				return GoSymbolKindUnknown
+			}
+			return NewTypeSpecKind(typeSpec)
+		}
+	}
+	return GoSymbolKindUnknown
+}
+
+// NewTypeSpecKind returns the GoSymbolKind of a type declaration
+func NewTypeSpecKind(typeSpec *ast.TypeSpec) GoSymbolKind {
+	switch {
+	case typeSpec.Assign.IsValid():
+		return GoSymbolKindTypeAlias
+	case typeSpec.TypeParams != nil && len(typeSpec.TypeParams.List) > 0:
+		return GoSymbolKindGenericType
+	}
+	switch typeSpec.Type.(type) {
+	case *ast.StructType:
+		return GoSymbolKindStruct
+	case *ast.InterfaceType:
+		return GoSymbolKindInterface
+	default:
+		return GoSymbolKindNamedType
+	}
+}
+
+// isEmbeddedField checks if a field may be embedded, since gopls names embedded fields after their type.
+// Fields named after their type, like "Base Base", have the same name and detail, so only their declaration can tell them apart.
+func isEmbeddedField(name string, detail string) bool {
+	typeName := strings.TrimPrefix(detail, "*")
+	typeName, _, _ = strings.Cut(typeName, "[")
+	if i := strings.LastIndex(typeName, "."); i >= 0 {
+		typeName = typeName[i+1:]
+	}
+	return typeName != "" && (name == detail || name == typeName)
+}
+
+// NewTestFunctionKind returns the kind of a function that is run by "go test", or an empty string if the symbol isn't one.
+// Test functions must be top-level functions in a _test.go file, named with a prefix that isn't followed by a lowercase letter.
+func NewTestFunctionKind(kind int, name string, file string) TestFunctionKind {
+	if NewSymbolKind(kind) != SymbolKindFunction || !strings.HasSuffix(filepath.Base(file), "_test.go") {
+		return ""
+	}
+
+	name = NewSymbolIdentifier(name)
+	for prefix, testKind := range testFunctionPrefixes {
+		rest, found := strings.CutPrefix(name, prefix)
+		if !found {
+			continue
+		}
+		if r, _ := utf8.DecodeRuneInString(rest); rest == "" || !unicode.IsLower(r) {
+			return testKind
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package results
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGoSymbolKind(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       int
+		symbolName string
+		detail     string
+		signature  string
+		expected   GoSymbolKind
+	}{
+		{"Struct", 23, "Calculator", "struct{...}", "", GoSymbolKindStruct},
+		{"Interface", 11, "Shape", "interface{...}", "", GoSymbolKindInterface},
+		{"Named type", 5, "Celsius", "float64", "", GoSymbolKindNamedType},
+		{"Type alias", 5, "Temp", "Celsius", "type Temp = Celsius", GoSymbolKindTypeAlias},
+		{"Generic type", 23, "List", "struct{...}", "type List[T any] struct {\n\titems []T\n}", GoSymbolKindGenericType},
+		{"Func type", 12, "Handler", "func()", "type Handler func()", GoSymbolKindNamedType},
+		{"Pointer method", 6, "(*Calculator).Add", "func(a int, b int) int", "", GoSymbolKindPointerMethod},
+		{"Value method", 6, "(Calculator).Value", "func() int", "", GoSymbolKindValueMethod},
+		{"Interface method", 6, "Area", "func() float64", "func (Shape) Area() float64", GoSymbolKindInterfaceMethod},
+		{"Method from hover", 6, "Calculator.Add", "", "func (c *Calculator) Add(a int, b int) int", GoSymbolKindPointerMethod},
+		{"Method without hover", 6, "Calculator.Add", "", "", GoSymbolKindMethod},
+		{"Field", 8, "value", "int", "field value int", GoSymbolKindField},
+		{"Embedded field", 8, "Base", "*Base", "", GoSymbolKindEmbeddedField},
+		{"Embedded qualified field", 8, "io.Writer", "io.Writer", "", GoSymbolKindEmbeddedField},
+		{"Const", 14, "Pi", "", "const Pi = 3.14", GoSymbolKindConst},
+		{"Var", 13, "count", "int", "", GoSymbolKindVar},
+		{"Func", 12, "main", "func()", "func main()", GoSymbolKindFunc},
+		{"Unknown", 99, "x", "", "", GoSymbolKindUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewGoSymbolKind(tt.kind, tt.symbolName, tt.detail, tt.signature))
+		})
+	}
+}
+
+func TestNewTestFunctionKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     int
+		symbol   string
+		file     string
+		expected TestFunctionKind
+	}{
+		{"Test", 12, "TestAdd", "calc_test.go", TestFunctionKindTest},
+		{"Test with underscore", 12, "Test_add", "calc_test.go", TestFunctionKindTest},
+		{"Bare test", 12, "Test", "calc_test.go", TestFunctionKindTest},
+		{"Benchmark", 12, "BenchmarkAdd", "pkg/calc_test.go", TestFunctionKindBenchmark},
+		{"Example", 12, "ExampleCalculator_Add", "calc_test.go", TestFunctionKindExample},
+		{"Fuzz", 12, "FuzzParse", "calc_test.go", TestFunctionKindFuzz},
+		{"Lowercase after prefix", 12, "Testify", "calc_test.go", ""},
+		{"Not a test file", 12, "TestAdd", "calc.go", ""},
+		{"Method", 6, "(*Suite).TestAdd", "calc_test.go", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewTestFunctionKind(tt.kind, tt.symbol, tt.file))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package results
+
+// GoToDefinitionByPositionToolResult represents the result of the go_to_definition_by_position tool
+type GoToDefinitionByPositionToolResult struct {
+	Message     string                           `json:"message"`
+	Arguments   GoToDefinitionByPositionToolArgs `json:"arguments"`
+	Definitions []PositionDefinition             `json:"definitions"`
+}
+
+// GoToDefinitionByPositionToolArgs represents the arguments for the go to definition by position tool
+type GoToDefinitionByPositionToolArgs struct {
+	SymbolAnchor string `json:"symbol_anchor,omitempty"`
+	FilePath     string `json:"file_path,omitempty"`
+	Line         int    `json:"line,omitempty"`
+	Column       int    `json:"column,omitempty"`
+	IncludeHover bool   `json:"include_hover,omitempty"`
+}
+
+// PositionDefinition represents the definition of the identifier at a position
+type PositionDefinition struct {
+	Name           string         `json:"name"`
+	Kind           SymbolKind     `json:"kind"`
+	GoKind         GoSymbolKind   `json:"go_kind"`
+	Location       SymbolLocation `json:"location"`
+	Anchor         SymbolAnchor   `json:"anchor"`
+	SemanticAnchor SymbolAnchor   `json:"semantic_anchor,omitempty"` // Only for package-level symbols and their members
+	External       bool           `json:"external"`                  // Whether the definition is outside the workspace, in which case the file path is absolute
+	ReadOnly       bool           `json:"read_only"`                 // Whether the definition is in the standard library or another read-only file (e.g. in the module cache)
+	HoverInfo      *HoverInfo     `json:"hover_info,omitempty"`
+}
@@ -0,0 +1,162 @@
+package results
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// GoToTypeDefinitionByPositionToolResult represents the result of the go_to_type_definition_by_position tool
+type GoToTypeDefinitionByPositionToolResult struct {
+	Message         string                               `json:"message"`
+	Arguments       GoToTypeDefinitionByPositionToolArgs `json:"arguments"`
+	ExpressionType  *ExpressionType                      `json:"expression_type,omitempty"`
+	TypeDefinitions []TypeDefinition                     `json:"type_definitions"`
+}
+
+// GoToTypeDefinitionByPositionToolArgs represents the arguments for the go to type definition by position tool
+type GoToTypeDefinitionByPositionToolArgs struct {
+	SymbolAnchor string `json:"symbol_anchor,omitempty"`
+	FilePath     string `json:"file_path,omitempty"`
+	Line         int    `json:"line,omitempty"`
+	Column       int    `json:"column,omitempty"`
+}
+
+// TypeDefinition represents the declaration of a type used by the identifier at a position
+type TypeDefinition struct {
+	PositionDefinition
+	Signature string `json:"signature,omitempty"` // Declaration of the type, from its hover information
+}
+
+// TypeWrapper represents a type constructor around a named type, like a pointer or a slice
+type TypeWrapper string
+
+const (
+	TypeWrapperPointer  TypeWrapper = "pointer"
+	TypeWrapperSlice    TypeWrapper = "slice"
+	TypeWrapperArray    TypeWrapper = "array"
+	TypeWrapperMap      TypeWrapper = "map"
+	TypeWrapperChannel  TypeWrapper = "channel"
+	TypeWrapperFunc     TypeWrapper = "func"
+	TypeWrapperGeneric  TypeWrapper = "generic"  // Instantiation of a generic type, like "List[int]"
+	TypeWrapperVariadic TypeWrapper = "variadic" // Variadic parameters, like "...int"
+)
+
+// ExpressionType represents the type of the identifier at a position, like "map[string]*Calculator"
+type ExpressionType struct {
+	Type          string        `json:"type"`                     // Type expression, as written in the hover signature
+	Wrappers      []TypeWrapper `json:"wrappers,omitempty"`       // Type constructors from the outside in, e.g. [map pointer] for "map[string]*Calculator"
+	NamedTypes    []string      `json:"named_types,omitempty"`    // Named types used by the type expression, e.g. [string Calculator]
+	TypeArguments []string      `json:"type_arguments,omitempty"` // Type arguments of a generic instantiation, e.g. [int] for "List[int]"
+}
+
+// NewExpressionType parses the type of a variable, field, or parameter from its hover signature (e.g. "var calc *Calculator"),
+// or returns nil if the signature doesn't declare a typed value
+func NewExpressionType(signature string) *ExpressionType {
+	// gopls describes fields as "field name T", which isn't valid Go
+	code, _, _ := strings.Cut(signature, "\n")
+	if rest, found := strings.CutPrefix(code, "field "); found {
+		code = "var " + rest
+	}
+	if !strings.HasPrefix(code, "var ") && !strings.HasPrefix(code, "const ") {
+		return nil
+	}
+
+	const header = "package p\n"
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", header+code, 0)
+	if err != nil || len(file.Decls) == 0 {
+		return nil
+	}
+	decl, ok := file.Decls[0].(*ast.GenDecl)
+	if !ok || len(decl.Specs) == 0 {
+		return nil
+	}
+	valueSpec, ok := decl.Specs[0].(*ast.ValueSpec)
+	if !ok || valueSpec.Type == nil {
+		return nil
+	}
+
+	source := func(node ast.Node) string {
+		return code[fset.Position(node.Pos()).Offset-len(header) : fset.Position(node.End()).Offset-len(header)]
+	}
+
+	expressionType := &ExpressionType{Type: source(valueSpec.Type)}
+	expressionType.addTypeExpr(valueSpec.Type, source, true)
+	return expressionType
+}
+
+// addTypeExpr walks a type expression, recording wrappers along the outermost path and every named type
+func (e *ExpressionType) addTypeExpr(expr ast.Expr, source func(ast.Node) string, outermost bool) {
+	wrap := func(wrapper TypeWrapper) {
+		if outermost {
+			e.Wrappers = append(e.Wrappers, wrapper)
+		}
+	}
+
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		e.addNamedType(expr.Name)
+	case *ast.SelectorExpr:
+		e.addNamedType(source(expr))
+	case *ast.StarExpr:
+		wrap(TypeWrapperPointer)
+		e.addTypeExpr(expr.X, source, outermost)
+	case *ast.ParenExpr:
+		e.addTypeExpr(expr.X, source, outermost)
+	case *ast.ArrayType:
+		if expr.Len == nil {
+			wrap(TypeWrapperSlice)
+		} else {
+			wrap(TypeWrapperArray)
+		}
+		e.addTypeExpr(expr.Elt, source, outermost)
+	case *ast.Ellipsis:
+		wrap(TypeWrapperVariadic)
+		e.addTypeExpr(expr.Elt, source, outermost)
+	case *ast.MapType:
+		wrap(TypeWrapperMap)
+		e.addTypeExpr(expr.Key, source, false)
+		e.addTypeExpr(expr.Value, source, outermost)
+	case *ast.ChanType:
+		wrap(TypeWrapperChannel)
+		e.addTypeExpr(expr.Value, source, outermost)
+	case *ast.FuncType:
+		wrap(TypeWrapperFunc)
+		for _, fields := range []*ast.FieldList{expr.Params, expr.Results} {
+			if fields == nil {
+				continue
+			}
+			for _, field := range fields.List {
+				e.addTypeExpr(field.Type, source, false)
+			}
+		}
+	case *ast.IndexExpr:
+		e.addGenericType(expr.X, []ast.Expr{expr.Index}, source, outermost, wrap)
+	case *ast.IndexListExpr:
+		e.addGenericType(expr.X, expr.Indices, source, outermost, wrap)
+	}
+}
+
+// addGenericType records a generic instantiation, whose type arguments are only kept for the outermost type
+func (e *ExpressionType) addGenericType(base ast.Expr, typeArgs []ast.Expr, source func(ast.Node) string, outermost bool, wrap func(TypeWrapper)) {
+	wrap(TypeWrapperGeneric)
+	e.addTypeExpr(base, source, false)
+	for _, typeArg := range typeArgs {
+		if outermost {
+			e.TypeArguments = append(e.TypeArguments, source(typeArg))
+		}
+		e.addTypeExpr(typeArg, source, false)
+	}
+}
+
+// addNamedType records a named type once
+func (e *ExpressionType) addNamedType(name string) {
+	for _, existing := range e.NamedTypes {
+		if existing == name {
+			return
+		}
+	}
+	e.NamedTypes = append(e.NamedTypes, name)
+}
@@ -0,0 +1,97 @@
+package results
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExpressionType(t *testing.T) {
+	tests := []struct {
+		name      string
+		signature string
+		expected  *ExpressionType
+	}{
+		{
+			name:      "named type",
+			signature: "var calc Calculator",
+			expected:  &ExpressionType{Type: "Calculator", NamedTypes: []string{"Calculator"}},
+		},
+		{
+			name:      "pointer",
+			signature: "var calc *Calculator",
+			expected: &ExpressionType{
+				Type:       "*Calculator",
+				Wrappers:   []TypeWrapper{TypeWrapperPointer},
+				NamedTypes: []string{"Calculator"},
+			},
+		},
+		{
+			name:      "field with slice of qualified pointers",
+			signature: "field writers []*bytes.Buffer",
+			expected: &ExpressionType{
+				Type:       "[]*bytes.Buffer",
+				Wrappers:   []TypeWrapper{TypeWrapperSlice, TypeWrapperPointer},
+				NamedTypes: []string{"bytes.Buffer"},
+			},
+		},
+		{
+			name:      "map",
+			signature: "var byName map[string]*Calculator",
+			expected: &ExpressionType{
+				Type:       "map[string]*Calculator",
+				Wrappers:   []TypeWrapper{TypeWrapperMap, TypeWrapperPointer},
+				NamedTypes: []string{"string", "Calculator"},
+			},
+		},
+		{
+			name:      "generic instantiation",
+			signature: "var pairs *Pair[string, []Calculator]",
+			expected: &ExpressionType{
+				Type:          "*Pair[string, []Calculator]",
+				Wrappers:      []TypeWrapper{TypeWrapperPointer, TypeWrapperGeneric},
+				NamedTypes:    []string{"Pair", "string", "Calculator"},
+				TypeArguments: []string{"string", "[]Calculator"},
+			},
+		},
+		{
+			name:      "channel",
+			signature: "var results <-chan error",
+			expected: &ExpressionType{
+				Type:       "<-chan error",
+				Wrappers:   []TypeWrapper{TypeWrapperChannel},
+				NamedTypes: []string{"error"},
+			},
+		},
+		{
+			name:      "function",
+			signature: "var op func(a float64, b float64) (float64, error)",
+			expected: &ExpressionType{
+				Type:       "func(a float64, b float64) (float64, error)",
+				Wrappers:   []TypeWrapper{TypeWrapperFunc},
+				NamedTypes: []string{"float64", "error"},
+			},
+		},
+		{
+			name:      "function declaration",
+			signature: "func NewCalculator(initial float64) *Calculator",
+			expected:  nil,
+		},
+		{
+			name:      "untyped constant",
+			signature: "const Pi = 3.14",
+			expected:  nil,
+		},
+		{
+			name:      "invalid signature",
+			signature: "var ???",
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewExpressionType(tt.signature))
+		})
+	}
+}
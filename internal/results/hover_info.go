@@ -0,0 +1,143 @@
+package results
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Matches fenced Go code blocks in hover markdown
+	hoverCodeRegexp = regexp.MustCompile("(?s)```go\n(.*?)\n?```")
+	// Matches documentation links in hover markdown, like "[`fmt.Println` on pkg.go.dev](https://pkg.go.dev/fmt#Println)"
+	hoverLinkRegexp = regexp.MustCompile(`\[[^\]]*\]\((https://pkg\.go\.dev/[^)]+)\)`)
+	// Matches module versions in documentation links, like "@v1.2.3"
+	hoverVersionRegexp = regexp.MustCompile(`@[^/#]+`)
+)
+
+// HoverInfo represents hover information for a symbol, parsed from the language server's markdown
+type HoverInfo struct {
+	Signature   string        `json:"signature,omitempty"`      // Declaration of the symbol, without methods or documentation
+	Doc         string        `json:"doc,omitempty"`            // Doc comment of the symbol
+	PackagePath string        `json:"package_path,omitempty"`   // Import path of the package that declares the symbol
+	Receiver    string        `json:"receiver,omitempty"`       // Receiver type of a method, like "*Calculator"
+	PkgGoDevURL string        `json:"pkg_go_dev_url,omitempty"` // Link to the symbol's documentation on pkg.go.dev
+	Fields      []StructField `json:"fields,omitempty"`         // Fields of a struct type
+}
+
+// StructField represents a field of a struct type
+type StructField struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Tag      string `json:"tag,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+	Embedded bool   `json:"embedded,omitempty"`
+}
+
+// NewHoverInfo parses hover markdown from the language server into a HoverInfo.
+// Code that isn't a valid Go declaration (e.g. "field value int") is used as the signature as-is.
+func NewHoverInfo(markdown string) HoverInfo {
+	var info HoverInfo
+
+	// Documentation links identify the package, and are otherwise noise
+	if matches := hoverLinkRegexp.FindStringSubmatch(markdown); matches != nil {
+		info.PkgGoDevURL = matches[1]
+		path, _, _ := strings.Cut(strings.TrimPrefix(matches[1], "https://pkg.go.dev/"), "#")
+		info.PackagePath = hoverVersionRegexp.ReplaceAllString(path, "")
+	}
+	rest := hoverLinkRegexp.ReplaceAllString(markdown, "")
+
+	if matches := hoverCodeRegexp.FindStringSubmatch(rest); matches != nil {
+		parseHoverCode(&info, strings.TrimSpace(matches[1]))
+		rest = strings.Replace(rest, matches[0], "", 1)
+	} else if !strings.Contains(rest, "```") {
+		// Plain text hovers (e.g. with the "SingleLine" hover kind) are just a signature
+		parseHoverCode(&info, strings.TrimSpace(rest))
+		return info
+	}
+
+	// The remaining sections are documentation, separated by horizontal rules
+	var docParts []string
+	for _, part := range strings.Split(rest, "\n---") {
+		if part = strings.TrimSpace(part); part != "" && part != "---" {
+			docParts = append(docParts, part)
+		}
+	}
+	info.Doc = strings.Join(docParts, "\n\n")
+
+	return info
+}
+
+// parseHoverCode parses the code block of a hover into the signature, receiver, and struct fields
+func parseHoverCode(info *HoverInfo, code string) {
+	const header = "package p\n"
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", header+code, parser.ParseComments)
+	if err != nil || len(file.Decls) == 0 {
+		// Only keep the first paragraph, since later paragraphs usually list methods
+		info.Signature, _, _ = strings.Cut(code, "\n\n")
+		return
+	}
+
+	source := func(node ast.Node) string {
+		return code[fset.Position(node.Pos()).Offset-len(header) : fset.Position(node.End()).Offset-len(header)]
+	}
+
+	decl := file.Decls[0]
+	info.Signature = source(decl)
+
+	switch decl := decl.(type) {
+	case *ast.FuncDecl:
+		if decl.Recv != nil && len(decl.Recv.List) > 0 {
+			info.Receiver = source(decl.Recv.List[0].Type)
+		}
+	case *ast.GenDecl:
+		for _, spec := range decl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || structType.Fields == nil {
+				continue
+			}
+			for _, field := range structType.Fields.List {
+				info.Fields = append(info.Fields, newStructFields(field, source)...)
+			}
+		}
+	}
+}
+
+// newStructFields converts a struct field declaration, which may declare several names, into StructFields
+func newStructFields(field *ast.Field, source func(ast.Node) string) []StructField {
+	template := StructField{Type: source(field.Type)}
+	if field.Tag != nil {
+		template.Tag = field.Tag.Value
+	}
+	if field.Comment != nil {
+		template.Comment = strings.TrimSpace(field.Comment.Text())
+	} else if field.Doc != nil {
+		template.Comment = strings.TrimSpace(field.Doc.Text())
+	}
+
+	if len(field.Names) == 0 {
+		// Embedded fields are named after their type, without pointers or package qualifiers
+		name := strings.TrimPrefix(template.Type, "*")
+		name, _, _ = strings.Cut(name, "[")
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		template.Name = name
+		template.Embedded = true
+		return []StructField{template}
+	}
+
+	fields := make([]StructField, len(field.Names))
+	for i, name := range field.Names {
+		fields[i] = template
+		fields[i].Name = name.Name
+	}
+	return fields
+}
@@ -0,0 +1,73 @@
+package results
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHoverInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected HoverInfo
+	}{
+		{
+			name: "method",
+			markdown: "```go\nfunc (c *Calculator) Add(a int, b int) int\n```\n\n---\n\nAdd adds two numbers.\n\n\n---\n\n" +
+				"[`(example.com/calc.Calculator).Add` on pkg.go.dev](https://pkg.go.dev/example.com/calc#Calculator.Add)",
+			expected: HoverInfo{
+				Signature:   "func (c *Calculator) Add(a int, b int) int",
+				Doc:         "Add adds two numbers.",
+				PackagePath: "example.com/calc",
+				Receiver:    "*Calculator",
+				PkgGoDevURL: "https://pkg.go.dev/example.com/calc#Calculator.Add",
+			},
+		},
+		{
+			name: "struct with fields and methods",
+			markdown: "```go\ntype Calculator struct {\n\tvalue int // current value\n\tName  string `json:\"name\"`\n\t*Base\n\tio.Writer\n}\n\n" +
+				"func (c *Calculator) Add(a int, b int) int\n```\n\n---\n\nCalculator performs arithmetic.",
+			expected: HoverInfo{
+				Signature: "type Calculator struct {\n\tvalue int // current value\n\tName  string `json:\"name\"`\n\t*Base\n\tio.Writer\n}",
+				Doc:       "Calculator performs arithmetic.",
+				Fields: []StructField{
+					{Name: "value", Type: "int", Comment: "current value"},
+					{Name: "Name", Type: "string", Tag: "`json:\"name\"`"},
+					{Name: "Base", Type: "*Base", Embedded: true},
+					{Name: "Writer", Type: "io.Writer", Embedded: true},
+				},
+			},
+		},
+		{
+			name:     "dependency with version",
+			markdown: "```go\nfunc Parse(s string) (*Doc, error)\n```\n\n---\n\n[`yaml.Parse` on pkg.go.dev](https://pkg.go.dev/gopkg.in/yaml.v3@v3.0.1#Parse)",
+			expected: HoverInfo{
+				Signature:   "func Parse(s string) (*Doc, error)",
+				PackagePath: "gopkg.in/yaml.v3",
+				PkgGoDevURL: "https://pkg.go.dev/gopkg.in/yaml.v3@v3.0.1#Parse",
+			},
+		},
+		{
+			name:     "field",
+			markdown: "```go\nfield value int\n```\n\n---\n\ncurrent value",
+			expected: HoverInfo{
+				Signature: "field value int",
+				Doc:       "current value",
+			},
+		},
+		{
+			name:     "plain text",
+			markdown: "func Add(a int, b int) int",
+			expected: HoverInfo{
+				Signature: "func Add(a int, b int) int",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewHoverInfo(tt.markdown))
+		})
+	}
+}
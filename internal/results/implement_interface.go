@@ -0,0 +1,29 @@
+package results
+
+import "github.com/averycrespi/gopls-mcp/pkg/types"
+
+// ImplementInterfaceToolResult represents the result of the implement_interface tool
+type ImplementInterfaceToolResult struct {
+	Message       string                     `json:"message"`
+	Arguments     ImplementInterfaceToolArgs `json:"arguments"`
+	Type          string                     `json:"type"`
+	Interface     string                     `json:"interface"`           // Qualified name of the interface, like "io.Reader"
+	Methods       []string                   `json:"methods"`             // Names of the generated methods, like "Read"
+	Code          string                     `json:"code"`                // Code of the generated methods, with their doc comments
+	Assertion     string                     `json:"assertion,omitempty"` // Kept assertion that the type implements the interface, like "var _ io.Reader = (*Buffer)(nil)"
+	Applied       bool                       `json:"applied"`             // Whether the changes were written to disk
+	WorkspaceEdit types.WorkspaceEdit        `json:"workspace_edit"`      // LSP workspace edit that makes the changes to the original files
+	FileChanges   []FileChange               `json:"file_changes"`
+}
+
+// ImplementInterfaceToolArgs represents the arguments for the implement interface tool
+type ImplementInterfaceToolArgs struct {
+	SymbolAnchor    string `json:"symbol_anchor,omitempty"`
+	FilePath        string `json:"file_path,omitempty"`
+	Line            int    `json:"line,omitempty"`
+	Column          int    `json:"column,omitempty"`
+	Interface       string `json:"interface"`
+	PointerReceiver bool   `json:"pointer_receiver"`
+	KeepAssertion   bool   `json:"keep_assertion,omitempty"`
+	DryRun          bool   `json:"dry_run,omitempty"`
+}
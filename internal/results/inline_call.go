@@ -0,0 +1,30 @@
+package results
+
+// InlineCallToolResult represents the result of the inline_call tool
+type InlineCallToolResult struct {
+	Message     string             `json:"message"`
+	Arguments   InlineCallToolArgs `json:"arguments"`
+	Function    string             `json:"function,omitempty"` // Name of the inlined function
+	CallSites   []InlineCallSite   `json:"call_sites"`
+	Applied     bool               `json:"applied"` // Whether the changes were written to disk
+	FileChanges []FileChange       `json:"file_changes"`
+}
+
+// InlineCallToolArgs represents the arguments for the inline call tool
+type InlineCallToolArgs struct {
+	SymbolAnchor string `json:"symbol_anchor,omitempty"`
+	FilePath     string `json:"file_path,omitempty"`
+	Line         int    `json:"line,omitempty"`
+	Column       int    `json:"column,omitempty"`
+	AllCallSites bool   `json:"all_call_sites,omitempty"`
+	DryRun       bool   `json:"dry_run,omitempty"`
+}
+
+// InlineCallSite represents a call site that was inlined, or that couldn't be inlined.
+// Locations are in the original content of the file, before any calls were inlined.
+type InlineCallSite struct {
+	Location SymbolLocation `json:"location"`
+	Anchor   SymbolAnchor   `json:"anchor"`
+	Inlined  bool           `json:"inlined"`
+	Error    string         `json:"error,omitempty"` // Why the call couldn't be inlined, e.g. because the reference isn't a call
+}
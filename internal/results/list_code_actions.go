@@ -0,0 +1,109 @@
+package results
+
+import (
+	"fmt"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+// ListCodeActionsToolResult represents the result of the list_code_actions tool
+type ListCodeActionsToolResult struct {
+	Message     string                  `json:"message"`
+	Arguments   ListCodeActionsToolArgs `json:"arguments"`
+	Diagnostics []CodeDiagnostic        `json:"diagnostics"` // Diagnostics overlapping the range, which quick fixes are offered for
+	CodeActions []CodeActionInfo        `json:"code_actions"`
+}
+
+// ListCodeActionsToolArgs represents the arguments for the list code actions tool
+type ListCodeActionsToolArgs struct {
+	SymbolAnchor string   `json:"symbol_anchor,omitempty"`
+	FilePath     string   `json:"file_path,omitempty"`
+	Line         int      `json:"line,omitempty"`
+	Column       int      `json:"column,omitempty"`
+	EndLine      int      `json:"end_line,omitempty"`
+	EndColumn    int      `json:"end_column,omitempty"`
+	EndAnchor    string   `json:"end_anchor,omitempty"`
+	Kinds        []string `json:"kinds,omitempty"`
+}
+
+// DiagnosticSeverity represents the severity of a diagnostic as an enum
+type DiagnosticSeverity string
+
+const (
+	DiagnosticSeverityError       DiagnosticSeverity = "error"
+	DiagnosticSeverityWarning     DiagnosticSeverity = "warning"
+	DiagnosticSeverityInformation DiagnosticSeverity = "information"
+	DiagnosticSeverityHint        DiagnosticSeverity = "hint"
+)
+
+// NewDiagnosticSeverity returns the DiagnosticSeverity for a given LSP diagnostic severity, which defaults to error
+func NewDiagnosticSeverity(severity types.DiagnosticSeverity) DiagnosticSeverity {
+	switch severity {
+	case types.DiagnosticSeverityWarning:
+		return DiagnosticSeverityWarning
+	case types.DiagnosticSeverityInformation:
+		return DiagnosticSeverityInformation
+	case types.DiagnosticSeverityHint:
+		return DiagnosticSeverityHint
+	default:
+		return DiagnosticSeverityError
+	}
+}
+
+// CodeDiagnostic represents a diagnostic, using display coordinates
+type CodeDiagnostic struct {
+	Severity    DiagnosticSeverity `json:"severity"`
+	Source      string             `json:"source,omitempty"` // E.g. "compiler", or the name of an analyzer
+	Code        string             `json:"code,omitempty"`
+	Message     string             `json:"message"`
+	StartLine   int                `json:"start_line"`   // Display line (starts at 1)
+	StartColumn int                `json:"start_column"` // Display column (starts at 1)
+	EndLine     int                `json:"end_line"`     // Display line (starts at 1)
+	EndColumn   int                `json:"end_column"`   // Display column (starts at 1)
+}
+
+// NewCodeDiagnostic converts an LSP diagnostic to display coordinates
+func NewCodeDiagnostic(diagnostic types.Diagnostic) CodeDiagnostic {
+	codeDiagnostic := CodeDiagnostic{
+		Severity:    NewDiagnosticSeverity(diagnostic.Severity),
+		Source:      diagnostic.Source,
+		Message:     diagnostic.Message,
+		StartLine:   diagnostic.Range.Start.Line + 1,
+		StartColumn: diagnostic.Range.Start.Character + 1,
+		EndLine:     diagnostic.Range.End.Line + 1,
+		EndColumn:   diagnostic.Range.End.Character + 1,
+	}
+	if diagnostic.Code != nil {
+		codeDiagnostic.Code = fmt.Sprint(diagnostic.Code)
+	}
+	return codeDiagnostic
+}
+
+// CodeActionInfo represents a code action, like a quick fix or a refactoring
+type CodeActionInfo struct {
+	Title          string   `json:"title"` // Identifies the code action for apply_code_action
+	Kind           string   `json:"kind,omitempty"`
+	Preferred      bool     `json:"preferred,omitempty"`       // Whether the code action is the preferred fix for its diagnostics
+	DisabledReason string   `json:"disabled_reason,omitempty"` // Why the code action can't be applied, if it's disabled
+	Fixes          []string `json:"fixes,omitempty"`           // Messages of the diagnostics fixed by the code action
+	Command        string   `json:"command,omitempty"`         // Language server command executed by the code action, if any
+}
+
+// NewCodeActionInfo describes an LSP code action
+func NewCodeActionInfo(action types.CodeAction) CodeActionInfo {
+	info := CodeActionInfo{
+		Title:     action.Title,
+		Kind:      string(action.Kind),
+		Preferred: action.IsPreferred,
+	}
+	if action.Disabled != nil {
+		info.DisabledReason = action.Disabled.Reason
+	}
+	for _, diagnostic := range action.Diagnostics {
+		info.Fixes = append(info.Fixes, diagnostic.Message)
+	}
+	if action.Command != nil {
+		info.Command = action.Command.Command
+	}
+	return info
+}
@@ -0,0 +1,74 @@
+package results
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDiagnosticSeverity(t *testing.T) {
+	tests := []struct {
+		input    types.DiagnosticSeverity
+		expected DiagnosticSeverity
+	}{
+		{types.DiagnosticSeverityError, DiagnosticSeverityError},
+		{types.DiagnosticSeverityWarning, DiagnosticSeverityWarning},
+		{types.DiagnosticSeverityInformation, DiagnosticSeverityInformation},
+		{types.DiagnosticSeverityHint, DiagnosticSeverityHint},
+		{0, DiagnosticSeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.expected), func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewDiagnosticSeverity(tt.input))
+		})
+	}
+}
+
+func TestNewCodeDiagnostic(t *testing.T) {
+	var diagnostic types.Diagnostic
+	err := json.Unmarshal([]byte(`{
+		"range": {"start": {"line": 4, "character": 1}, "end": {"line": 4, "character": 2}},
+		"severity": 2,
+		"code": "unusedvariable",
+		"source": "unusedvariable",
+		"message": "declared and not used: x"
+	}`), &diagnostic)
+	assert.NoError(t, err)
+
+	expected := CodeDiagnostic{
+		Severity:    DiagnosticSeverityWarning,
+		Source:      "unusedvariable",
+		Code:        "unusedvariable",
+		Message:     "declared and not used: x",
+		StartLine:   5,
+		StartColumn: 2,
+		EndLine:     5,
+		EndColumn:   3,
+	}
+	assert.Equal(t, expected, NewCodeDiagnostic(diagnostic))
+
+	diagnostic.Code = float64(1001) // Numeric codes are unmarshaled as float64
+	assert.Equal(t, "1001", NewCodeDiagnostic(diagnostic).Code)
+}
+
+func TestNewCodeActionInfo(t *testing.T) {
+	action := types.CodeAction{
+		Title:       "Remove variable x",
+		Kind:        types.CodeActionKindQuickFix,
+		IsPreferred: true,
+		Diagnostics: []types.Diagnostic{{Message: "declared and not used: x"}},
+		Command:     &types.Command{Title: "Remove variable x", Command: "gopls.apply_fix"},
+	}
+
+	expected := CodeActionInfo{
+		Title:     "Remove variable x",
+		Kind:      "quickfix",
+		Preferred: true,
+		Fixes:     []string{"declared and not used: x"},
+		Command:   "gopls.apply_fix",
+	}
+	assert.Equal(t, expected, NewCodeActionInfo(action))
+}
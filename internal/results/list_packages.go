@@ -0,0 +1,46 @@
+package results
+
+// ListPackagesToolResult represents the result of the list_packages tool
+type ListPackagesToolResult struct {
+	Message    string               `json:"message"`
+	Arguments  ListPackagesToolArgs `json:"arguments"`
+	Modules    []ModuleInfo         `json:"modules,omitempty"`     // Modules containing the listed workspace packages
+	Total      int                  `json:"total"`                 // Total number of packages across all pages
+	Truncated  bool                 `json:"truncated"`             // Whether more packages are available
+	NextCursor Cursor               `json:"next_cursor,omitempty"` // Cursor for the next page, if truncated
+	Packages   []PackageInfo        `json:"packages"`
+}
+
+// ListPackagesToolArgs represents the arguments for the list packages tool
+type ListPackagesToolArgs struct {
+	Pattern         string `json:"pattern,omitempty"`
+	IncludeTests    bool   `json:"include_tests,omitempty"`
+	IncludeExternal bool   `json:"include_external,omitempty"`
+	Limit           int    `json:"limit,omitempty"`
+	Cursor          string `json:"cursor,omitempty"`
+}
+
+// ModuleInfo represents a module that contains packages
+type ModuleInfo struct {
+	Path      string `json:"path"`
+	Version   string `json:"version,omitempty"` // Only for dependencies
+	GoMod     string `json:"go_mod,omitempty"`  // Path of the go.mod file, absolute for modules outside the workspace
+	Workspace bool   `json:"workspace"`         // Whether the module is part of the workspace, rather than a dependency
+}
+
+// PackageInfo represents a package, or a test variant of a package
+type PackageInfo struct {
+	ImportPath string            `json:"import_path"`
+	Directory  string            `json:"directory,omitempty"`   // Relative to the workspace root; not included for external packages
+	ModulePath string            `json:"module_path,omitempty"` // Path of the module containing the package
+	ForTest    string            `json:"for_test,omitempty"`    // Import path of the package under test, for test variants
+	External   bool              `json:"external"`              // Whether the package is importable but outside the workspace, like the standard library
+	Files      []string          `json:"files,omitempty"`       // Non-test Go files that match the build constraints of the current platform, relative to the workspace root
+	TestFiles  []PackageTestFile `json:"test_files,omitempty"`  // Only included if include_tests is true
+}
+
+// PackageTestFile represents a test file of a package and the tests it contains
+type PackageTestFile struct {
+	File  string   `json:"file"`
+	Tests []string `json:"tests,omitempty"` // Names of tests, benchmarks, examples, fuzz tests, and subtests
+}
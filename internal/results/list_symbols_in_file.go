@@ -4,6 +4,9 @@ package results
 type ListSymbolsInFileToolResult struct {
 	Message     string                    `json:"message"`
 	Arguments   ListSymbolsInFileToolArgs `json:"arguments"`
+	Total       int                       `json:"total"`                 // Total number of top-level symbols across all pages
+	Truncated   bool                      `json:"truncated"`             // Whether more symbols are available
+	NextCursor  Cursor                    `json:"next_cursor,omitempty"` // Cursor for the next page, if truncated
 	FileSymbols []FileSymbol              `json:"file_symbols,omitempty"`
 }
 
@@ -11,15 +14,20 @@ type ListSymbolsInFileToolResult struct {
 type ListSymbolsInFileToolArgs struct {
 	FilePath     string `json:"file_path"`
 	Limit        int    `json:"limit,omitempty"`
+	Cursor       string `json:"cursor,omitempty"`
 	IncludeHover bool   `json:"include_hover,omitempty"`
 }
 
 // FileSymbol represents a symbol within a file with hierarchical structure
 type FileSymbol struct {
-	Name      string         `json:"name"`
-	Kind      SymbolKind     `json:"kind"`
-	Location  SymbolLocation `json:"location"`
-	Anchor    SymbolAnchor   `json:"anchor"`
-	HoverInfo string         `json:"hover_info,omitempty"`
-	Children  []FileSymbol   `json:"children,omitempty"`
+	Name           string           `json:"name"`
+	Kind           SymbolKind       `json:"kind"`
+	GoKind         GoSymbolKind     `json:"go_kind"`
+	Exported       bool             `json:"exported"`
+	TestFunction   TestFunctionKind `json:"test_function,omitempty"` // Only for test, benchmark, example, and fuzz functions
+	Location       SymbolLocation   `json:"location"`
+	Anchor         SymbolAnchor     `json:"anchor"`
+	SemanticAnchor SymbolAnchor     `json:"semantic_anchor,omitempty"` // Only for package-level symbols and their members
+	HoverInfo      *HoverInfo       `json:"hover_info,omitempty"`
+	Children       []FileSymbol     `json:"children,omitempty"`
 }
@@ -0,0 +1,33 @@
+package results
+
+// ListSymbolsInPackageToolResult represents the result of the list_symbols_in_package tool
+type ListSymbolsInPackageToolResult struct {
+	Message        string                       `json:"message"`
+	Arguments      ListSymbolsInPackageToolArgs `json:"arguments"`
+	PackageName    string                       `json:"package_name,omitempty"`
+	ImportPath     string                       `json:"import_path,omitempty"`
+	Directory      string                       `json:"directory"`
+	Doc            string                       `json:"doc,omitempty"`          // Package doc comment
+	Files          []string                     `json:"files"`                  // Go files whose symbols were listed
+	ExportedAPI    []string                     `json:"exported_api,omitempty"` // Symbol paths of the exported API, like "Calculator.Add"
+	Total          int                          `json:"total"`                  // Total number of top-level symbols across all pages
+	Truncated      bool                         `json:"truncated"`              // Whether more symbols are available
+	NextCursor     Cursor                       `json:"next_cursor,omitempty"`  // Cursor for the next page, if truncated
+	PackageSymbols []PackageSymbol              `json:"package_symbols,omitempty"`
+}
+
+// ListSymbolsInPackageToolArgs represents the arguments for the list symbols in package tool
+type ListSymbolsInPackageToolArgs struct {
+	Package      string `json:"package"`
+	IncludeTests bool   `json:"include_tests,omitempty"`
+	ExportedOnly bool   `json:"exported_only,omitempty"`
+	Limit        int    `json:"limit,omitempty"`
+	Cursor       string `json:"cursor,omitempty"`
+	IncludeHover bool   `json:"include_hover,omitempty"`
+}
+
+// PackageSymbol represents a top-level symbol of a package, with the methods of types grouped under them across files
+type PackageSymbol struct {
+	FileSymbol
+	Methods []FileSymbol `json:"methods,omitempty"` // Only for types with methods
+}
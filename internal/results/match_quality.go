@@ -0,0 +1,81 @@
+package results
+
+import (
+	"strings"
+)
+
+// MatchQuality represents how closely a symbol name matches a search query
+type MatchQuality string
+
+const (
+	MatchQualityExact               MatchQuality = "exact"
+	MatchQualityExactIgnoreCase     MatchQuality = "exact_ignore_case"
+	MatchQualityPrefix              MatchQuality = "prefix"
+	MatchQualityPrefixIgnoreCase    MatchQuality = "prefix_ignore_case"
+	MatchQualitySubstring           MatchQuality = "substring"
+	MatchQualitySubstringIgnoreCase MatchQuality = "substring_ignore_case"
+	MatchQualityFuzzy               MatchQuality = "fuzzy"
+)
+
+// Ordered from best to worst
+var matchQualityRanks = map[MatchQuality]int{
+	MatchQualityExact:               0,
+	MatchQualityExactIgnoreCase:     1,
+	MatchQualityPrefix:              2,
+	MatchQualityPrefixIgnoreCase:    3,
+	MatchQualitySubstring:           4,
+	MatchQualitySubstringIgnoreCase: 5,
+	MatchQualityFuzzy:               6,
+}
+
+// NewMatchQuality returns the best MatchQuality of a symbol name for a query.
+// Qualified names like "example.com/pkg.Type.Method" are also matched by each of their suffixes, like "pkg.Type.Method" and "Method".
+func NewMatchQuality(name string, query string) MatchQuality {
+	best := MatchQualityFuzzy
+	for _, candidate := range qualifiedSuffixes(name) {
+		if quality := matchQuality(candidate, query); quality.Rank() < best.Rank() {
+			best = quality
+		}
+	}
+	return best
+}
+
+// Rank returns the rank of the match quality, where lower ranks are better matches
+func (q MatchQuality) Rank() int {
+	rank, ok := matchQualityRanks[q]
+	if !ok {
+		return len(matchQualityRanks)
+	}
+	return rank
+}
+
+func matchQuality(candidate string, query string) MatchQuality {
+	lowerCandidate, lowerQuery := strings.ToLower(candidate), strings.ToLower(query)
+	switch {
+	case candidate == query:
+		return MatchQualityExact
+	case lowerCandidate == lowerQuery:
+		return MatchQualityExactIgnoreCase
+	case strings.HasPrefix(candidate, query):
+		return MatchQualityPrefix
+	case strings.HasPrefix(lowerCandidate, lowerQuery):
+		return MatchQualityPrefixIgnoreCase
+	case strings.Contains(candidate, query):
+		return MatchQualitySubstring
+	case strings.Contains(lowerCandidate, lowerQuery):
+		return MatchQualitySubstringIgnoreCase
+	default:
+		return MatchQualityFuzzy
+	}
+}
+
+// qualifiedSuffixes returns a name and each of its suffixes after a dot or slash
+func qualifiedSuffixes(name string) []string {
+	suffixes := []string{name}
+	for i := 0; i < len(name); i++ {
+		if (name[i] == '.' || name[i] == '/') && i+1 < len(name) {
+			suffixes = append(suffixes, name[i+1:])
+		}
+	}
+	return suffixes
+}
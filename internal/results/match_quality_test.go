@@ -0,0 +1,49 @@
+package results
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMatchQuality(t *testing.T) {
+	tests := []struct {
+		name     string
+		symbol   string
+		query    string
+		expected MatchQuality
+	}{
+		{"Exact", "Calculator", "Calculator", MatchQualityExact},
+		{"Exact ignoring case", "Calculator", "calculator", MatchQualityExactIgnoreCase},
+		{"Prefix", "CalculatorOption", "Calculator", MatchQualityPrefix},
+		{"Prefix ignoring case", "CalculatorOption", "calc", MatchQualityPrefixIgnoreCase},
+		{"Substring", "NewCalculator", "Calculator", MatchQualitySubstring},
+		{"Substring ignoring case", "NewCalculator", "calculator", MatchQualitySubstringIgnoreCase},
+		{"Fuzzy", "NewCalculator", "nclc", MatchQualityFuzzy},
+		{"Exact method suffix", "Calculator.Add", "Add", MatchQualityExact},
+		{"Exact qualified name", "example.com/pkg.Calculator.Add", "pkg.Calculator.Add", MatchQualityExact},
+		{"Best suffix wins", "Adder.Add", "Add", MatchQualityExact},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewMatchQuality(tt.symbol, tt.query))
+		})
+	}
+}
+
+func TestMatchQuality_Rank(t *testing.T) {
+	ordered := []MatchQuality{
+		MatchQualityExact,
+		MatchQualityExactIgnoreCase,
+		MatchQualityPrefix,
+		MatchQualityPrefixIgnoreCase,
+		MatchQualitySubstring,
+		MatchQualitySubstringIgnoreCase,
+		MatchQualityFuzzy,
+	}
+	for i := 1; i < len(ordered); i++ {
+		assert.Less(t, ordered[i-1].Rank(), ordered[i].Rank(), "%s should rank better than %s", ordered[i-1], ordered[i])
+	}
+	assert.Greater(t, MatchQuality("unknown").Rank(), MatchQualityFuzzy.Rank())
+}
@@ -0,0 +1,28 @@
+package results
+
+import "github.com/averycrespi/gopls-mcp/pkg/types"
+
+// MoveSymbolToolResult represents the result of the move_symbol tool
+type MoveSymbolToolResult struct {
+	Message           string              `json:"message"`
+	Arguments         MoveSymbolToolArgs  `json:"arguments"`
+	Symbol            string              `json:"symbol"`
+	MovedDeclarations []string            `json:"moved_declarations"` // Names of the moved declarations, like "Calculator" and "(*Calculator).Add"
+	FromPackage       string              `json:"from_package"`       // Import path of the package the symbol was moved from
+	ToPackage         string              `json:"to_package"`         // Import path of the package the symbol was moved to
+	Destination       string              `json:"destination"`        // Destination file, relative to the workspace root
+	UpdatedReferences int                 `json:"updated_references"` // Number of references whose package qualifiers were changed
+	Applied           bool                `json:"applied"`            // Whether the changes were written to disk
+	WorkspaceEdit     types.WorkspaceEdit `json:"workspace_edit"`     // LSP workspace edit that makes the changes to the original files
+	FileChanges       []FileChange        `json:"file_changes"`
+}
+
+// MoveSymbolToolArgs represents the arguments for the move symbol tool
+type MoveSymbolToolArgs struct {
+	SymbolAnchor string `json:"symbol_anchor,omitempty"`
+	FilePath     string `json:"file_path,omitempty"`
+	Line         int    `json:"line,omitempty"`
+	Column       int    `json:"column,omitempty"`
+	Destination  string `json:"destination"`
+	DryRun       bool   `json:"dry_run,omitempty"`
+}
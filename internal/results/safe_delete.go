@@ -0,0 +1,33 @@
+package results
+
+// SafeDeleteToolResult represents the result of the safe_delete tool
+type SafeDeleteToolResult struct {
+	Message             string                `json:"message"`
+	Arguments           SafeDeleteToolArgs    `json:"arguments"`
+	Symbol              string                `json:"symbol"`
+	Deleted             bool                  `json:"deleted"`                        // Whether the symbol can be deleted, which it can't if references outside test files remain
+	DeletedDeclarations []string              `json:"deleted_declarations,omitempty"` // Names of the deleted declarations, like "Calculator" and "(*Calculator).Add"
+	ReferenceCount      int                   `json:"reference_count"`                // Number of references outside test files, excluding those inside the deleted declarations
+	TestReferenceCount  int                   `json:"test_reference_count"`           // Number of references in test files, which must be updated after the deletion
+	References          []SafeDeleteReference `json:"references,omitempty"`           // Remaining references, if they were requested
+	RemovedImports      []string              `json:"removed_imports,omitempty"`      // Import paths of the imports that only the deleted declarations used
+	Applied             bool                  `json:"applied"`                        // Whether the changes were written to disk
+	FileChanges         []FileChange          `json:"file_changes"`
+}
+
+// SafeDeleteToolArgs represents the arguments for the safe delete tool
+type SafeDeleteToolArgs struct {
+	SymbolAnchor   string `json:"symbol_anchor,omitempty"`
+	FilePath       string `json:"file_path,omitempty"`
+	Line           int    `json:"line,omitempty"`
+	Column         int    `json:"column,omitempty"`
+	ListReferences bool   `json:"list_references,omitempty"`
+	DryRun         bool   `json:"dry_run,omitempty"`
+}
+
+// SafeDeleteReference represents a remaining reference to a deleted symbol
+type SafeDeleteReference struct {
+	Location SymbolLocation `json:"location"`
+	Anchor   SymbolAnchor   `json:"anchor"`
+	Test     bool           `json:"test"` // Whether the reference is in a test file
+}
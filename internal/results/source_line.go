@@ -0,0 +1,7 @@
+package results
+
+// SourceLine represents a single line of source code
+type SourceLine struct {
+	DisplayLine int    `json:"line"` // Display line (starts at 1)
+	Text        string `json:"text"`
+}
@@ -2,6 +2,8 @@ package results
 
 import (
 	"fmt"
+	"go/token"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -12,7 +14,14 @@ const (
 	anchorScheme = "go"
 )
 
-// SymbolAnchor represents the encoding of the fixed position of a symbol in a file
+var (
+	// Matches method names in document symbols, like "(*Calculator).Add" or "(List[T]).Push"
+	receiverRegexp = regexp.MustCompile(`^\(\*?([^)\[]+)(\[[^)]*\])?\)\.(.+)$`)
+)
+
+// SymbolAnchor represents the encoding of a symbol, either by its fixed position in a file (go://FILE#LINE:CHAR),
+// or semantically by its package import path and symbol path (go://IMPORTPATH#Type.Method).
+// Positional anchors may end with the identifier expected at that position (go://FILE#LINE:CHAR@Name), to detect stale anchors.
 type SymbolAnchor string
 
 // NewSymbolAnchor creates a new SymbolAnchor from a file, display line, and display character
@@ -20,12 +29,73 @@ func NewSymbolAnchor(file string, displayLine int, displayChar int) SymbolAnchor
 	return SymbolAnchor(fmt.Sprintf("%s://%s#%d:%d", anchorScheme, file, displayLine, displayChar))
 }
 
-// IsValid checks if the anchor has a valid format
+// NewSemanticSymbolAnchor creates a new semantic SymbolAnchor from a package import path and symbol path (e.g. "Calculator.Add").
+// Unlike positional anchors, semantic anchors remain valid when code above the symbol is edited.
+func NewSemanticSymbolAnchor(importPath string, symbolPath string) SymbolAnchor {
+	return SymbolAnchor(fmt.Sprintf("%s://%s#%s", anchorScheme, importPath, symbolPath))
+}
+
+// NewSymbolIdentifier converts a symbol name to the identifier at its position, e.g. "(*Calculator).Add" or "pkg.Calculator.Add" to "Add"
+func NewSymbolIdentifier(name string) string {
+	name = NewSemanticSymbolPath(name)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// NewSemanticSymbolPath converts a document symbol name to a symbol path, e.g. "(*Calculator).Add" to "Calculator.Add"
+func NewSemanticSymbolPath(name string) string {
+	if matches := receiverRegexp.FindStringSubmatch(name); matches != nil {
+		return matches[1] + "." + matches[3]
+	}
+	return name
+}
+
+// WithName returns a copy of a positional anchor with the identifier expected at its position, replacing any existing one.
+// Semantic anchors and empty names are returned unchanged.
+func (a SymbolAnchor) WithName(name string) SymbolAnchor {
+	if name == "" || a.IsSemantic() {
+		return a
+	}
+	return SymbolAnchor(strings.TrimSuffix(string(a), "@"+a.Name()) + "@" + name)
+}
+
+// Name returns the identifier expected at the position of a positional anchor, or an empty string if there is none
+func (a SymbolAnchor) Name() string {
+	if a.IsSemantic() {
+		return ""
+	}
+	_, fragment, _ := strings.Cut(string(a), "#")
+	_, name, _ := strings.Cut(fragment, "@")
+	return name
+}
+
+// IsValid checks if the anchor has a valid positional or semantic format
 func (a SymbolAnchor) IsValid() bool {
+	if a.IsSemantic() {
+		_, _, err := a.ParseSemantic()
+		return err == nil
+	}
 	_, _, _, err := a.Parse()
 	return err == nil
 }
 
+// IsSemantic checks if the anchor is a semantic anchor, rather than a positional anchor
+func (a SymbolAnchor) IsSemantic() bool {
+	anchorStr := string(a)
+	if !strings.HasPrefix(anchorStr, anchorScheme+"://") {
+		return false
+	}
+	_, fragment, found := strings.Cut(anchorStr, "#")
+	if !found || fragment == "" {
+		return false
+	}
+	// Positional anchors always start with a line number, which can't start a Go identifier
+	first := fragment[0]
+	return first != '-' && (first < '0' || first > '9')
+}
+
 // String returns the string representation of the anchor
 func (a SymbolAnchor) String() string {
 	return string(a)
@@ -80,8 +150,13 @@ func (a SymbolAnchor) Parse() (file string, displayLine int, displayChar int, er
 		return "", 0, 0, fmt.Errorf("empty file in anchor: %s", anchorStr)
 	}
 
+	// Strip the identifier fingerprint (@Name), if any
+	coords, name, found := strings.Cut(parts[1], "@")
+	if found && !token.IsIdentifier(name) {
+		return "", 0, 0, fmt.Errorf("invalid identifier '%s' in anchor: %s", name, anchorStr)
+	}
+
 	// Parse coordinates (LINE:CHAR)
-	coords := parts[1]
 	coordParts := strings.SplitN(coords, ":", 2)
 	if len(coordParts) != 2 {
 		return "", 0, 0, fmt.Errorf("invalid coordinate format, expected 'LINE:CHAR', got: %s", coords)
@@ -107,3 +182,38 @@ func (a SymbolAnchor) Parse() (file string, displayLine int, displayChar int, er
 
 	return file, displayLine, displayChar, nil
 }
+
+// ParseSemantic parses a semantic SymbolAnchor into a package import path and symbol path
+func (a SymbolAnchor) ParseSemantic() (importPath string, symbolPath string, err error) {
+	anchorStr := string(a)
+
+	// Check scheme
+	if !strings.HasPrefix(anchorStr, anchorScheme+"://") {
+		return "", "", fmt.Errorf("invalid anchor scheme, expected '%s://', got: %s", anchorScheme, anchorStr)
+	}
+
+	// Split on the last # to separate the import path from the symbol path
+	rest := anchorStr[len(anchorScheme)+3:] // +3 for "://"
+	separator := strings.LastIndex(rest, "#")
+	if separator < 0 {
+		return "", "", fmt.Errorf("invalid anchor format, expected 'go://IMPORTPATH#SYMBOL', got: %s", anchorStr)
+	}
+
+	importPath = rest[:separator]
+	if importPath == "" {
+		return "", "", fmt.Errorf("empty import path in anchor: %s", anchorStr)
+	}
+
+	symbolPath = rest[separator+1:]
+	parts := strings.Split(symbolPath, ".")
+	if len(parts) > 2 {
+		return "", "", fmt.Errorf("invalid symbol path, expected 'NAME' or 'TYPE.MEMBER', got: %s", symbolPath)
+	}
+	for _, part := range parts {
+		if !token.IsIdentifier(part) {
+			return "", "", fmt.Errorf("invalid symbol path, '%s' is not a Go identifier: %s", part, symbolPath)
+		}
+	}
+
+	return importPath, symbolPath, nil
+}
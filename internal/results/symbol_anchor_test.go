@@ -12,6 +12,112 @@ func TestNewSymbolAnchor(t *testing.T) {
 	assert.Equal(t, expected, anchor.String())
 }
 
+func TestNewSemanticSymbolAnchor(t *testing.T) {
+	anchor := NewSemanticSymbolAnchor("example.com/pkg", "Calculator.Add")
+	assert.Equal(t, "go://example.com/pkg#Calculator.Add", anchor.String())
+}
+
+func TestNewSymbolIdentifier(t *testing.T) {
+	assert.Equal(t, "Calculator", NewSymbolIdentifier("Calculator"))
+	assert.Equal(t, "Add", NewSymbolIdentifier("(*Calculator).Add"))
+	assert.Equal(t, "Add", NewSymbolIdentifier("Calculator.Add"))
+	assert.Equal(t, "Add", NewSymbolIdentifier("example.com/pkg.Calculator.Add"))
+}
+
+func TestSymbolAnchor_WithName(t *testing.T) {
+	anchor := NewSymbolAnchor("test.go", 10, 5).WithName("Add")
+	assert.Equal(t, "go://test.go#10:5@Add", anchor.String())
+	assert.Equal(t, "Add", anchor.Name())
+
+	// Replaces an existing name
+	assert.Equal(t, "go://test.go#10:5@Sub", anchor.WithName("Sub").String())
+
+	// Leaves semantic anchors and empty names unchanged
+	assert.Equal(t, "go://test.go#10:5@Add", anchor.WithName("").String())
+	assert.Equal(t, "go://fmt#Println", SymbolAnchor("go://fmt#Println").WithName("Println").String())
+	assert.Equal(t, "", SymbolAnchor("go://fmt#Println").Name())
+	assert.Equal(t, "", NewSymbolAnchor("test.go", 10, 5).Name())
+}
+
+func TestNewSemanticSymbolPath(t *testing.T) {
+	assert.Equal(t, "Calculator", NewSemanticSymbolPath("Calculator"))
+	assert.Equal(t, "Calculator.Add", NewSemanticSymbolPath("(*Calculator).Add"))
+	assert.Equal(t, "Calculator.Value", NewSemanticSymbolPath("(Calculator).Value"))
+	assert.Equal(t, "List.Push", NewSemanticSymbolPath("(*List[T]).Push"))
+	assert.Equal(t, "Pair.First", NewSemanticSymbolPath("(Pair[K, V]).First"))
+}
+
+func TestSymbolAnchor_ParseSemantic(t *testing.T) {
+	tests := []struct {
+		name               string
+		anchor             SymbolAnchor
+		expectedImportPath string
+		expectedSymbolPath string
+		errorContains      string
+	}{
+		{
+			name:               "type member",
+			anchor:             "go://example.com/pkg#Calculator.Add",
+			expectedImportPath: "example.com/pkg",
+			expectedSymbolPath: "Calculator.Add",
+		},
+		{
+			name:               "package-level symbol",
+			anchor:             "go://fmt#Println",
+			expectedImportPath: "fmt",
+			expectedSymbolPath: "Println",
+		},
+		{
+			name:          "invalid scheme",
+			anchor:        "http://fmt#Println",
+			errorContains: "invalid anchor scheme",
+		},
+		{
+			name:          "no fragment separator",
+			anchor:        "go://fmt",
+			errorContains: "invalid anchor format",
+		},
+		{
+			name:          "empty import path",
+			anchor:        "go://#Println",
+			errorContains: "empty import path",
+		},
+		{
+			name:          "too many components",
+			anchor:        "go://fmt#A.B.C",
+			errorContains: "expected 'NAME' or 'TYPE.MEMBER'",
+		},
+		{
+			name:          "not an identifier",
+			anchor:        "go://fmt#Calculator.",
+			errorContains: "is not a Go identifier",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importPath, symbolPath, err := tt.anchor.ParseSemantic()
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedImportPath, importPath)
+				assert.Equal(t, tt.expectedSymbolPath, symbolPath)
+			}
+		})
+	}
+}
+
+func TestSymbolAnchor_IsSemantic(t *testing.T) {
+	assert.True(t, SymbolAnchor("go://example.com/pkg#Calculator.Add").IsSemantic())
+	assert.True(t, SymbolAnchor("go://fmt#_").IsSemantic())
+	assert.False(t, SymbolAnchor("go://test.go#10:5").IsSemantic())
+	assert.False(t, SymbolAnchor("go://test.go#-1:5").IsSemantic())
+	assert.False(t, SymbolAnchor("go://test.go").IsSemantic())
+	assert.False(t, SymbolAnchor("invalid").IsSemantic())
+}
+
 func TestSymbolAnchor_Parse(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -38,6 +144,20 @@ func TestSymbolAnchor_Parse(t *testing.T) {
 			expectedChar: 1,
 			expectError:  false,
 		},
+		{
+			name:         "valid anchor with name",
+			anchor:       "go://test.go#10:5@Add",
+			expectedFile: "test.go",
+			expectedLine: 10,
+			expectedChar: 5,
+			expectError:  false,
+		},
+		{
+			name:          "invalid name",
+			anchor:        "go://test.go#10:5@1x",
+			expectError:   true,
+			errorContains: "invalid identifier",
+		},
 		{
 			name:          "invalid scheme",
 			anchor:        "http://test.go#10:5",
@@ -136,6 +256,16 @@ func TestSymbolAnchor_IsValid(t *testing.T) {
 			anchor:   "go://test.go#10:5",
 			expected: true,
 		},
+		{
+			name:     "valid semantic anchor",
+			anchor:   "go://example.com/pkg#Calculator.Add",
+			expected: true,
+		},
+		{
+			name:     "invalid semantic anchor",
+			anchor:   "go://example.com/pkg#Calculator.Add.X",
+			expected: false,
+		},
 		{
 			name:     "invalid anchor",
 			anchor:   "invalid",
@@ -184,6 +314,17 @@ func TestSymbolLocation_ToAnchor(t *testing.T) {
 	assert.Equal(t, expected, anchor.String())
 }
 
+func TestSymbolLocation_Less(t *testing.T) {
+	location := SymbolLocation{File: "b.go", DisplayLine: 10, DisplayChar: 5}
+
+	assert.True(t, location.Less(SymbolLocation{File: "c.go", DisplayLine: 1, DisplayChar: 1}))
+	assert.True(t, location.Less(SymbolLocation{File: "b.go", DisplayLine: 11, DisplayChar: 1}))
+	assert.True(t, location.Less(SymbolLocation{File: "b.go", DisplayLine: 10, DisplayChar: 6}))
+	assert.False(t, location.Less(location))
+	assert.False(t, location.Less(SymbolLocation{File: "a.go", DisplayLine: 20, DisplayChar: 1}))
+	assert.False(t, location.Less(SymbolLocation{File: "b.go", DisplayLine: 10, DisplayChar: 4}))
+}
+
 func TestRoundTrip(t *testing.T) {
 	// Test that converting location -> anchor -> location preserves data
 	originalLocation := SymbolLocation{
@@ -65,6 +65,16 @@ var symbolKindMap = map[int]SymbolKind{
 	26: SymbolKindTypeParameter,
 }
 
+// IsValid checks if the symbol kind is one of the known LSP symbol kinds
+func (k SymbolKind) IsValid() bool {
+	for _, symbolKind := range symbolKindMap {
+		if k == symbolKind {
+			return true
+		}
+	}
+	return false
+}
+
 // NewSymbolKind returns the SymbolKind for a given LSP symbol kind
 func NewSymbolKind(kind int) SymbolKind {
 	symbolKind, ok := symbolKindMap[kind]
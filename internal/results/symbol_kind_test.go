@@ -108,6 +108,13 @@ func TestSymbolKindString(t *testing.T) {
 	}
 }
 
+func TestSymbolKind_IsValid(t *testing.T) {
+	assert.True(t, SymbolKindStruct.IsValid())
+	assert.True(t, SymbolKindTypeParameter.IsValid())
+	assert.False(t, SymbolKindUnknown.IsValid())
+	assert.False(t, SymbolKind("widget").IsValid())
+}
+
 func TestSymbolKindMapCompleteness(t *testing.T) {
 	// Test that all expected LSP symbol kinds are mapped
 	expectedMappings := map[int]SymbolKind{
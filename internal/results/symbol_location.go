@@ -13,3 +13,14 @@ type SymbolLocation struct {
 func (sl SymbolLocation) ToAnchor() SymbolAnchor {
 	return NewSymbolAnchor(sl.File, sl.DisplayLine, sl.DisplayChar)
 }
+
+// Less reports whether this location sorts before another location, ordering by file, line, then character
+func (sl SymbolLocation) Less(other SymbolLocation) bool {
+	if sl.File != other.File {
+		return sl.File < other.File
+	}
+	if sl.DisplayLine != other.DisplayLine {
+		return sl.DisplayLine < other.DisplayLine
+	}
+	return sl.DisplayChar < other.DisplayChar
+}
@@ -75,9 +75,81 @@ func (s *GoplsServer) registerTools() {
 	s.mcpServer.AddTool(listSymbolsInFileTool.GetTool(), listSymbolsInFileTool.Handle)
 	slog.Debug("Registered tool", "name", "list_symbols_in_file")
 
+	listSymbolsInPackageTool := tools.NewListSymbolsInPackageTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(listSymbolsInPackageTool.GetTool(), listSymbolsInPackageTool.Handle)
+	slog.Debug("Registered tool", "name", "list_symbols_in_package")
+
+	listPackagesTool := tools.NewListPackagesTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(listPackagesTool.GetTool(), listPackagesTool.Handle)
+	slog.Debug("Registered tool", "name", "list_packages")
+
+	getDocumentationTool := tools.NewGetDocumentationTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(getDocumentationTool.GetTool(), getDocumentationTool.Handle)
+	slog.Debug("Registered tool", "name", "get_documentation")
+
 	renameSymbolByAnchorTool := tools.NewRenameSymbolByAnchorTool(s.goplsClient, s.config)
 	s.mcpServer.AddTool(renameSymbolByAnchorTool.GetTool(), renameSymbolByAnchorTool.Handle)
 	slog.Debug("Registered tool", "name", "rename_symbol_by_anchor")
 
+	goToDefinitionByPositionTool := tools.NewGoToDefinitionByPositionTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(goToDefinitionByPositionTool.GetTool(), goToDefinitionByPositionTool.Handle)
+	slog.Debug("Registered tool", "name", "go_to_definition_by_position")
+
+	goToTypeDefinitionByPositionTool := tools.NewGoToTypeDefinitionByPositionTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(goToTypeDefinitionByPositionTool.GetTool(), goToTypeDefinitionByPositionTool.Handle)
+	slog.Debug("Registered tool", "name", "go_to_type_definition_by_position")
+
+	getSignatureHelpTool := tools.NewGetSignatureHelpTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(getSignatureHelpTool.GetTool(), getSignatureHelpTool.Handle)
+	slog.Debug("Registered tool", "name", "get_signature_help")
+
+	getCompletionsTool := tools.NewGetCompletionsTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(getCompletionsTool.GetTool(), getCompletionsTool.Handle)
+	slog.Debug("Registered tool", "name", "get_completions")
+
+	listCodeActionsTool := tools.NewListCodeActionsTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(listCodeActionsTool.GetTool(), listCodeActionsTool.Handle)
+	slog.Debug("Registered tool", "name", "list_code_actions")
+
+	applyCodeActionTool := tools.NewApplyCodeActionTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(applyCodeActionTool.GetTool(), applyCodeActionTool.Handle)
+	slog.Debug("Registered tool", "name", "apply_code_action")
+
+	formatFileTool := tools.NewFormatFileTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(formatFileTool.GetTool(), formatFileTool.Handle)
+	slog.Debug("Registered tool", "name", "format_file")
+
+	extractFunctionTool := tools.NewExtractFunctionTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(extractFunctionTool.GetTool(), extractFunctionTool.Handle)
+	slog.Debug("Registered tool", "name", "extract_function")
+
+	extractVariableTool := tools.NewExtractVariableTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(extractVariableTool.GetTool(), extractVariableTool.Handle)
+	slog.Debug("Registered tool", "name", "extract_variable")
+
+	inlineCallTool := tools.NewInlineCallTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(inlineCallTool.GetTool(), inlineCallTool.Handle)
+	slog.Debug("Registered tool", "name", "inline_call")
+
+	changeSignatureTool := tools.NewChangeSignatureTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(changeSignatureTool.GetTool(), changeSignatureTool.Handle)
+	slog.Debug("Registered tool", "name", "change_signature")
+
+	moveSymbolTool := tools.NewMoveSymbolTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(moveSymbolTool.GetTool(), moveSymbolTool.Handle)
+	slog.Debug("Registered tool", "name", "move_symbol")
+
+	safeDeleteTool := tools.NewSafeDeleteTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(safeDeleteTool.GetTool(), safeDeleteTool.Handle)
+	slog.Debug("Registered tool", "name", "safe_delete")
+
+	findUnusedSymbolsTool := tools.NewFindUnusedSymbolsTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(findUnusedSymbolsTool.GetTool(), findUnusedSymbolsTool.Handle)
+	slog.Debug("Registered tool", "name", "find_unused_symbols")
+
+	implementInterfaceTool := tools.NewImplementInterfaceTool(s.goplsClient, s.config)
+	s.mcpServer.AddTool(implementInterfaceTool.GetTool(), implementInterfaceTool.Handle)
+	slog.Debug("Registered tool", "name", "implement_interface")
+
 	slog.Debug("Registered all MCP tools")
 }
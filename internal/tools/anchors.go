@@ -0,0 +1,310 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+const (
+	// MaxAnchorRelocationLines is the maximum number of lines above or below a stale anchor to search for its symbol
+	MaxAnchorRelocationLines = 50
+	// MaxStaleAnchorCandidates is the maximum number of candidate anchors to include in a stale anchor error
+	MaxStaleAnchorCandidates = 10
+)
+
+// ResolvedAnchor represents a symbol anchor resolved to an LSP location
+type ResolvedAnchor struct {
+	URI             string
+	Position        types.Position
+	Name            string               // Identifier at the position, if known
+	RelocatedAnchor results.SymbolAnchor // Anchor the symbol was found at, if the original anchor was stale
+}
+
+// StaleAnchorError is returned when the identifier at a positional anchor doesn't match the name embedded in the anchor,
+// and the symbol couldn't be relocated unambiguously or the anchor was resolved for an edit
+type StaleAnchorError struct {
+	Anchor     results.SymbolAnchor
+	Found      string
+	Candidates []results.SymbolAnchor
+}
+
+// Error returns the error message, including any candidate anchors
+func (e *StaleAnchorError) Error() string {
+	found := "no identifier"
+	if e.Found != "" {
+		found = fmt.Sprintf("'%s'", e.Found)
+	}
+	msg := fmt.Sprintf("anchor is stale: expected identifier '%s' at %s, but found %s", e.Anchor.Name(), e.Anchor, found)
+
+	if len(e.Candidates) == 0 {
+		return msg + fmt.Sprintf("; no candidates were found within %d lines, so look up the symbol again", MaxAnchorRelocationLines)
+	}
+	candidates := make([]string, len(e.Candidates))
+	for i, candidate := range e.Candidates {
+		candidates[i] = candidate.String()
+	}
+	return msg + "; candidate anchors are: " + strings.Join(candidates, ", ")
+}
+
+// ResolveSymbolAnchor resolves a positional or semantic symbol anchor to a file URI and LSP position.
+// If a positional anchor includes a name that doesn't match the identifier at its position,
+// then the symbol is relocated to the only nearby occurrence of the name, or a StaleAnchorError is returned.
+func ResolveSymbolAnchor(ctx context.Context, client types.Client, workspaceRoot string, anchor results.SymbolAnchor) (ResolvedAnchor, error) {
+	return resolveSymbolAnchor(ctx, client, workspaceRoot, anchor, true)
+}
+
+// ResolveSymbolAnchorForEdit resolves a symbol anchor like ResolveSymbolAnchor, for tools that change the workspace.
+// Stale positional anchors are never relocated, since editing the wrong occurrence of a name is worse than failing:
+// a StaleAnchorError with the candidate anchors is returned instead.
+func ResolveSymbolAnchorForEdit(ctx context.Context, client types.Client, workspaceRoot string, anchor results.SymbolAnchor) (ResolvedAnchor, error) {
+	return resolveSymbolAnchor(ctx, client, workspaceRoot, anchor, false)
+}
+
+// resolveSymbolAnchor resolves a symbol anchor, relocating stale positional anchors if relocate is set
+func resolveSymbolAnchor(ctx context.Context, client types.Client, workspaceRoot string, anchor results.SymbolAnchor, relocate bool) (ResolvedAnchor, error) {
+	if !anchor.IsSemantic() {
+		file, position, err := anchor.ToFilePosition()
+		if err != nil {
+			return ResolvedAnchor{}, err
+		}
+		return verifyAnchor(anchor, file, position, workspaceRoot, relocate)
+	}
+
+	importPath, symbolPath, err := anchor.ParseSemantic()
+	if err != nil {
+		return ResolvedAnchor{}, err
+	}
+	name := results.NewSymbolIdentifier(symbolPath)
+
+	// Search the document symbols of the package's files, which cover the workspace, its dependencies and the standard library,
+	// and unlike workspace symbols don't depend on the gopls symbol search settings
+	qualifiedName := importPath + "." + symbolPath
+	dir, err := ImportPackageDir(workspaceRoot, importPath)
+	if err != nil {
+		return ResolvedAnchor{}, fmt.Errorf("symbol %s not found: %w", qualifiedName, err)
+	}
+	files, err := ListPackageFiles(dir, true)
+	if err != nil {
+		return ResolvedAnchor{}, fmt.Errorf("failed to read package directory: %w", err)
+	}
+	for _, file := range anchorSearchOrder(files) {
+		uri := PathToUri(file, workspaceRoot)
+		symbols, err := client.GetDocumentSymbols(ctx, uri)
+		if err != nil {
+			continue
+		}
+		if sym := FindSemanticSymbol(symbols, symbolPath); sym != nil {
+			return ResolvedAnchor{URI: uri, Position: sym.SelectionRange.Start, Name: name}, nil
+		}
+	}
+
+	return ResolvedAnchor{}, fmt.Errorf("symbol %s not found", qualifiedName)
+}
+
+// anchorSearchOrder orders the sorted files of a package by where semantic anchors are searched: files of the current build
+// context before files excluded by build constraints, so that symbols declared for several platforms resolve consistently,
+// and non-test files before test files
+func anchorSearchOrder(files []string) []string {
+	rank := func(file string) int {
+		r := 0
+		if !MatchesBuildContext(file) {
+			r += 2
+		}
+		if IsTestFile(file) {
+			r++
+		}
+		return r
+	}
+	ordered := append([]string(nil), files...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank(ordered[i]) < rank(ordered[j])
+	})
+	return ordered
+}
+
+// RelocationMessage returns a message suffix that reports a relocated stale anchor, or an empty string if the anchor wasn't stale
+func RelocationMessage(resolved ResolvedAnchor) string {
+	if resolved.RelocatedAnchor == "" {
+		return ""
+	}
+	return fmt.Sprintf(" The symbol anchor was stale, so the symbol was relocated to %s.", resolved.RelocatedAnchor)
+}
+
+// verifyAnchor checks that the identifier at a positional anchor matches its name, relocating the symbol if it doesn't and relocate is set
+func verifyAnchor(anchor results.SymbolAnchor, file string, position types.Position, workspaceRoot string, relocate bool) (ResolvedAnchor, error) {
+	uri := PathToUri(file, workspaceRoot)
+	resolved := ResolvedAnchor{URI: uri, Position: position}
+
+	// If the file can't be read, then let the language server report the problem
+	lines, err := ReadFileLines(UriToPath(uri))
+	if err != nil {
+		resolved.Name = anchor.Name()
+		return resolved, nil
+	}
+
+	found := ""
+	if position.Line < len(lines) {
+		found = IdentifierAt(lines[position.Line], position.Character)
+	}
+	name := anchor.Name()
+	if name == "" || found == name {
+		resolved.Name = found
+		return resolved, nil
+	}
+
+	candidates := FindIdentifierOccurrences(lines, name, position.Line, MaxAnchorRelocationLines)
+	if relocate && len(candidates) == 1 {
+		resolved.Position = candidates[0]
+		resolved.Name = name
+		resolved.RelocatedAnchor = results.NewSymbolAnchor(file, candidates[0].Line+1, candidates[0].Character+1).WithName(name)
+		return resolved, nil
+	}
+
+	staleErr := &StaleAnchorError{Anchor: anchor, Found: found}
+	for _, candidate := range candidates[:min(len(candidates), MaxStaleAnchorCandidates)] {
+		staleErr.Candidates = append(staleErr.Candidates, results.NewSymbolAnchor(file, candidate.Line+1, candidate.Character+1).WithName(name))
+	}
+	return ResolvedAnchor{}, staleErr
+}
+
+// IdentifierAt returns the Go identifier starting at a UTF-16 character offset in a line,
+// or an empty string if no identifier starts there
+func IdentifierAt(line string, character int) string {
+	start, ok := utf16OffsetToByte(line, character)
+	if !ok {
+		return ""
+	}
+	if prev, size := utf8.DecodeLastRuneInString(line[:start]); size > 0 && isIdentifierRune(prev) {
+		return ""
+	}
+
+	end := start
+	for end < len(line) {
+		r, size := utf8.DecodeRuneInString(line[end:])
+		if !isIdentifierRune(r) {
+			break
+		}
+		end += size
+	}
+
+	identifier := line[start:end]
+	if !token.IsIdentifier(identifier) {
+		return ""
+	}
+	return identifier
+}
+
+// FindIdentifierOccurrences finds the positions of a Go identifier within maxDistance lines of a line, ordered by distance.
+// Occurrences in line comments are ignored.
+func FindIdentifierOccurrences(lines []string, name string, line int, maxDistance int) []types.Position {
+	var occurrences []types.Position
+	for distance := 0; distance <= maxDistance; distance++ {
+		candidateLines := []int{line - distance, line + distance}
+		if distance == 0 {
+			candidateLines = candidateLines[:1]
+		}
+		for _, candidateLine := range candidateLines {
+			if candidateLine < 0 || candidateLine >= len(lines) {
+				continue
+			}
+			text := lines[candidateLine]
+			if comment := strings.Index(text, "//"); comment >= 0 {
+				text = text[:comment]
+			}
+			for offset := 0; offset < len(text); {
+				index := strings.Index(text[offset:], name)
+				if index < 0 {
+					break
+				}
+				start := offset + index
+				character := utf16Length(text[:start])
+				if IdentifierAt(text, character) == name {
+					occurrences = append(occurrences, types.Position{Line: candidateLine, Character: character})
+				}
+				offset = start + len(name)
+			}
+		}
+	}
+	return occurrences
+}
+
+// isIdentifierRune checks if a rune can be part of a Go identifier
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// utf16OffsetToByte converts a UTF-16 character offset (as used by LSP) to a byte offset in a line
+func utf16OffsetToByte(line string, character int) (int, bool) {
+	units := 0
+	for i, r := range line {
+		if units == character {
+			return i, true
+		}
+		if units > character {
+			return 0, false
+		}
+		units += len(utf16.Encode([]rune{r}))
+	}
+	return len(line), units == character
+}
+
+// utf16Length returns the length of a string in UTF-16 code units
+func utf16Length(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
+
+// FindSemanticSymbol finds the document symbol with a symbol path (e.g. "Calculator.Add"), or returns nil if there is none
+func FindSemanticSymbol(symbols []types.DocumentSymbol, symbolPath string) *types.DocumentSymbol {
+	for i := range symbols {
+		sym := &symbols[i]
+		name := results.NewSemanticSymbolPath(sym.Name)
+		if name == symbolPath {
+			return sym
+		}
+		if member, found := strings.CutPrefix(symbolPath, name+"."); found {
+			for j := range sym.Children {
+				if sym.Children[j].Name == member {
+					return &sym.Children[j]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SemanticSymbolPath returns the symbol path (e.g. "Calculator.Add") of the document symbol whose name starts at a position,
+// or an empty string if there is no such package-level symbol or member
+func SemanticSymbolPath(symbols []types.DocumentSymbol, position types.Position) string {
+	for _, sym := range symbols {
+		name := results.NewSemanticSymbolPath(sym.Name)
+		if sym.SelectionRange.Start == position {
+			return name
+		}
+		if !rangeContains(sym.Range, position) {
+			continue
+		}
+		for _, child := range sym.Children {
+			if child.SelectionRange.Start == position {
+				return name + "." + child.Name
+			}
+		}
+	}
+	return ""
+}
+
+// comparePositions compares two positions, returning a negative number if a is before b, zero if equal, and positive otherwise
+func comparePositions(a types.Position, b types.Position) int {
+	if a.Line != b.Line {
+		return a.Line - b.Line
+	}
+	return a.Character - b.Character
+}
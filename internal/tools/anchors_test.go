@@ -0,0 +1,211 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+var anchorTestSymbols = []types.DocumentSymbol{
+	{
+		Name:           "Calculator",
+		Kind:           23, // Struct
+		Range:          types.Range{Start: types.Position{Line: 5}, End: types.Position{Line: 7, Character: 1}},
+		SelectionRange: types.Range{Start: types.Position{Line: 5, Character: 5}, End: types.Position{Line: 5, Character: 15}},
+		Children: []types.DocumentSymbol{
+			{
+				Name:           "value",
+				Kind:           8, // Field
+				Range:          types.Range{Start: types.Position{Line: 6, Character: 1}, End: types.Position{Line: 6, Character: 10}},
+				SelectionRange: types.Range{Start: types.Position{Line: 6, Character: 1}, End: types.Position{Line: 6, Character: 6}},
+			},
+		},
+	},
+	{
+		Name:           "(*Calculator).Add",
+		Kind:           6, // Method
+		Range:          types.Range{Start: types.Position{Line: 10}, End: types.Position{Line: 13, Character: 1}},
+		SelectionRange: types.Range{Start: types.Position{Line: 10, Character: 23}, End: types.Position{Line: 10, Character: 26}},
+	},
+}
+
+func TestFindSemanticSymbol(t *testing.T) {
+	tests := []struct {
+		symbolPath string
+		expected   string
+	}{
+		{"Calculator", "Calculator"},
+		{"Calculator.Add", "(*Calculator).Add"},
+		{"Calculator.value", "value"},
+		{"Calculator.Missing", ""},
+		{"Missing", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbolPath, func(t *testing.T) {
+			result := FindSemanticSymbol(anchorTestSymbols, tt.symbolPath)
+			if tt.expected == "" {
+				assert.Nil(t, result)
+			} else {
+				assert.NotNil(t, result)
+				assert.Equal(t, tt.expected, result.Name)
+			}
+		})
+	}
+}
+
+func TestSemanticSymbolPath(t *testing.T) {
+	assert.Equal(t, "Calculator", SemanticSymbolPath(anchorTestSymbols, types.Position{Line: 5, Character: 5}))
+	assert.Equal(t, "Calculator.value", SemanticSymbolPath(anchorTestSymbols, types.Position{Line: 6, Character: 1}))
+	assert.Equal(t, "Calculator.Add", SemanticSymbolPath(anchorTestSymbols, types.Position{Line: 10, Character: 23}))
+	assert.Equal(t, "", SemanticSymbolPath(anchorTestSymbols, types.Position{Line: 11, Character: 4}))
+}
+
+func TestResolveSymbolAnchor_Positional(t *testing.T) {
+	// Positional anchors are resolved without the client, and files that can't be read are left for the language server to report
+	resolved, err := ResolveSymbolAnchor(context.Background(), nil, "/workspace", results.SymbolAnchor("go://pkg/calc.go#11:24"))
+	assert.NoError(t, err)
+	assert.Equal(t, "file:///workspace/pkg/calc.go", resolved.URI)
+	assert.Equal(t, types.Position{Line: 10, Character: 23}, resolved.Position)
+	assert.Empty(t, resolved.RelocatedAnchor)
+
+	_, err = ResolveSymbolAnchor(context.Background(), nil, "/workspace", results.SymbolAnchor("go://pkg/calc.go#0:1"))
+	assert.Error(t, err)
+}
+
+func TestResolveSymbolAnchor_Stale(t *testing.T) {
+	root := t.TempDir()
+	source := `package calc
+
+// Add adds two numbers
+func Add(a, b int) int {
+	return a + b
+}
+
+func Sub(a, b int) int {
+	return a - b
+}
+
+func Twice(a int) int {
+	return Sub(a, -a) + Sub(0, -a)
+}
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "calc.go"), []byte(source), 0o644))
+
+	tests := []struct {
+		name             string
+		anchor           results.SymbolAnchor
+		expectedPosition types.Position
+		expectedName     string
+		relocatedAnchor  results.SymbolAnchor
+		errorContains    string
+	}{
+		{
+			name:             "Matching name",
+			anchor:           "go://calc.go#4:6@Add",
+			expectedPosition: types.Position{Line: 3, Character: 5},
+			expectedName:     "Add",
+		},
+		{
+			name:             "Name read from file",
+			anchor:           "go://calc.go#4:6",
+			expectedPosition: types.Position{Line: 3, Character: 5},
+			expectedName:     "Add",
+		},
+		{
+			name:             "Relocated to only occurrence",
+			anchor:           "go://calc.go#2:6@Add",
+			expectedPosition: types.Position{Line: 3, Character: 5},
+			expectedName:     "Add",
+			relocatedAnchor:  "go://calc.go#4:6@Add",
+		},
+		{
+			name:          "Ambiguous occurrences",
+			anchor:        "go://calc.go#5:2@Sub",
+			errorContains: "candidate anchors are: go://calc.go#8:6@Sub, go://calc.go#13:9@Sub, go://calc.go#13:22@Sub",
+		},
+		{
+			name:          "No occurrences",
+			anchor:        "go://calc.go#4:6@Mul",
+			errorContains: "expected identifier 'Mul' at go://calc.go#4:6@Mul, but found 'Add'; no candidates were found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := ResolveSymbolAnchor(context.Background(), nil, root, tt.anchor)
+			if tt.errorContains != "" {
+				var staleErr *StaleAnchorError
+				assert.ErrorAs(t, err, &staleErr)
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPosition, resolved.Position)
+			assert.Equal(t, tt.expectedName, resolved.Name)
+			assert.Equal(t, tt.relocatedAnchor, resolved.RelocatedAnchor)
+		})
+	}
+}
+
+func TestResolveSymbolAnchorForEdit_Stale(t *testing.T) {
+	root := t.TempDir()
+	source := "package calc\n\n// Add adds two numbers\nfunc Add(a, b int) int {\n\treturn a + b\n}\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "calc.go"), []byte(source), 0o644))
+
+	resolved, err := ResolveSymbolAnchorForEdit(context.Background(), nil, root, "go://calc.go#4:6@Add")
+	assert.NoError(t, err)
+	assert.Equal(t, types.Position{Line: 3, Character: 5}, resolved.Position)
+
+	// A stale anchor with a single nearby occurrence is reported with the candidate instead of being relocated
+	_, err = ResolveSymbolAnchorForEdit(context.Background(), nil, root, "go://calc.go#2:6@Add")
+	var staleErr *StaleAnchorError
+	assert.ErrorAs(t, err, &staleErr)
+	assert.Equal(t, []results.SymbolAnchor{"go://calc.go#4:6@Add"}, staleErr.Candidates)
+}
+
+func TestAnchorSearchOrder(t *testing.T) {
+	if runtime.GOOS == "plan9" {
+		t.Skip("plan9 files match the build context")
+	}
+	dir := t.TempDir()
+	files := []string{"a_test.go", "b.go", "c_plan9.go", "d.go"}
+	for i, name := range files {
+		files[i] = filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(files[i], []byte("package calc\n"), 0o644))
+	}
+
+	ordered := anchorSearchOrder(files)
+	for i, name := range []string{"b.go", "d.go", "a_test.go", "c_plan9.go"} {
+		assert.Equal(t, filepath.Join(dir, name), ordered[i])
+	}
+}
+
+func TestIdentifierAt(t *testing.T) {
+	assert.Equal(t, "Add", IdentifierAt("func Add(a, b int) int {", 5))
+	assert.Equal(t, "", IdentifierAt("func Add(a, b int) int {", 6))
+	assert.Equal(t, "", IdentifierAt("func Add(a, b int) int {", 4))
+	assert.Equal(t, "", IdentifierAt("func Add(a, b int) int {", 100))
+	assert.Equal(t, "", IdentifierAt("x := 1", 5))
+	// Character offsets are in UTF-16 code units
+	assert.Equal(t, "name", IdentifierAt(`s := "😀"; name := s`, 11))
+}
+
+func TestFindIdentifierOccurrences(t *testing.T) {
+	lines := []string{
+		"x := Add(1, 2) // Add again",
+		"y := Adder(x)",
+		"z := Add(x, y)",
+	}
+	// Occurrences are ordered by distance, then from top to bottom
+	assert.Equal(t, []types.Position{{Line: 0, Character: 5}, {Line: 2, Character: 5}}, FindIdentifierOccurrences(lines, "Add", 1, 5))
+	assert.Equal(t, []types.Position{{Line: 0, Character: 5}}, FindIdentifierOccurrences(lines, "Add", 0, 1))
+	assert.Empty(t, FindIdentifierOccurrences(lines, "Sub", 1, 5))
+}
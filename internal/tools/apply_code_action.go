@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ApplyCodeActionTool handles apply code action requests
+type ApplyCodeActionTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewApplyCodeActionTool creates a new apply code action tool
+func NewApplyCodeActionTool(client types.Client, config types.Config) *ApplyCodeActionTool {
+	return &ApplyCodeActionTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *ApplyCodeActionTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Apply a code action from list_code_actions to the workspace, returning a unified diff of each changed file. Use the same range as list_code_actions, and dry_run to preview the changes without writing them."),
+		},
+		rangeToolOptions()...,
+	)
+	options = append(options,
+		mcp.WithString("title", mcp.Required(), mcp.Description("Title of the code action to apply, exactly as returned by list_code_actions")),
+		mcp.WithString("kind", mcp.Description("Kind of the code action to apply, to disambiguate code actions with the same title")),
+		mcp.WithBoolean("dry_run", mcp.Description("Whether to only preview the changes, without writing them to disk (default: false)")),
+	)
+	tool := mcp.NewTool("apply_code_action", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *ApplyCodeActionTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	title := mcp.ParseString(req, "title", "")
+	if title == "" {
+		slog.Debug("MCP tool called with missing title parameter", "tool", "apply_code_action")
+		return mcp.NewToolResultError("title parameter is required"), nil
+	}
+
+	rangeArgs, err := parseRangeArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid range parameters", "tool", "apply_code_action", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	kind := mcp.ParseString(req, "kind", "")
+	dryRun := mcp.ParseBoolean(req, "dry_run", false)
+
+	slog.Debug("MCP tool called",
+		"tool", "apply_code_action",
+		"symbol_anchor", rangeArgs.anchor,
+		"file_path", rangeArgs.filePath,
+		"line", rangeArgs.line,
+		"column", rangeArgs.column,
+		"end_line", rangeArgs.endLine,
+		"end_column", rangeArgs.endColumn,
+		"end_anchor", rangeArgs.endAnchor,
+		"title", title,
+		"kind", kind,
+		"dry_run", dryRun)
+
+	resolved, rng, err := rangeArgs.resolveForEdit(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve range",
+			"tool", "apply_code_action",
+			"symbol_anchor", rangeArgs.anchor,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid range: %v", err)), nil
+	}
+
+	var kinds []types.CodeActionKind
+	if kind != "" {
+		kinds = []types.CodeActionKind{types.CodeActionKind(kind)}
+	}
+	actions, _, err := GetCodeActions(ctx, t.client, resolved.URI, rng, kinds)
+	if err != nil {
+		slog.Error("Failed to get code actions",
+			"tool", "apply_code_action",
+			"uri", resolved.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get code actions: %v", err)), nil
+	}
+
+	action, err := FindCodeAction(actions, title, types.CodeActionKind(kind))
+	if err != nil {
+		slog.Debug("Code action not found",
+			"tool", "apply_code_action",
+			"title", title,
+			"error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	changes, err := ApplyCodeAction(ctx, t.client, t.config.WorkspaceRoot, *action, dryRun)
+	if err != nil {
+		slog.Error("Failed to apply code action",
+			"tool", "apply_code_action",
+			"title", title,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to apply code action %q: %v", title, err)), nil
+	}
+
+	actionInfo := results.NewCodeActionInfo(*action)
+	toolResult := results.ApplyCodeActionToolResult{
+		Arguments: results.ApplyCodeActionToolArgs{
+			SymbolAnchor: rangeArgs.anchor,
+			FilePath:     rangeArgs.filePath,
+			Line:         rangeArgs.line,
+			Column:       rangeArgs.column,
+			EndLine:      rangeArgs.endLine,
+			EndColumn:    rangeArgs.endColumn,
+			EndAnchor:    rangeArgs.endAnchor,
+			Title:        title,
+			Kind:         kind,
+			DryRun:       dryRun,
+		},
+		CodeAction:  &actionInfo,
+		Applied:     !dryRun && len(changes) > 0,
+		FileChanges: make([]results.FileChange, 0, len(changes)),
+	}
+	toolResult.FileChanges = append(toolResult.FileChanges, changes...)
+
+	switch {
+	case len(changes) == 0:
+		toolResult.Message = fmt.Sprintf("Code action %q made no changes.", title)
+	case dryRun:
+		toolResult.Message = fmt.Sprintf("Code action %q would change %d files. No changes were written (dry run).", title, len(changes))
+	default:
+		toolResult.Message = fmt.Sprintf("Applied code action %q, changing %d files.", title, len(changes))
+	}
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "apply_code_action",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "apply_code_action",
+		"file_change_count", len(toolResult.FileChanges),
+		"applied", toolResult.Applied,
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
@@ -0,0 +1,257 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ChangeSignatureTool handles change signature requests
+type ChangeSignatureTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewChangeSignatureTool creates a new change signature tool
+func NewChangeSignatureTool(client types.Client, config types.Config) *ChangeSignatureTool {
+	return &ChangeSignatureTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *ChangeSignatureTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Change the parameters of a function or method: add, remove, or reorder them, updating the declaration and every call site. Returns a unified diff of each changed file; use dry_run to preview the changes without writing them. The position may be on the declaration of the function or on any reference to it."),
+		},
+		positionToolOptions()...,
+	)
+	options = append(options,
+		mcp.WithArray("parameters",
+			mcp.Required(),
+			mcp.Description("The new parameter list, in order. Each item is either an existing parameter, given by its name or by its position like \"#2\", or a new parameter with a default argument for call sites like \"ctx context.Context = context.Background()\". Existing parameters that aren't listed are removed."),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithBoolean("dry_run", mcp.Description("Whether to only preview the changes, without writing them to disk (default: false)")),
+	)
+	tool := mcp.NewTool("change_signature", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *ChangeSignatureTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if mcp.ParseArgument(req, "parameters", nil) == nil {
+		slog.Debug("MCP tool called with missing parameters parameter", "tool", "change_signature")
+		return mcp.NewToolResultError("parameters parameter is required"), nil
+	}
+	specs := ParseStringArray(req, "parameters")
+
+	position, err := parsePositionArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid position parameters", "tool", "change_signature", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	dryRun := mcp.ParseBoolean(req, "dry_run", false)
+
+	slog.Debug("MCP tool called",
+		"tool", "change_signature",
+		"symbol_anchor", position.anchor,
+		"file_path", position.filePath,
+		"line", position.line,
+		"column", position.column,
+		"parameters", specs,
+		"dry_run", dryRun)
+
+	resolved, err := position.resolveForEdit(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve position",
+			"tool", "change_signature",
+			"symbol_anchor", position.anchor,
+			"error", err)
+		return mcp.NewToolResultError(position.resolveErrorMessage(err)), nil
+	}
+
+	definitions, err := t.client.GoToDefinition(ctx, resolved.URI, resolved.Position)
+	if err != nil || len(definitions) == 0 {
+		slog.Debug("Failed to find declaration",
+			"tool", "change_signature",
+			"uri", resolved.URI,
+			"error", err)
+		return mcp.NewToolResultError("No function declaration found. The position should be on the name of a function or method."), nil
+	}
+	definition := definitions[0]
+	declPath := UriToPath(definition.URI)
+	if IsReadOnlyFile(declPath) {
+		return mcp.NewToolResultError(fmt.Sprintf("The function is declared in %s, which is read-only.", declPath)), nil
+	}
+
+	session := NewEditSession(t.config.WorkspaceRoot)
+	decl, err := t.parseDeclaration(session, declPath, definition.Range.Start)
+	if err != nil {
+		slog.Debug("Failed to parse declaration",
+			"tool", "change_signature",
+			"path", declPath,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse function declaration: %v", err)), nil
+	}
+
+	newParams, err := ParseParameterSpecs(specs, decl.Params)
+	if err == nil {
+		err = decl.CheckRemovedParams(newParams)
+	}
+	if err != nil {
+		slog.Debug("Invalid parameters",
+			"tool", "change_signature",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parameters: %v", err)), nil
+	}
+
+	references, err := t.client.FindReferences(ctx, definition.URI, definition.Range.Start)
+	if err != nil {
+		slog.Error("Failed to find references",
+			"tool", "change_signature",
+			"uri", definition.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to find call sites: %v", err)), nil
+	}
+	var callSites []types.Location
+	for _, reference := range references {
+		if reference.URI != definition.URI || reference.Range.Start != definition.Range.Start {
+			callSites = append(callSites, reference)
+		}
+	}
+
+	strategy := results.SignatureChangeStrategyReferences
+	if t.changeWithCodeAction(ctx, session, definition.URI, decl, newParams, dryRun) {
+		strategy = results.SignatureChangeStrategyCodeAction
+	} else if err := ChangeSignatureByReferences(session, declPath, decl, callSites, newParams); err != nil {
+		slog.Debug("Failed to change signature",
+			"tool", "change_signature",
+			"function", decl.Name,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to change the signature of %s: %v", decl.Name, err)), nil
+	}
+
+	changes := session.Changes()
+	if !dryRun && len(changes) > 0 {
+		if err := WriteEditSession(ctx, t.client, session); err != nil {
+			slog.Error("Failed to write signature change",
+				"tool", "change_signature",
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write the signature change: %v", err)), nil
+		}
+	}
+
+	file, _ := GetDisplayPath(declPath, t.config.WorkspaceRoot)
+	toolResult := results.ChangeSignatureToolResult{
+		Arguments: results.ChangeSignatureToolArgs{
+			SymbolAnchor: position.anchor,
+			FilePath:     position.filePath,
+			Line:         position.line,
+			Column:       position.column,
+			Parameters:   specs,
+			DryRun:       dryRun,
+		},
+		Function: decl.Name,
+		Declaration: results.SymbolLocation{
+			File:        file,
+			DisplayLine: definition.Range.Start.Line + 1,      // Convert LSP coordinates to display line
+			DisplayChar: definition.Range.Start.Character + 1, // Convert LSP coordinates to display character
+		},
+		OldParameters: FormatParams(decl.Params),
+		NewParameters: FormatParams(newParams),
+		Strategy:      strategy,
+		CallSites:     len(callSites),
+		Applied:       !dryRun && len(changes) > 0,
+		FileChanges:   make([]results.FileChange, 0, len(changes)),
+	}
+	toolResult.FileChanges = append(toolResult.FileChanges, changes...)
+
+	if dryRun {
+		toolResult.Message = fmt.Sprintf("Changing the signature of %s with %d call sites would change %d files. No changes were written (dry run).", decl.Name, len(callSites), len(changes))
+	} else {
+		toolResult.Message = fmt.Sprintf("Changed the signature of %s with %d call sites, changing %d files.", decl.Name, len(callSites), len(changes))
+	}
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "change_signature",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "change_signature",
+		"strategy", strategy,
+		"call_site_count", len(callSites),
+		"file_change_count", len(toolResult.FileChanges),
+		"applied", toolResult.Applied,
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// parseDeclaration parses the function declaration whose name is at a position of a file
+func (t *ChangeSignatureTool) parseDeclaration(session *EditSession, path string, position types.Position) (*FuncDeclaration, error) {
+	content, err := session.Content(path)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := positionOffset(content, lineOffsets(content), position)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFuncDeclaration(path, content, offset)
+}
+
+// changeWithCodeAction tries to change the signature with a gopls code action, which also handles call sites whose arguments
+// have side effects. Returns false if no code action supports the change, so that the call sites should be edited instead.
+func (t *ChangeSignatureTool) changeWithCodeAction(ctx context.Context, session *EditSession, uri string, decl *FuncDeclaration, newParams []SignatureParam, dryRun bool) bool {
+	kind, index, ok := SignatureCodeAction(decl.Params, newParams)
+	if !ok {
+		return false
+	}
+
+	position := decl.ParamPosition(index)
+	kinds := []types.CodeActionKind{kind}
+	actions, _, err := GetCodeActions(ctx, t.client, uri, types.Range{Start: position, End: position}, kinds)
+	if err != nil {
+		slog.Debug("Failed to get signature code actions", "kind", kind, "error", err)
+		return false
+	}
+	action, err := FindCodeActionByKind(actions, kinds, "")
+	if err != nil {
+		slog.Debug("No signature code action available", "kind", kind, "error", err)
+		return false
+	}
+	edits, err := CodeActionEdits(ctx, t.client, *action, dryRun)
+	if err != nil {
+		slog.Debug("Failed to compute signature code action edits", "kind", kind, "error", err)
+		return false
+	}
+
+	// Edits are applied to a separate session first, so that a failure leaves the session unchanged for the fallback
+	trial := NewEditSession(session.workspaceRoot)
+	for _, edit := range edits {
+		if err := trial.ApplyWorkspaceEdit(edit); err != nil {
+			slog.Debug("Failed to apply signature code action edits", "kind", kind, "error", err)
+			return false
+		}
+	}
+	for _, edit := range edits {
+		if err := session.ApplyWorkspaceEdit(edit); err != nil {
+			return false
+		}
+	}
+	return true
+}
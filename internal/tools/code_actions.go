@@ -0,0 +1,210 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+// GetCodeActions gets the code actions for a range of a file, along with the published diagnostics overlapping the range.
+// The diagnostics are sent with the request, so that gopls includes their quick fixes.
+func GetCodeActions(ctx context.Context, client types.Client, uri string, rng types.Range, kinds []types.CodeActionKind) ([]types.CodeAction, []types.Diagnostic, error) {
+	allDiagnostics, err := client.GetDiagnostics(ctx, uri)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get diagnostics: %w", err)
+	}
+
+	diagnostics := make([]types.Diagnostic, 0)
+	for _, diagnostic := range allDiagnostics {
+		if rangesOverlap(diagnostic.Range, rng) {
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+
+	actions, err := client.GetCodeActions(ctx, uri, rng, types.CodeActionContext{
+		Diagnostics: diagnostics,
+		Only:        kinds,
+		TriggerKind: 1, // Invoked explicitly
+	})
+	if err != nil {
+		return nil, diagnostics, err
+	}
+	return actions, diagnostics, nil
+}
+
+// rangesOverlap checks if two ranges overlap, counting ranges that touch (like an empty range at the end of another) as overlapping
+func rangesOverlap(a types.Range, b types.Range) bool {
+	return comparePositions(a.Start, b.End) <= 0 && comparePositions(b.Start, a.End) <= 0
+}
+
+// codeActionKindMatches checks if a code action kind is the same as, or a subkind of, a requested kind
+func codeActionKindMatches(kind types.CodeActionKind, requested types.CodeActionKind) bool {
+	return requested == "" || kind == requested || strings.HasPrefix(string(kind), string(requested)+".")
+}
+
+// FindCodeAction finds a code action by title, and by kind if it's set
+func FindCodeAction(actions []types.CodeAction, title string, kind types.CodeActionKind) (*types.CodeAction, error) {
+	titles := make([]string, 0, len(actions))
+	for i, action := range actions {
+		if action.Title == title && codeActionKindMatches(action.Kind, kind) {
+			return &actions[i], nil
+		}
+		titles = append(titles, fmt.Sprintf("%q", action.Title))
+	}
+
+	if len(titles) == 0 {
+		return nil, fmt.Errorf("no code actions are available for the range")
+	}
+	return nil, fmt.Errorf("no code action titled %q; available code actions: %s", title, strings.Join(titles, ", "))
+}
+
+// FindCodeActionByKind finds the first enabled code action of the given kinds, trying the kinds in order.
+// If no code action is available, then the hint is added to the error message.
+func FindCodeActionByKind(actions []types.CodeAction, kinds []types.CodeActionKind, hint string) (*types.CodeAction, error) {
+	var disabled *types.CodeAction
+	for _, kind := range kinds {
+		for i, action := range actions {
+			if action.Kind != kind {
+				continue
+			}
+			if action.Disabled == nil {
+				return &actions[i], nil
+			}
+			if disabled == nil {
+				disabled = &actions[i]
+			}
+		}
+	}
+
+	if disabled != nil {
+		return nil, fmt.Errorf("code action %q is disabled: %s", disabled.Title, disabled.Disabled.Reason)
+	}
+	names := make([]string, len(kinds))
+	for i, kind := range kinds {
+		names[i] = string(kind)
+	}
+	msg := fmt.Sprintf("no %s code action is available for the range", strings.Join(names, " or "))
+	if hint != "" {
+		msg += "; " + hint
+	}
+	return nil, errors.New(msg)
+}
+
+// resolveCodeAction checks that a code action is enabled, and resolves it if its edit or command is computed lazily
+func resolveCodeAction(ctx context.Context, client types.Client, action types.CodeAction) (types.CodeAction, error) {
+	if action.Disabled != nil {
+		return action, fmt.Errorf("code action %q is disabled: %s", action.Title, action.Disabled.Reason)
+	}
+
+	// Code actions without an edit or command are computed lazily
+	if action.Edit == nil && action.Command == nil {
+		resolved, err := client.ResolveCodeAction(ctx, action)
+		if err != nil {
+			return action, err
+		}
+		action = *resolved
+	}
+	return action, nil
+}
+
+// commandDryRunError returns the error for a code action whose command would be executed during a dry run.
+// Commands can't be previewed, since gopls may do more than request edits while executing them (like running the go command).
+func commandDryRunError(action types.CodeAction) error {
+	return fmt.Errorf("code action %q runs the gopls command %s, which can't be previewed without running it; retry without dry_run to apply it",
+		action.Title, action.Command.Command)
+}
+
+// CodeActionEdits returns the workspace edits of a code action, resolving it or executing its command if needed.
+// Code actions with a command are refused if dryRun is set, and so are code actions with both an edit and a command,
+// since the command must run after the edit is written (see ApplyCodeAction).
+func CodeActionEdits(ctx context.Context, client types.Client, action types.CodeAction, dryRun bool) ([]types.WorkspaceEdit, error) {
+	action, err := resolveCodeAction(ctx, client, action)
+	if err != nil {
+		return nil, err
+	}
+
+	if action.Command == nil {
+		if action.Edit == nil {
+			return nil, nil
+		}
+		return []types.WorkspaceEdit{*action.Edit}, nil
+	}
+	if dryRun {
+		return nil, commandDryRunError(action)
+	}
+	if action.Edit != nil {
+		return nil, fmt.Errorf("code action %q runs a command after its edit, so it can only be applied with apply_code_action", action.Title)
+	}
+
+	slog.Debug("Executing code action command", "title", action.Title, "command", action.Command.Command)
+	return client.ExecuteCommandEdits(ctx, *action.Command)
+}
+
+// ApplyCodeAction applies a code action, resolving it if needed, and returns the changes to each file.
+// As the LSP specification requires, the edit of a code action is applied before its command is executed, so the edit is
+// written first if there's a command, which may then request more edits. Code actions with a command are refused if dryRun is set.
+// Unless dryRun is set, the changes are written to disk and the language server is notified of them.
+func ApplyCodeAction(ctx context.Context, client types.Client, workspaceRoot string, action types.CodeAction, dryRun bool) ([]results.FileChange, error) {
+	action, err := resolveCodeAction(ctx, client, action)
+	if err != nil {
+		return nil, err
+	}
+	if action.Command != nil && dryRun {
+		return nil, commandDryRunError(action)
+	}
+
+	session := NewEditSession(workspaceRoot)
+	if action.Edit != nil {
+		if err := session.ApplyWorkspaceEdit(*action.Edit); err != nil {
+			return nil, err
+		}
+	}
+
+	if action.Command != nil {
+		// Commands are executed against the files on disk, so the edit must be written first
+		if len(session.Changes()) > 0 {
+			if err := WriteEditSession(ctx, client, session); err != nil {
+				return nil, err
+			}
+		}
+
+		slog.Debug("Executing code action command", "title", action.Title, "command", action.Command.Command)
+		commandEdits, err := client.ExecuteCommandEdits(ctx, *action.Command)
+		if err != nil {
+			if action.Edit != nil {
+				return nil, fmt.Errorf("the edit of the code action was written, but its command failed: %w", err)
+			}
+			return nil, err
+		}
+		for _, edit := range commandEdits {
+			if err := session.ApplyWorkspaceEdit(edit); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	changes := session.Changes()
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+	if err := WriteEditSession(ctx, client, session); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// WriteEditSession writes the changes of an edit session to disk, and notifies the language server of the changed files
+func WriteEditSession(ctx context.Context, client types.Client, session *EditSession) error {
+	events, err := session.Write()
+	if len(events) > 0 {
+		if notifyErr := client.DidChangeWatchedFiles(ctx, events); notifyErr != nil {
+			slog.Error("Failed to notify file changes", "error", notifyErr)
+		}
+	}
+	return err
+}
@@ -0,0 +1,231 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// codeActionClient is a fake client that resolves code actions and executes commands
+type codeActionClient struct {
+	types.Client
+	resolved      *types.CodeAction
+	commandEdits  []types.WorkspaceEdit
+	commandErr    error
+	executed      []string
+	resolvedCount int
+	notified      []types.FileEvent
+	onExecute     func() // Called when a command is executed, e.g. to check the files on disk
+}
+
+func (c *codeActionClient) ResolveCodeAction(ctx context.Context, action types.CodeAction) (*types.CodeAction, error) {
+	c.resolvedCount++
+	return c.resolved, nil
+}
+
+func (c *codeActionClient) ExecuteCommandEdits(ctx context.Context, command types.Command) ([]types.WorkspaceEdit, error) {
+	c.executed = append(c.executed, command.Command)
+	if c.onExecute != nil {
+		c.onExecute()
+	}
+	return c.commandEdits, c.commandErr
+}
+
+func (c *codeActionClient) DidChangeWatchedFiles(ctx context.Context, changes []types.FileEvent) error {
+	c.notified = append(c.notified, changes...)
+	return nil
+}
+
+func TestRangesOverlap(t *testing.T) {
+	rng := func(startLine, startChar, endLine, endChar int) types.Range {
+		return types.Range{Start: types.Position{Line: startLine, Character: startChar}, End: types.Position{Line: endLine, Character: endChar}}
+	}
+
+	tests := []struct {
+		name     string
+		a        types.Range
+		b        types.Range
+		expected bool
+	}{
+		{"Same range", rng(1, 2, 1, 5), rng(1, 2, 1, 5), true},
+		{"Contained range", rng(1, 0, 3, 0), rng(2, 4, 2, 6), true},
+		{"Empty range inside", rng(1, 2, 1, 5), rng(1, 3, 1, 3), true},
+		{"Touching at end", rng(1, 2, 1, 5), rng(1, 5, 1, 5), true},
+		{"Before", rng(1, 2, 1, 5), rng(1, 6, 1, 8), false},
+		{"Different lines", rng(1, 2, 1, 5), rng(3, 0, 3, 1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rangesOverlap(tt.a, tt.b))
+			assert.Equal(t, tt.expected, rangesOverlap(tt.b, tt.a))
+		})
+	}
+}
+
+func TestFindCodeAction(t *testing.T) {
+	actions := []types.CodeAction{
+		{Title: "Extract variable", Kind: "refactor.extract.variable"},
+		{Title: "Extract function", Kind: "refactor.extract.function"},
+		{Title: "Organize Imports", Kind: types.CodeActionKindSourceOrganizeImports},
+	}
+
+	action, err := FindCodeAction(actions, "Extract function", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "refactor.extract.function", string(action.Kind))
+
+	action, err = FindCodeAction(actions, "Extract variable", types.CodeActionKindRefactorExtract)
+	assert.NoError(t, err)
+	assert.Equal(t, "Extract variable", action.Title)
+
+	_, err = FindCodeAction(actions, "Extract variable", types.CodeActionKindRefactorInline)
+	assert.Error(t, err, "Kind should have to match")
+
+	_, err = FindCodeAction(actions, "Extract", "")
+	assert.ErrorContains(t, err, `"Organize Imports"`, "Error should list the available titles")
+
+	_, err = FindCodeAction(nil, "Extract variable", "")
+	assert.Error(t, err)
+}
+
+func TestFindCodeActionByKind(t *testing.T) {
+	actions := []types.CodeAction{
+		{Title: "Extract function", Kind: types.CodeActionKindExtractFunction, Disabled: &types.CodeActionDisabled{Reason: "invalid selection"}},
+		{Title: "Extract constant", Kind: types.CodeActionKindExtractConstant},
+		{Title: "Extract variable", Kind: types.CodeActionKindExtractVariable},
+	}
+
+	action, err := FindCodeActionByKind(actions, []types.CodeActionKind{types.CodeActionKindExtractVariable, types.CodeActionKindExtractConstant}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Extract variable", action.Title, "Kinds should be tried in order")
+
+	_, err = FindCodeActionByKind(actions, []types.CodeActionKind{types.CodeActionKindExtractFunction}, "")
+	assert.ErrorContains(t, err, "invalid selection", "Disabled actions should report their reason")
+
+	_, err = FindCodeActionByKind(actions, []types.CodeActionKind{types.CodeActionKindExtractMethod}, "select statements")
+	assert.EqualError(t, err, "no refactor.extract.method code action is available for the range; select statements")
+}
+
+func TestCodeActionEdits(t *testing.T) {
+	edit := types.WorkspaceEdit{Changes: map[string][]types.TextEdit{"file:///project/main.go": {{NewText: "x"}}}}
+	commandEdit := types.WorkspaceEdit{Changes: map[string][]types.TextEdit{"file:///project/util.go": {{NewText: "y"}}}}
+	command := &types.Command{Title: "Fix", Command: "gopls.apply_fix", Arguments: []json.RawMessage{json.RawMessage(`{}`)}}
+
+	t.Run("Edit", func(t *testing.T) {
+		client := &codeActionClient{}
+		edits, err := CodeActionEdits(context.Background(), client, types.CodeAction{Title: "Fix", Edit: &edit}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []types.WorkspaceEdit{edit}, edits)
+		assert.Zero(t, client.resolvedCount)
+		assert.Empty(t, client.executed)
+	})
+
+	t.Run("Command", func(t *testing.T) {
+		client := &codeActionClient{commandEdits: []types.WorkspaceEdit{commandEdit}}
+		edits, err := CodeActionEdits(context.Background(), client, types.CodeAction{Title: "Fix", Command: command}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []types.WorkspaceEdit{commandEdit}, edits)
+		assert.Equal(t, []string{"gopls.apply_fix"}, client.executed)
+	})
+
+	t.Run("Command in dry run", func(t *testing.T) {
+		client := &codeActionClient{commandEdits: []types.WorkspaceEdit{commandEdit}}
+		_, err := CodeActionEdits(context.Background(), client, types.CodeAction{Title: "Fix", Command: command}, true)
+		assert.ErrorContains(t, err, "can't be previewed")
+		assert.Empty(t, client.executed, "Commands should not be executed in dry runs")
+	})
+
+	t.Run("Edit and command", func(t *testing.T) {
+		client := &codeActionClient{commandEdits: []types.WorkspaceEdit{commandEdit}}
+		_, err := CodeActionEdits(context.Background(), client, types.CodeAction{Title: "Fix", Edit: &edit, Command: command}, false)
+		assert.ErrorContains(t, err, "apply_code_action")
+		assert.Empty(t, client.executed)
+	})
+
+	t.Run("Resolved", func(t *testing.T) {
+		client := &codeActionClient{resolved: &types.CodeAction{Title: "Extract function", Edit: &edit}}
+		edits, err := CodeActionEdits(context.Background(), client, types.CodeAction{Title: "Extract function", Data: json.RawMessage(`{}`)}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []types.WorkspaceEdit{edit}, edits)
+		assert.Equal(t, 1, client.resolvedCount)
+	})
+
+	t.Run("Command error", func(t *testing.T) {
+		client := &codeActionClient{commandErr: errors.New("failed")}
+		_, err := CodeActionEdits(context.Background(), client, types.CodeAction{Title: "Fix", Command: command}, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		action := types.CodeAction{Title: "Fix", Edit: &edit, Disabled: &types.CodeActionDisabled{Reason: "selection is not an expression"}}
+		_, err := CodeActionEdits(context.Background(), &codeActionClient{}, action, false)
+		assert.ErrorContains(t, err, "selection is not an expression")
+	})
+}
+
+func TestApplyCodeAction(t *testing.T) {
+	root := t.TempDir()
+	mainPath, utilPath := filepath.Join(root, "main.go"), filepath.Join(root, "util.go")
+	writeFiles := func() {
+		require.NoError(t, os.WriteFile(mainPath, []byte("package main\n"), 0o644))
+		require.NoError(t, os.WriteFile(utilPath, []byte("package main\n"), 0o644))
+	}
+	insert := func(path string, text string) types.WorkspaceEdit {
+		return types.WorkspaceEdit{Changes: map[string][]types.TextEdit{PathToUri(path, root): {{NewText: text}}}}
+	}
+	edit := insert(mainPath, "// Edited\n")
+	command := &types.Command{Title: "Fix", Command: "gopls.apply_fix"}
+
+	t.Run("Edit before command", func(t *testing.T) {
+		writeFiles()
+		var contentAtExecution string
+		client := &codeActionClient{commandEdits: []types.WorkspaceEdit{insert(utilPath, "// Fixed\n")}}
+		client.onExecute = func() {
+			data, err := os.ReadFile(mainPath)
+			require.NoError(t, err)
+			contentAtExecution = string(data)
+		}
+
+		changes, err := ApplyCodeAction(context.Background(), client, root, types.CodeAction{Title: "Fix", Edit: &edit, Command: command}, false)
+		require.NoError(t, err)
+		assert.Equal(t, "// Edited\npackage main\n", contentAtExecution, "The edit should be written before the command is executed")
+		assert.Len(t, changes, 2)
+
+		data, err := os.ReadFile(utilPath)
+		require.NoError(t, err)
+		assert.Equal(t, "// Fixed\npackage main\n", string(data))
+		assert.NotEmpty(t, client.notified)
+	})
+
+	t.Run("Dry run with edit", func(t *testing.T) {
+		writeFiles()
+		client := &codeActionClient{}
+		changes, err := ApplyCodeAction(context.Background(), client, root, types.CodeAction{Title: "Fix", Edit: &edit}, true)
+		require.NoError(t, err)
+		assert.Len(t, changes, 1)
+
+		data, err := os.ReadFile(mainPath)
+		require.NoError(t, err)
+		assert.Equal(t, "package main\n", string(data), "Dry runs should not write files")
+		assert.Empty(t, client.notified)
+	})
+
+	t.Run("Dry run with command", func(t *testing.T) {
+		writeFiles()
+		client := &codeActionClient{}
+		_, err := ApplyCodeAction(context.Background(), client, root, types.CodeAction{Title: "Fix", Edit: &edit, Command: command}, true)
+		assert.ErrorContains(t, err, "can't be previewed")
+		assert.Empty(t, client.executed)
+
+		data, err := os.ReadFile(mainPath)
+		require.NoError(t, err)
+		assert.Equal(t, "package main\n", string(data))
+	})
+}
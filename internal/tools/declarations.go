@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+// TopLevelDeclaration represents a top-level declaration of a Go file
+type TopLevelDeclaration struct {
+	Name    string      // Name of the document symbol, like "Calculator" or "(*Calculator).Add"
+	Path    string      // Absolute path of the file containing the declaration
+	Range   types.Range // Range of the declaration, from its document symbol
+	Keyword string      // Keyword of a type, variable or constant declaration, which may be outside of the range
+}
+
+// SymbolDeclarations represents the declarations of a top-level symbol
+type SymbolDeclarations struct {
+	Name         string
+	Kind         results.SymbolKind
+	Declarations []TopLevelDeclaration // Declaration of the symbol, followed by the methods of a type
+}
+
+// declarationKeyword returns the keyword of the declaration of a top-level symbol, which is empty for functions and methods
+func declarationKeyword(kind results.SymbolKind) string {
+	switch kind {
+	case results.SymbolKindFunction, results.SymbolKindMethod:
+		return ""
+	case results.SymbolKindConstant:
+		return "const"
+	case results.SymbolKindVariable:
+		return "var"
+	default:
+		// Any other top-level symbol, like a struct, interface or number, is a type
+		return "type"
+	}
+}
+
+// FindSymbolDeclarations finds the declarations of the top-level symbol declared at a location.
+// The declarations of a type include its methods declared in the files of its package, optionally including test files.
+func FindSymbolDeclarations(ctx context.Context, client types.Client, definition types.Location, includeTests bool) (*SymbolDeclarations, error) {
+	symbols, err := client.GetDocumentSymbols(ctx, definition.URI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document symbols: %w", err)
+	}
+	var symbol *types.DocumentSymbol
+	for i := range symbols {
+		if symbols[i].SelectionRange.Start == definition.Range.Start {
+			symbol = &symbols[i]
+		}
+	}
+	if symbol == nil {
+		return nil, fmt.Errorf("no top-level declaration found")
+	}
+
+	path := UriToPath(definition.URI)
+	decls := &SymbolDeclarations{Name: symbol.Name, Kind: results.NewSymbolKind(symbol.Kind)}
+	keyword := declarationKeyword(decls.Kind)
+	decls.Declarations = append(decls.Declarations, TopLevelDeclaration{Name: symbol.Name, Path: path, Range: symbol.Range, Keyword: keyword})
+	if keyword != "type" {
+		return decls, nil
+	}
+
+	files, err := ListPackageFiles(filepath.Dir(path), includeTests)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		fileSymbols := symbols
+		if file != path {
+			if fileSymbols, err = client.GetDocumentSymbols(ctx, PathToUri(file, "")); err != nil {
+				return nil, fmt.Errorf("failed to get document symbols of %s: %w", file, err)
+			}
+		}
+		for _, method := range fileSymbols {
+			if receiverTypeName(method) == symbol.Name {
+				decls.Declarations = append(decls.Declarations, TopLevelDeclaration{Name: method.Name, Path: file, Range: method.Range})
+			}
+		}
+	}
+	return decls, nil
+}
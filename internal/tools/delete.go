@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+// SymbolDeletion describes the deletion of a top-level symbol, with the methods of a type
+type SymbolDeletion struct {
+	Name         string                // Name of the deleted symbol
+	Declarations []TopLevelDeclaration // Declarations of the symbol and its methods
+	References   []types.Location      // References to the symbol, which may include its declaration
+}
+
+// SymbolDeletionResult represents the outcome of a symbol deletion
+type SymbolDeletionResult struct {
+	Deleted             bool             // Whether the declarations were deleted, which they aren't if references outside test files remain
+	DeletedDeclarations []string         // Names of the deleted declarations
+	References          []types.Location // References outside test files, which prevent the deletion
+	TestReferences      []types.Location // References in test files, which must be updated after the deletion
+	RemovedImports      []string         // Import paths of the imports that were only used by the deleted declarations
+	EditedFiles         []string         // Absolute paths of the edited files
+}
+
+// DeleteSymbol deletes the declarations of a symbol in an edit session, with their doc comments and the imports that only they used.
+// References inside the deleted declarations are ignored. Nothing is edited if references outside test files remain.
+func DeleteSymbol(session *EditSession, deletion SymbolDeletion) (*SymbolDeletionResult, error) {
+	if len(deletion.Declarations) == 0 {
+		return nil, errors.New("no declarations to delete")
+	}
+	blocks, err := declarationBlocks(session, deletion.Declarations)
+	if err != nil {
+		return nil, err
+	}
+	for _, decl := range deletion.Declarations {
+		if decl.Keyword != "const" && decl.Keyword != "var" {
+			continue
+		}
+		content, err := session.Content(decl.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkValueSpecDeletion(decl, content); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &SymbolDeletionResult{}
+	for _, reference := range deletion.References {
+		path := UriToPath(reference.URI)
+		switch {
+		case isReferenceInBlocks(session, blocks, path, reference.Range.Start):
+		case IsTestFile(path):
+			result.TestReferences = append(result.TestReferences, reference)
+		default:
+			result.References = append(result.References, reference)
+		}
+	}
+	if len(result.References) > 0 {
+		return result, nil
+	}
+
+	blocksByPath := make(map[string][]types.TextEdit)
+	for _, block := range blocks {
+		content, _ := session.Content(block.decl.Path)
+		if _, ok := blocksByPath[block.decl.Path]; !ok {
+			result.EditedFiles = append(result.EditedFiles, block.decl.Path)
+		}
+		blocksByPath[block.decl.Path] = append(blocksByPath[block.decl.Path], offsetTextEdit(content, block.start, block.end, ""))
+		result.DeletedDeclarations = append(result.DeletedDeclarations, block.decl.Name)
+	}
+
+	removedImports := make(map[string]bool)
+	for _, path := range result.EditedFiles {
+		original, _ := session.Content(path)
+		if err := session.EditFile(path, blocksByPath[path]); err != nil {
+			return nil, err
+		}
+		edited, _ := session.Content(path)
+		importEdits, removed, err := UnusedImportEdits(path, original, edited)
+		if err != nil {
+			return nil, err
+		}
+		if err := session.EditFile(path, importEdits); err != nil {
+			return nil, err
+		}
+		for _, importPath := range removed {
+			removedImports[importPath] = true
+		}
+	}
+	for importPath := range removedImports {
+		result.RemovedImports = append(result.RemovedImports, importPath)
+	}
+	sort.Strings(result.RemovedImports)
+	result.Deleted = true
+	return result, nil
+}
+
+// checkValueSpecDeletion checks that deleting the spec of a variable or constant declaration doesn't delete other names,
+// or change the values of the other constants of its group
+func checkValueSpecDeletion(decl TopLevelDeclaration, content string) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, decl.Path, content, parser.SkipObjectResolution)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", decl.Path, err)
+	}
+	offset, err := positionOffset(content, lineOffsets(content), decl.Range.Start)
+	if err != nil {
+		return err
+	}
+
+	for _, fileDecl := range file.Decls {
+		genDecl, ok := fileDecl.(*ast.GenDecl)
+		if !ok || fset.Position(genDecl.End()).Offset <= offset || offset < fset.Position(genDecl.Pos()).Offset {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok || fset.Position(valueSpec.End()).Offset <= offset || offset < fset.Position(valueSpec.Pos()).Offset {
+				continue
+			}
+			if len(valueSpec.Names) > 1 {
+				names := make([]string, len(valueSpec.Names))
+				for i, name := range valueSpec.Names {
+					names[i] = name.Name
+				}
+				return fmt.Errorf("%s is declared together with other names (%s), which would be deleted too", decl.Name, strings.Join(names, ", "))
+			}
+		}
+		if genDecl.Tok == token.CONST && len(genDecl.Specs) > 1 && usesImplicitValues(genDecl) {
+			return fmt.Errorf("deleting %s would change the values of the other constants in its group, which use iota or implicit values", decl.Name)
+		}
+	}
+	return nil
+}
+
+// usesImplicitValues checks if the specs of a grouped constant declaration use iota, or repeat the values of previous specs
+func usesImplicitValues(decl *ast.GenDecl) bool {
+	implicit := false
+	for _, spec := range decl.Specs {
+		valueSpec := spec.(*ast.ValueSpec)
+		if len(valueSpec.Values) == 0 {
+			return true
+		}
+		for _, value := range valueSpec.Values {
+			ast.Inspect(value, func(node ast.Node) bool {
+				if ident, ok := node.(*ast.Ident); ok && ident.Name == "iota" {
+					implicit = true
+				}
+				return !implicit
+			})
+		}
+	}
+	return implicit
+}
@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deleteShapesSource = `package shapes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Square is a square
+type Square struct {
+	Side float64
+}
+
+// String describes the square
+func (s Square) String() string {
+	return fmt.Sprintf("square %v", s.Side)
+}
+
+// Upper returns s in upper case
+func Upper(s string) string {
+	return strings.ToUpper(s)
+}
+`
+
+func TestDeleteSymbol(t *testing.T) {
+	root := t.TempDir()
+	shapesPath := filepath.Join(root, "shapes.go")
+	require.NoError(t, os.WriteFile(shapesPath, []byte(deleteShapesSource), 0o644))
+	otherPath := filepath.Join(root, "other.go")
+	testPath := filepath.Join(root, "shapes_test.go")
+
+	square := []TopLevelDeclaration{
+		{Name: "Square", Path: shapesPath, Range: textRange(t, deleteShapesSource, "Square struct", "}"), Keyword: "type"},
+		{Name: "(Square).String", Path: shapesPath, Range: textRange(t, deleteShapesSource, "func (s Square)", "\n}")},
+	}
+	squareReferences := []types.Location{
+		textLocation(t, shapesPath, deleteShapesSource, "Square", 1), // Declaration
+		textLocation(t, shapesPath, deleteShapesSource, "Square", 2), // String receiver
+	}
+	upper := []TopLevelDeclaration{
+		{Name: "Upper", Path: shapesPath, Range: textRange(t, deleteShapesSource, "func Upper", "\n}")},
+	}
+	upperDeclaration := textLocation(t, shapesPath, deleteShapesSource, "Upper", 1)
+	elsewhere := types.Range{Start: types.Position{Line: 4, Character: 1}, End: types.Position{Line: 4, Character: 6}}
+
+	tests := []struct {
+		name                   string
+		declarations           []TopLevelDeclaration
+		references             []types.Location
+		expectedDeleted        bool
+		expectedReferences     int
+		expectedTestReferences int
+		expectedRemovedImports []string
+		expectedContent        string
+	}{
+		{
+			name:                   "Type with methods",
+			declarations:           square,
+			references:             squareReferences,
+			expectedDeleted:        true,
+			expectedRemovedImports: []string{"fmt"},
+			expectedContent:        "package shapes\n\nimport (\n\t\"strings\"\n)\n\n// Upper returns s in upper case\nfunc Upper(s string) string {\n\treturn strings.ToUpper(s)\n}\n",
+		},
+		{
+			name:                   "Test references",
+			declarations:           upper,
+			references:             []types.Location{upperDeclaration, {URI: PathToUri(testPath, ""), Range: elsewhere}},
+			expectedDeleted:        true,
+			expectedTestReferences: 1,
+			expectedRemovedImports: []string{"strings"},
+			expectedContent:        "package shapes\n\nimport (\n\t\"fmt\"\n)\n\n// Square is a square\ntype Square struct {\n\tSide float64\n}\n\n// String describes the square\nfunc (s Square) String() string {\n\treturn fmt.Sprintf(\"square %v\", s.Side)\n}\n",
+		},
+		{
+			name:               "Remaining references",
+			declarations:       upper,
+			references:         []types.Location{upperDeclaration, {URI: PathToUri(otherPath, ""), Range: elsewhere}, {URI: PathToUri(testPath, ""), Range: elsewhere}},
+			expectedReferences: 1,
+			// References in test files are still reported
+			expectedTestReferences: 1,
+			expectedContent:        deleteShapesSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := NewEditSession(root)
+			result, err := DeleteSymbol(session, SymbolDeletion{Name: tt.declarations[0].Name, Declarations: tt.declarations, References: tt.references})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDeleted, result.Deleted)
+			assert.Len(t, result.References, tt.expectedReferences)
+			assert.Len(t, result.TestReferences, tt.expectedTestReferences)
+			assert.Equal(t, tt.expectedRemovedImports, result.RemovedImports)
+
+			content, err := session.Content(shapesPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedContent, content)
+			if !tt.expectedDeleted {
+				assert.Empty(t, session.Changes(), "Nothing should be edited if references remain")
+			}
+		})
+	}
+}
+
+func TestCheckValueSpecDeletion(t *testing.T) {
+	content := `package p
+
+var a, b = 1, 2
+
+var c = 3
+
+const (
+	Red = iota
+	Green
+)
+
+const (
+	Small = 1
+	Large = 2
+)
+`
+
+	tests := []struct {
+		name  string
+		start string
+		error string
+	}{
+		{name: "Several names", start: "a, b", error: "declared together with other names (a, b)"},
+		{name: "Single variable", start: "c = 3"},
+		{name: "Constant group with iota", start: "Green", error: "would change the values of the other constants"},
+		{name: "Constant group with explicit values", start: "Large = 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decl := TopLevelDeclaration{Name: tt.start[:1], Path: "p.go", Range: textRange(t, content, tt.start, tt.start), Keyword: "var"}
+			err := checkValueSpecDeletion(decl, content)
+			if tt.error != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.error)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package tools
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/doc"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+)
+
+// PackageDocumentation represents the documentation of a package, parsed with go/doc
+type PackageDocumentation struct {
+	Package       *doc.Package
+	fset          *token.FileSet
+	workspaceRoot string
+}
+
+// FindPackageDir returns the directory of a package from its import path, including packages in
+// the standard library and the module cache (which are found by the go command, without network access).
+// Arguments are only interpreted as directories if they start with "./", "../" or "/", so that directories of the workspace
+// named like standard library packages (e.g. "errors" or "log") don't shadow them.
+func FindPackageDir(workspaceRoot string, importPath string) (string, error) {
+	if isDirectoryArgument(importPath) {
+		return ResolvePackageDir(workspaceRoot, importPath)
+	}
+	return ImportPackageDir(workspaceRoot, importPath)
+}
+
+// isDirectoryArgument checks if a package argument is explicitly a directory, rather than an import path
+func isDirectoryArgument(pkg string) bool {
+	slashed := filepath.ToSlash(pkg)
+	return slashed == "." || slashed == ".." || strings.HasPrefix(slashed, "./") || strings.HasPrefix(slashed, "../") || filepath.IsAbs(pkg) || strings.HasPrefix(slashed, "/")
+}
+
+// LoadPackageDocumentation parses the Go files of a package that match the current build context into documentation, plus
+// the given definition files of symbols that gopls resolved in the package, even if build constraints exclude them.
+// Test files are only used for examples, and unexported declarations are only included if requested.
+func LoadPackageDocumentation(dir string, importPath string, workspaceRoot string, includeUnexported bool, definitionFiles ...string) (*PackageDocumentation, error) {
+	buildPkg, err := build.Default.ImportDir(dir, 0)
+	var noGoErr *build.NoGoError
+	if err != nil && !(errors.As(err, &noGoErr) && len(definitionFiles) > 0) {
+		return nil, fmt.Errorf("failed to load package in %s: %w", dir, err)
+	}
+
+	var paths []string
+	for _, names := range [][]string{buildPkg.GoFiles, buildPkg.CgoFiles, buildPkg.TestGoFiles, buildPkg.XTestGoFiles} {
+		for _, name := range names {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+	for _, file := range definitionFiles {
+		if !slices.Contains(paths, file) {
+			paths = append(paths, file)
+		}
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+		}
+		files = append(files, file)
+	}
+
+	mode := doc.Mode(0)
+	if includeUnexported {
+		mode |= doc.AllDecls | doc.AllMethods
+	}
+	pkg, err := doc.NewFromFiles(fset, files, importPath, mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute documentation for %s: %w", importPath, err)
+	}
+
+	return &PackageDocumentation{Package: pkg, fset: fset, workspaceRoot: workspaceRoot}, nil
+}
+
+// Declarations summarizes the package-level declarations in "go doc" order: constants, variables, functions, and then
+// types, each followed by its constants, variables, constructors, and methods
+func (d *PackageDocumentation) Declarations() []results.DocDeclaration {
+	var declarations []results.DocDeclaration
+	declarations = append(declarations, d.valueDeclarations(d.Package.Consts)...)
+	declarations = append(declarations, d.valueDeclarations(d.Package.Vars)...)
+	declarations = append(declarations, d.funcDeclarations(d.Package.Funcs)...)
+	for _, typ := range d.Package.Types {
+		declarations = append(declarations, d.typeDeclaration(typ, true))
+		declarations = append(declarations, d.typeMemberDeclarations(typ)...)
+	}
+	return declarations
+}
+
+// Examples returns the package-level examples
+func (d *PackageDocumentation) Examples() []results.DocExample {
+	return d.examples(d.Package.Examples)
+}
+
+// Symbol returns the full documentation of a symbol, like "Calculator", "NewCalculator", or "Calculator.Add".
+// Members can be methods, struct fields, or interface methods.
+func (d *PackageDocumentation) Symbol(symbolPath string) (*results.SymbolDocumentation, error) {
+	name, member, hasMember := strings.Cut(symbolPath, ".")
+
+	for _, typ := range d.Package.Types {
+		if hasMember && typ.Name == name {
+			return d.typeMember(typ, member)
+		}
+		if !hasMember && typ.Name == name {
+			return &results.SymbolDocumentation{
+				DocDeclaration: d.typeDeclaration(typ, false),
+				Doc:            strings.TrimSpace(typ.Doc),
+				Methods:        d.typeMemberDeclarations(typ),
+				Examples:       d.examples(typ.Examples),
+			}, nil
+		}
+	}
+	if hasMember {
+		return nil, fmt.Errorf("type %s not found in package %s", name, d.Package.ImportPath)
+	}
+
+	// Constructors and typed constants are grouped under their types by go/doc
+	funcs, values := d.Package.Funcs, append(d.Package.Consts, d.Package.Vars...)
+	for _, typ := range d.Package.Types {
+		funcs = append(funcs, typ.Funcs...)
+		values = append(values, typ.Consts...)
+		values = append(values, typ.Vars...)
+	}
+	for _, fn := range funcs {
+		if fn.Name == name {
+			return &results.SymbolDocumentation{
+				DocDeclaration: d.funcDeclaration(fn),
+				Doc:            strings.TrimSpace(fn.Doc),
+				Examples:       d.examples(fn.Examples),
+			}, nil
+		}
+	}
+	for _, value := range values {
+		for _, valueName := range value.Names {
+			if valueName == name {
+				declaration := d.valueDeclaration(value, false)
+				declaration.Name = name
+				declaration.SemanticAnchor = results.NewSemanticSymbolAnchor(d.Package.ImportPath, name)
+				return &results.SymbolDocumentation{
+					DocDeclaration: declaration,
+					Doc:            strings.TrimSpace(value.Doc),
+				}, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("symbol %s not found in package %s", name, d.Package.ImportPath)
+}
+
+// typeMember returns the documentation of a method, struct field, or interface method of a type
+func (d *PackageDocumentation) typeMember(typ *doc.Type, member string) (*results.SymbolDocumentation, error) {
+	for _, method := range typ.Methods {
+		if method.Name == member {
+			return &results.SymbolDocumentation{
+				DocDeclaration: d.funcDeclaration(method),
+				Doc:            strings.TrimSpace(method.Doc),
+				Examples:       d.examples(method.Examples),
+			}, nil
+		}
+	}
+
+	typeSpec := docTypeSpec(typ)
+	var fields []*ast.Field
+	goKind := results.GoSymbolKindField
+	switch t := typeSpec.Type.(type) {
+	case *ast.StructType:
+		fields = t.Fields.List
+	case *ast.InterfaceType:
+		fields = t.Methods.List
+		goKind = results.GoSymbolKindInterfaceMethod
+	}
+	for _, field := range fields {
+		for _, fieldName := range field.Names {
+			if fieldName.Name != member {
+				continue
+			}
+
+			signature := fieldName.Name + " " + d.source(field.Type)
+			if funcType, ok := field.Type.(*ast.FuncType); ok {
+				signature = fieldName.Name + strings.TrimPrefix(d.source(funcType), "func")
+			}
+			fieldDoc := field.Doc.Text()
+			if fieldDoc == "" {
+				fieldDoc = field.Comment.Text()
+			}
+
+			declaration := d.newDeclaration(typ.Name+"."+member, signature, "", fieldName.Pos())
+			declaration.GoKind = goKind
+			return &results.SymbolDocumentation{
+				DocDeclaration: declaration,
+				Doc:            strings.TrimSpace(fieldDoc),
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s has no method or field named %s", typ.Name, member)
+}
+
+// typeMemberDeclarations summarizes the constants, variables, constructors, and methods of a type
+func (d *PackageDocumentation) typeMemberDeclarations(typ *doc.Type) []results.DocDeclaration {
+	var declarations []results.DocDeclaration
+	declarations = append(declarations, d.valueDeclarations(typ.Consts)...)
+	declarations = append(declarations, d.valueDeclarations(typ.Vars)...)
+	declarations = append(declarations, d.funcDeclarations(typ.Funcs)...)
+	declarations = append(declarations, d.funcDeclarations(typ.Methods)...)
+	return declarations
+}
+
+func (d *PackageDocumentation) valueDeclarations(values []*doc.Value) []results.DocDeclaration {
+	declarations := make([]results.DocDeclaration, 0, len(values))
+	for _, value := range values {
+		declarations = append(declarations, d.valueDeclaration(value, true))
+	}
+	return declarations
+}
+
+func (d *PackageDocumentation) funcDeclarations(funcs []*doc.Func) []results.DocDeclaration {
+	declarations := make([]results.DocDeclaration, 0, len(funcs))
+	for _, fn := range funcs {
+		declarations = append(declarations, d.funcDeclaration(fn))
+	}
+	return declarations
+}
+
+// valueDeclaration describes a const or var declaration, which may declare several names
+func (d *PackageDocumentation) valueDeclaration(value *doc.Value, summarize bool) results.DocDeclaration {
+	decl := *value.Decl
+	decl.Doc = nil
+	signature := d.source(&decl)
+
+	pos := value.Decl.Pos()
+	if valueSpec, ok := value.Decl.Specs[0].(*ast.ValueSpec); ok && len(valueSpec.Names) > 0 {
+		pos = valueSpec.Names[0].Pos()
+	}
+
+	declaration := d.newDeclaration(value.Names[0], signature, value.Doc, pos)
+	if len(value.Names) > 1 {
+		declaration.Names = value.Names
+	}
+	if summarize {
+		declaration.Signature = SummarizeSignature(signature)
+	}
+	return declaration
+}
+
+// funcDeclaration describes a function or method declaration, without its body
+func (d *PackageDocumentation) funcDeclaration(fn *doc.Func) results.DocDeclaration {
+	decl := *fn.Decl
+	decl.Doc = nil
+	decl.Body = nil
+
+	name := fn.Name
+	if fn.Recv != "" {
+		// Methods are named after their receiver type, without pointers or type parameters (e.g. "*List[T]" to "List")
+		recv, _, _ := strings.Cut(strings.TrimPrefix(fn.Recv, "*"), "[")
+		name = recv + "." + fn.Name
+	}
+	return d.newDeclaration(name, d.source(&decl), fn.Doc, fn.Decl.Name.Pos())
+}
+
+// typeDeclaration describes a type declaration, optionally with its fields or methods elided
+func (d *PackageDocumentation) typeDeclaration(typ *doc.Type, summarize bool) results.DocDeclaration {
+	decl := *typ.Decl
+	decl.Doc = nil
+	typeSpec := docTypeSpec(typ)
+
+	declaration := d.newDeclaration(typ.Name, d.source(&decl), typ.Doc, typeSpec.Name.Pos())
+	if summarize {
+		declaration.Signature = SummarizeSignature(declaration.Signature)
+	}
+	return declaration
+}
+
+// newDeclaration creates a declaration, with its Go kind from the full signature
+func (d *PackageDocumentation) newDeclaration(name string, signature string, docText string, pos token.Pos) results.DocDeclaration {
+	position := d.fset.Position(pos)
+	file, _ := GetDisplayPath(position.Filename, d.workspaceRoot)
+	location := results.SymbolLocation{
+		File:        file,
+		DisplayLine: position.Line,
+		DisplayChar: position.Column,
+	}
+	return results.DocDeclaration{
+		Name:           name,
+		GoKind:         results.NewGoSymbolKind(0, name, "", signature),
+		Signature:      signature,
+		Synopsis:       d.Package.Synopsis(docText),
+		Location:       location,
+		Anchor:         location.ToAnchor().WithName(results.NewSymbolIdentifier(name)),
+		SemanticAnchor: results.NewSemanticSymbolAnchor(d.Package.ImportPath, name),
+	}
+}
+
+// examples renders examples, naming them after their example functions
+func (d *PackageDocumentation) examples(examples []*doc.Example) []results.DocExample {
+	var rendered []results.DocExample
+	for _, example := range examples {
+		name := "Example"
+		if example.Name != "" {
+			name += example.Name
+		}
+		if example.Suffix != "" {
+			name += "_" + example.Suffix
+		}
+
+		code := d.source(example.Code)
+		if block, ok := example.Code.(*ast.BlockStmt); ok {
+			code = unindentBlock(d.source(block))
+		}
+		rendered = append(rendered, results.DocExample{
+			Name:   name,
+			Doc:    strings.TrimSpace(example.Doc),
+			Code:   code,
+			Output: strings.TrimSpace(example.Output),
+		})
+	}
+	return rendered
+}
+
+// source formats an AST node as Go source code
+func (d *PackageDocumentation) source(node any) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, d.fset, node); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// docTypeSpec returns the type spec of a documented type, since go/doc gives each type its own declaration
+func docTypeSpec(typ *doc.Type) *ast.TypeSpec {
+	for _, spec := range typ.Decl.Specs {
+		if typeSpec, ok := spec.(*ast.TypeSpec); ok && typeSpec.Name.Name == typ.Name {
+			return typeSpec
+		}
+	}
+	return typ.Decl.Specs[0].(*ast.TypeSpec)
+}
+
+// SummarizeSignature elides the fields, methods, or values of a multi-line declaration, like "go doc" does for a package,
+// e.g. "type Calculator struct {\n\tvalue float64\n}" to "type Calculator struct{ ... }"
+func SummarizeSignature(signature string) string {
+	firstLine, _, multiline := strings.Cut(signature, "\n")
+	if !multiline {
+		return signature
+	}
+
+	firstLine = strings.TrimRight(firstLine, " ")
+	switch {
+	case strings.HasSuffix(firstLine, "{"):
+		return strings.TrimRight(strings.TrimSuffix(firstLine, "{"), " ") + "{ ... }"
+	case strings.HasSuffix(firstLine, "("):
+		return firstLine + " ... )"
+	default:
+		return firstLine + " ..."
+	}
+}
+
+// unindentBlock removes the braces around a block statement and one level of indentation
+func unindentBlock(block string) string {
+	block = strings.TrimSpace(block)
+	block = strings.TrimPrefix(block, "{")
+	block = strings.TrimSuffix(block, "}")
+
+	lines := strings.Split(strings.Trim(block, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, "\t")
+	}
+	return strings.Join(lines, "\n")
+}
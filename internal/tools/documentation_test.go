@@ -0,0 +1,284 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/stretchr/testify/assert"
+)
+
+const documentationTestSource = `// Package calc performs arithmetic.
+package calc
+
+// Pi is an approximation of pi.
+const Pi = 3.14
+
+// Operation represents an arithmetic operation.
+type Operation int
+
+// Operations.
+const (
+	Add Operation = iota
+	Subtract
+)
+
+// Calculator performs arithmetic.
+type Calculator struct {
+	// Value is the current value.
+	Value float64
+	memory float64
+}
+
+// NewCalculator creates a calculator.
+func NewCalculator(initial float64) *Calculator {
+	return &Calculator{Value: initial}
+}
+
+// Add adds a number to the current value.
+func (c *Calculator) Add(x float64) float64 {
+	c.Value += x
+	return c.Value
+}
+
+// Processor processes numbers.
+type Processor interface {
+	// Process processes two numbers.
+	Process(x, y float64) (float64, error)
+}
+
+func helper() {}
+`
+
+const documentationTestExamples = `package calc_test
+
+import (
+	"fmt"
+
+	"example.com/calc"
+)
+
+// This example adds a number.
+func ExampleCalculator_Add() {
+	c := calc.NewCalculator(1)
+	fmt.Println(c.Add(2))
+	// Output: 3
+}
+`
+
+func loadTestPackageDocumentation(t *testing.T, includeUnexported bool) *PackageDocumentation {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/calc\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "calc.go"), []byte(documentationTestSource), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "calc_test.go"), []byte(documentationTestExamples), 0o644))
+
+	documentation, err := LoadPackageDocumentation(dir, "example.com/calc", dir, includeUnexported)
+	assert.NoError(t, err)
+	return documentation
+}
+
+func TestPackageDocumentationDeclarations(t *testing.T) {
+	documentation := loadTestPackageDocumentation(t, false)
+	assert.Equal(t, "calc", documentation.Package.Name)
+	assert.Equal(t, "Package calc performs arithmetic.", documentation.Package.Synopsis(documentation.Package.Doc))
+
+	var names, signatures []string
+	for _, declaration := range documentation.Declarations() {
+		names = append(names, declaration.Name)
+		signatures = append(signatures, declaration.Signature)
+	}
+	assert.Equal(t, []string{"Pi", "Calculator", "NewCalculator", "Calculator.Add", "Operation", "Add", "Processor"}, names)
+	assert.Equal(t, []string{
+		"const Pi = 3.14",
+		"type Calculator struct{ ... }",
+		"func NewCalculator(initial float64) *Calculator",
+		"func (c *Calculator) Add(x float64) float64",
+		"type Operation int",
+		"const ( ... )",
+		"type Processor interface{ ... }",
+	}, signatures)
+
+	declarations := documentation.Declarations()
+	assert.Equal(t, results.GoSymbolKindStruct, declarations[1].GoKind)
+	assert.Equal(t, results.GoSymbolKindPointerMethod, declarations[3].GoKind)
+	assert.Equal(t, []string{"Add", "Subtract"}, declarations[5].Names)
+	assert.Equal(t, results.SymbolAnchor("go://calc.go#17:6@Calculator"), declarations[1].Anchor)
+	assert.Equal(t, results.SymbolAnchor("go://example.com/calc#Calculator.Add"), declarations[3].SemanticAnchor)
+}
+
+func TestPackageDocumentationUnexported(t *testing.T) {
+	documentation := loadTestPackageDocumentation(t, true)
+
+	symbol, err := documentation.Symbol("helper")
+	assert.NoError(t, err)
+	assert.Equal(t, "func helper()", symbol.Signature)
+}
+
+func TestPackageDocumentationSymbol(t *testing.T) {
+	documentation := loadTestPackageDocumentation(t, false)
+
+	tests := []struct {
+		name              string
+		symbolPath        string
+		expectedSignature string
+		expectedDoc       string
+		expectedGoKind    results.GoSymbolKind
+		expectedExamples  []string
+		expectError       bool
+	}{
+		{
+			name:              "Type",
+			symbolPath:        "Calculator",
+			expectedSignature: "type Calculator struct {\n\t// Value is the current value.\n\tValue float64\n\t// contains filtered or unexported fields\n}",
+			expectedDoc:       "Calculator performs arithmetic.",
+			expectedGoKind:    results.GoSymbolKindStruct,
+		},
+		{
+			name:              "Constructor",
+			symbolPath:        "NewCalculator",
+			expectedSignature: "func NewCalculator(initial float64) *Calculator",
+			expectedDoc:       "NewCalculator creates a calculator.",
+			expectedGoKind:    results.GoSymbolKindFunc,
+		},
+		{
+			name:              "Method with example",
+			symbolPath:        "Calculator.Add",
+			expectedSignature: "func (c *Calculator) Add(x float64) float64",
+			expectedDoc:       "Add adds a number to the current value.",
+			expectedGoKind:    results.GoSymbolKindPointerMethod,
+			expectedExamples:  []string{"ExampleCalculator_Add"},
+		},
+		{
+			name:              "Struct field",
+			symbolPath:        "Calculator.Value",
+			expectedSignature: "Value float64",
+			expectedDoc:       "Value is the current value.",
+			expectedGoKind:    results.GoSymbolKindField,
+		},
+		{
+			name:              "Interface method",
+			symbolPath:        "Processor.Process",
+			expectedSignature: "Process(x, y float64) (float64, error)",
+			expectedDoc:       "Process processes two numbers.",
+			expectedGoKind:    results.GoSymbolKindInterfaceMethod,
+		},
+		{
+			name:              "Constant in a group",
+			symbolPath:        "Subtract",
+			expectedSignature: "const (\n\tAdd Operation = iota\n\tSubtract\n)",
+			expectedDoc:       "Operations.",
+			expectedGoKind:    results.GoSymbolKindConst,
+		},
+		{name: "Unexported symbol", symbolPath: "helper", expectError: true},
+		{name: "Missing member", symbolPath: "Calculator.Missing", expectError: true},
+		{name: "Missing type", symbolPath: "Missing.Add", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			symbol, err := documentation.Symbol(tt.symbolPath)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.symbolPath, symbol.Name)
+			assert.Equal(t, tt.expectedSignature, symbol.Signature)
+			assert.Equal(t, tt.expectedDoc, symbol.Doc)
+			assert.Equal(t, tt.expectedGoKind, symbol.GoKind)
+
+			var examples []string
+			for _, example := range symbol.Examples {
+				examples = append(examples, example.Name)
+			}
+			assert.Equal(t, tt.expectedExamples, examples)
+		})
+	}
+}
+
+func TestPackageDocumentationExamples(t *testing.T) {
+	documentation := loadTestPackageDocumentation(t, false)
+
+	symbol, err := documentation.Symbol("Calculator.Add")
+	assert.NoError(t, err)
+	assert.Equal(t, []results.DocExample{{
+		Name:   "ExampleCalculator_Add",
+		Doc:    "This example adds a number.",
+		Code:   "c := calc.NewCalculator(1)\nfmt.Println(c.Add(2))",
+		Output: "3",
+	}}, symbol.Examples)
+}
+
+func TestLoadPackageDocumentation_DefinitionFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "calc.go"), []byte(documentationTestSource), 0o644))
+	excluded := filepath.Join(dir, "calc_other.go")
+	assert.NoError(t, os.WriteFile(excluded, []byte("//go:build ignore\n\npackage calc\n\n// Other is only built with a tag.\nfunc Other() {}\n"), 0o644))
+
+	documentation, err := LoadPackageDocumentation(dir, "example.com/calc", dir, false)
+	assert.NoError(t, err)
+	_, err = documentation.Symbol("Other")
+	assert.Error(t, err)
+
+	// Files that gopls resolved a symbol to are documented even if the current build context excludes them
+	documentation, err = LoadPackageDocumentation(dir, "example.com/calc", dir, false, excluded, filepath.Join(dir, "calc.go"))
+	assert.NoError(t, err)
+	symbol, err := documentation.Symbol("Other")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Other is only built with a tag.", symbol.Doc)
+	}
+}
+
+func TestFindPackageDir(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/project\n"), 0o644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "pkg"), 0o755))
+
+	dir, err := FindPackageDir(root, "example.com/project/pkg")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "pkg"), dir)
+
+	dir, err = FindPackageDir(root, "strings")
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "strings.go"))
+
+	_, err = FindPackageDir(root, "example.com/missing")
+	assert.Error(t, err)
+
+	// Workspace directories named like standard library packages don't shadow them
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "errors"), 0o755))
+	dir, err = FindPackageDir(root, "errors")
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "errors.go"))
+
+	dir, err = FindPackageDir(root, "./errors")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "errors"), dir)
+
+	dir, err = FindPackageDir(root, filepath.Join(root, "pkg"))
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "pkg"), dir)
+
+	_, err = FindPackageDir(root, "pkg")
+	assert.Error(t, err)
+}
+
+func TestSummarizeSignature(t *testing.T) {
+	tests := []struct {
+		signature string
+		expected  string
+	}{
+		{"func Add(a, b int) int", "func Add(a, b int) int"},
+		{"type Calculator struct {\n\tvalue int\n}", "type Calculator struct{ ... }"},
+		{"type Processor interface {\n\tProcess()\n}", "type Processor interface{ ... }"},
+		{"const (\n\tA = 1\n\tB = 2\n)", "const ( ... )"},
+		{"var handlers = map[string]int{\n\t\"a\": 1,\n}", "var handlers = map[string]int{ ... }"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SummarizeSignature(tt.signature))
+		})
+	}
+}
@@ -0,0 +1,513 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	// diffContextLines is the number of unchanged lines shown around each change in a diff
+	diffContextLines = 3
+)
+
+// EditSession applies workspace edits to files in memory, so that they can be previewed as diffs before being written to disk
+type EditSession struct {
+	workspaceRoot string
+	files         map[string]*editedFile // By absolute path
+	order         []string               // Absolute paths, in the order they were first edited
+}
+
+// editedFile represents the original and edited states of a file
+type editedFile struct {
+	originalExists bool
+	original       string
+	exists         bool
+	content        string
+	renamedFrom    string // Absolute path of the file this file was renamed from, if any
+}
+
+// NewEditSession creates a new edit session for files in a workspace
+func NewEditSession(workspaceRoot string) *EditSession {
+	return &EditSession{
+		workspaceRoot: workspaceRoot,
+		files:         make(map[string]*editedFile),
+	}
+}
+
+// file returns the state of a file, reading it from disk the first time it's edited
+func (s *EditSession) file(path string) (*editedFile, error) {
+	if f, ok := s.files[path]; ok {
+		return f, nil
+	}
+	if IsReadOnlyFile(path) {
+		return nil, fmt.Errorf("%s is read-only", path)
+	}
+
+	f := &editedFile{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		f.originalExists, f.exists = true, true
+		f.original, f.content = string(data), string(data)
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	s.files[path] = f
+	s.order = append(s.order, path)
+	return f, nil
+}
+
+// ApplyWorkspaceEdit applies a workspace edit in memory. Nothing is written until Write is called.
+func (s *EditSession) ApplyWorkspaceEdit(edit types.WorkspaceEdit) error {
+	// Apply changes in a stable order, since map iteration order is random
+	uris := make([]string, 0, len(edit.Changes))
+	for uri := range edit.Changes {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	for _, uri := range uris {
+		if err := s.applyTextEdits(UriToPath(uri), edit.Changes[uri]); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range edit.DocumentChanges {
+		var err error
+		switch change.Kind {
+		case "":
+			err = s.applyTextEdits(UriToPath(change.TextDocument.URI), change.Edits)
+		case types.ResourceOperationCreate:
+			err = s.createFile(UriToPath(change.URI))
+		case types.ResourceOperationRename:
+			err = s.renameFile(UriToPath(change.OldURI), UriToPath(change.NewURI))
+		case types.ResourceOperationDelete:
+			err = s.deleteFile(UriToPath(change.URI))
+		default:
+			err = fmt.Errorf("unsupported resource operation %q", change.Kind)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Content returns the edited content of a file, reading it from disk if it hasn't been edited
+func (s *EditSession) Content(path string) (string, error) {
+	f, err := s.file(path)
+	if err != nil {
+		return "", err
+	}
+	if !f.exists {
+		return "", fmt.Errorf("%s does not exist", path)
+	}
+	return f.content, nil
+}
+
+// EditFile applies text edits to the edited content of a file
+func (s *EditSession) EditFile(path string, edits []types.TextEdit) error {
+	return s.applyTextEdits(path, edits)
+}
+
+// applyTextEdits applies text edits to the content of a file
+func (s *EditSession) applyTextEdits(path string, edits []types.TextEdit) error {
+	f, err := s.file(path)
+	if err != nil {
+		return err
+	}
+	if !f.exists {
+		return fmt.Errorf("cannot edit %s: file does not exist", path)
+	}
+
+	content, err := ApplyTextEdits(f.content, edits)
+	if err != nil {
+		return fmt.Errorf("failed to edit %s: %w", path, err)
+	}
+	f.content = content
+	return nil
+}
+
+// createFile creates an empty file
+func (s *EditSession) createFile(path string) error {
+	f, err := s.file(path)
+	if err != nil {
+		return err
+	}
+	if f.exists {
+		return fmt.Errorf("cannot create %s: file already exists", path)
+	}
+	f.exists, f.content = true, ""
+	return nil
+}
+
+// renameFile moves the content of a file to a new path
+func (s *EditSession) renameFile(oldPath string, newPath string) error {
+	oldFile, err := s.file(oldPath)
+	if err != nil {
+		return err
+	}
+	newFile, err := s.file(newPath)
+	if err != nil {
+		return err
+	}
+	if !oldFile.exists {
+		return fmt.Errorf("cannot rename %s: file does not exist", oldPath)
+	}
+	if newFile.exists {
+		return fmt.Errorf("cannot rename %s to %s: file already exists", oldPath, newPath)
+	}
+
+	newFile.exists, newFile.content = true, oldFile.content
+	newFile.renamedFrom = oldPath
+	if oldFile.renamedFrom != "" {
+		newFile.renamedFrom = oldFile.renamedFrom
+	}
+	oldFile.exists, oldFile.content, oldFile.renamedFrom = false, "", ""
+	return nil
+}
+
+// deleteFile deletes a file
+func (s *EditSession) deleteFile(path string) error {
+	f, err := s.file(path)
+	if err != nil {
+		return err
+	}
+	if !f.exists {
+		return fmt.Errorf("cannot delete %s: file does not exist", path)
+	}
+	f.exists, f.content, f.renamedFrom = false, "", ""
+	return nil
+}
+
+// Changes returns the changes to each edited file, with unified diffs, in the order the files were first edited
+func (s *EditSession) Changes() []results.FileChange {
+	renamed := make(map[string]bool) // Original paths of renamed files
+	for _, path := range s.order {
+		if from := s.files[path].renamedFrom; from != "" && !s.files[from].exists {
+			renamed[from] = true
+		}
+	}
+
+	var changes []results.FileChange
+	for _, path := range s.order {
+		f := s.files[path]
+		file, _ := GetDisplayPath(path, s.workspaceRoot)
+		switch {
+		case f.exists && f.renamedFrom != "" && !f.originalExists:
+			oldFile, _ := GetDisplayPath(f.renamedFrom, s.workspaceRoot)
+			original := s.files[f.renamedFrom].original
+			changes = append(changes, results.FileChange{
+				File:      file,
+				OldFile:   oldFile,
+				Operation: results.FileOperationRename,
+				Diff:      unifiedDiff(oldFile, file, original, f.content),
+			})
+		case f.exists && !f.originalExists:
+			changes = append(changes, results.FileChange{
+				File:      file,
+				Operation: results.FileOperationCreate,
+				Diff:      unifiedDiff("", file, "", f.content),
+			})
+		case !f.exists && f.originalExists && !renamed[path]:
+			changes = append(changes, results.FileChange{
+				File:      file,
+				Operation: results.FileOperationDelete,
+				Diff:      unifiedDiff(file, "", f.original, ""),
+			})
+		case f.exists && f.originalExists && f.content != f.original:
+			changes = append(changes, results.FileChange{
+				File:      file,
+				Operation: results.FileOperationModify,
+				Diff:      unifiedDiff(file, file, f.original, f.content),
+			})
+		}
+	}
+	return changes
+}
+
+// WorkspaceEdit returns a workspace edit that makes the changes of the session to the files on disk,
+// with line-based text edits for modified files and resource operations for created, renamed, and deleted files
+func (s *EditSession) WorkspaceEdit() types.WorkspaceEdit {
+	renamed := make(map[string]bool) // Original paths of renamed files
+	for _, path := range s.order {
+		if from := s.files[path].renamedFrom; from != "" && !s.files[from].exists {
+			renamed[from] = true
+		}
+	}
+
+	var edit types.WorkspaceEdit
+	var deleted []types.DocumentChange // Deletions go last, so that renamed files are deleted after their content is moved
+	for _, path := range s.order {
+		f := s.files[path]
+		uri := PathToUri(path, s.workspaceRoot)
+		switch {
+		case f.exists && f.renamedFrom != "" && !f.originalExists:
+			original := s.files[f.renamedFrom].original
+			edit.DocumentChanges = append(edit.DocumentChanges, types.DocumentChange{
+				Kind:   types.ResourceOperationRename,
+				OldURI: PathToUri(f.renamedFrom, s.workspaceRoot),
+				NewURI: uri,
+			})
+			if edits := lineTextEdits(original, f.content); len(edits) > 0 {
+				edit.DocumentChanges = append(edit.DocumentChanges, textDocumentChange(uri, edits))
+			}
+		case f.exists && !f.originalExists:
+			edit.DocumentChanges = append(edit.DocumentChanges,
+				types.DocumentChange{Kind: types.ResourceOperationCreate, URI: uri},
+				textDocumentChange(uri, []types.TextEdit{{NewText: f.content}}),
+			)
+		case !f.exists && f.originalExists && !renamed[path]:
+			deleted = append(deleted, types.DocumentChange{Kind: types.ResourceOperationDelete, URI: uri})
+		case f.exists && f.originalExists && f.content != f.original:
+			edit.DocumentChanges = append(edit.DocumentChanges, textDocumentChange(uri, lineTextEdits(f.original, f.content)))
+		}
+	}
+	edit.DocumentChanges = append(edit.DocumentChanges, deleted...)
+	return edit
+}
+
+// textDocumentChange creates the document change of a workspace edit that applies text edits to a file
+func textDocumentChange(uri string, edits []types.TextEdit) types.DocumentChange {
+	return types.DocumentChange{
+		TextDocumentEdit: types.TextDocumentEdit{
+			TextDocument: types.TextDocumentIdentifier{URI: uri},
+			Edits:        edits,
+		},
+	}
+}
+
+// lineTextEdits returns text edits that change content from one version to another, replacing whole lines
+func lineTextEdits(from string, to string) []types.TextEdit {
+	fromLines, toLines := strings.SplitAfter(from, "\n"), strings.SplitAfter(to, "\n")
+	lineStarts := make([]int, len(fromLines)+1)
+	for i, line := range fromLines {
+		lineStarts[i+1] = lineStarts[i] + len(line)
+	}
+
+	var edits []types.TextEdit
+	for _, opCode := range difflib.NewMatcher(fromLines, toLines).GetOpCodes() {
+		if opCode.Tag == 'e' {
+			continue
+		}
+		edits = append(edits, types.TextEdit{
+			Range: types.Range{
+				Start: offsetPosition(from, lineStarts[opCode.I1]),
+				End:   offsetPosition(from, lineStarts[opCode.I2]),
+			},
+			NewText: strings.Join(toLines[opCode.J1:opCode.J2], ""),
+		})
+	}
+	return edits
+}
+
+// Write writes the edited files to disk, returning the file events to notify the language server of
+func (s *EditSession) Write() ([]types.FileEvent, error) {
+	var events []types.FileEvent
+	for _, path := range s.order {
+		f := s.files[path]
+		switch {
+		case f.exists && (!f.originalExists || f.content != f.original):
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return events, fmt.Errorf("failed to create directory for %s: %w", path, err)
+			}
+			if err := os.WriteFile(path, []byte(f.content), filePerm(path, f.originalExists)); err != nil {
+				return events, fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			eventType := types.FileChangeTypeChanged
+			if !f.originalExists {
+				eventType = types.FileChangeTypeCreated
+			}
+			events = append(events, types.FileEvent{URI: PathToUri(path, s.workspaceRoot), Type: eventType})
+		case !f.exists && f.originalExists:
+			if err := os.Remove(path); err != nil {
+				return events, fmt.Errorf("failed to delete %s: %w", path, err)
+			}
+			events = append(events, types.FileEvent{URI: PathToUri(path, s.workspaceRoot), Type: types.FileChangeTypeDeleted})
+		}
+	}
+	return events, nil
+}
+
+// filePerm returns the permissions to write a file with, keeping the permissions of existing files
+func filePerm(path string, exists bool) os.FileMode {
+	if exists {
+		if info, err := os.Stat(path); err == nil {
+			return info.Mode().Perm()
+		}
+	}
+	return 0o644
+}
+
+// unifiedDiff returns a unified diff between two versions of a file, using /dev/null for missing files
+func unifiedDiff(fromFile string, toFile string, from string, to string) string {
+	if from == to {
+		return ""
+	}
+
+	diff := difflib.UnifiedDiff{
+		A:        splitLines(from),
+		B:        splitLines(to),
+		FromFile: "/dev/null",
+		ToFile:   "/dev/null",
+		Context:  diffContextLines,
+	}
+	if fromFile != "" {
+		diff.FromFile = "a/" + filepath.ToSlash(fromFile)
+	}
+	if toFile != "" {
+		diff.ToFile = "b/" + filepath.ToSlash(toFile)
+	}
+
+	text, err := difflib.GetUnifiedDiffString(diff)
+	if err != nil {
+		return ""
+	}
+	return text
+}
+
+// splitLines splits content into lines for a diff, each ending with a newline
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
+
+// ApplyTextEdits applies LSP text edits to content. Edits must not overlap, and edits at the same position are applied in order.
+func ApplyTextEdits(content string, edits []types.TextEdit) (string, error) {
+	edited, _, err := applyTextEditOffsets(content, edits)
+	return edited, err
+}
+
+// applyTextEditOffsets applies LSP text edits to content, also returning the byte offset of the new text of each edit in the edited content
+func applyTextEditOffsets(content string, edits []types.TextEdit) (string, []int, error) {
+	type offsetEdit struct {
+		index      int
+		start, end int
+		text       string
+	}
+
+	lineStarts := lineOffsets(content)
+	offsetEdits := make([]offsetEdit, 0, len(edits))
+	for i, edit := range edits {
+		start, err := positionOffset(content, lineStarts, edit.Range.Start)
+		if err != nil {
+			return "", nil, err
+		}
+		end, err := positionOffset(content, lineStarts, edit.Range.End)
+		if err != nil {
+			return "", nil, err
+		}
+		if end < start {
+			return "", nil, fmt.Errorf("invalid range %d:%d-%d:%d", edit.Range.Start.Line+1, edit.Range.Start.Character+1, edit.Range.End.Line+1, edit.Range.End.Character+1)
+		}
+		offsetEdits = append(offsetEdits, offsetEdit{index: i, start: start, end: end, text: edit.NewText})
+	}
+
+	sort.SliceStable(offsetEdits, func(i, j int) bool {
+		return offsetEdits[i].start < offsetEdits[j].start
+	})
+
+	var b strings.Builder
+	offsets := make([]int, len(edits))
+	last := 0
+	for _, edit := range offsetEdits {
+		if edit.start < last {
+			return "", nil, errors.New("overlapping edits")
+		}
+		b.WriteString(content[last:edit.start])
+		offsets[edit.index] = b.Len()
+		b.WriteString(edit.text)
+		last = edit.end
+	}
+	b.WriteString(content[last:])
+	return b.String(), offsets, nil
+}
+
+// MapPosition maps a position in content to the corresponding position in the content edited by text edits.
+// Returns false if the position is inside a range that the edits replaced.
+func MapPosition(content string, edits []types.TextEdit, position types.Position) (types.Position, bool, error) {
+	edited, newOffsets, err := applyTextEditOffsets(content, edits)
+	if err != nil {
+		return types.Position{}, false, err
+	}
+	lineStarts := lineOffsets(content)
+	offset, err := positionOffset(content, lineStarts, position)
+	if err != nil {
+		return types.Position{}, false, err
+	}
+
+	// Positions keep their distance from the end of the last edit before them
+	mapped, lastEnd := offset, -1
+	for i, edit := range edits {
+		start, _ := positionOffset(content, lineStarts, edit.Range.Start)
+		end, _ := positionOffset(content, lineStarts, edit.Range.End)
+		if start <= offset && offset < end {
+			return types.Position{}, false, nil
+		}
+		if end > offset || end < lastEnd {
+			continue
+		}
+		// Of edits ending at the same offset, the last one in the edited content is closest
+		if newEnd := newOffsets[i] + len(edit.NewText); end > lastEnd || newEnd+offset-end > mapped {
+			mapped, lastEnd = newEnd+offset-end, end
+		}
+	}
+	return offsetPosition(edited, mapped), true, nil
+}
+
+// offsetPosition converts a byte offset in content to an LSP position (with a UTF-16 character offset)
+func offsetPosition(content string, offset int) types.Position {
+	offset = min(max(offset, 0), len(content))
+	lineStart := strings.LastIndex(content[:offset], "\n") + 1
+	return types.Position{
+		Line:      strings.Count(content[:lineStart], "\n"),
+		Character: utf16Length(content[lineStart:offset]),
+	}
+}
+
+// lineOffsets returns the byte offsets of the start of each line in content
+func lineOffsets(content string) []int {
+	offsets := []int{0}
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+// positionOffset converts an LSP position (with a UTF-16 character offset) to a byte offset in content
+func positionOffset(content string, lineStarts []int, position types.Position) (int, error) {
+	if position.Line < 0 || position.Line >= len(lineStarts) {
+		return 0, fmt.Errorf("line %d is out of range", position.Line+1)
+	}
+
+	start := lineStarts[position.Line]
+	end := len(content)
+	if position.Line+1 < len(lineStarts) {
+		end = lineStarts[position.Line+1] - 1 // Exclude the newline
+	}
+	line := strings.TrimSuffix(content[start:end], "\r")
+
+	offset, ok := utf16OffsetToByte(line, position.Character)
+	if !ok || position.Character < 0 {
+		return 0, fmt.Errorf("character %d is out of range on line %d", position.Character+1, position.Line+1)
+	}
+	return start + offset, nil
+}
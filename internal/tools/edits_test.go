@@ -0,0 +1,338 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// textEdit creates a text edit from LSP coordinates
+func textEdit(startLine, startChar, endLine, endChar int, newText string) types.TextEdit {
+	return types.TextEdit{
+		Range: types.Range{
+			Start: types.Position{Line: startLine, Character: startChar},
+			End:   types.Position{Line: endLine, Character: endChar},
+		},
+		NewText: newText,
+	}
+}
+
+func TestApplyTextEdits(t *testing.T) {
+	content := "package main\n\nfunc main() {\n\tprintln(\"héllo\", \"😀\", x)\n}\n"
+
+	tests := []struct {
+		name        string
+		edits       []types.TextEdit
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "No edits",
+			expected: content,
+		},
+		{
+			name:     "Replace word",
+			edits:    []types.TextEdit{textEdit(0, 8, 0, 12, "util")},
+			expected: "package util\n\nfunc main() {\n\tprintln(\"héllo\", \"😀\", x)\n}\n",
+		},
+		{
+			name:     "Multi-line replacement",
+			edits:    []types.TextEdit{textEdit(2, 12, 4, 1, "{}")},
+			expected: "package main\n\nfunc main() {}\n",
+		},
+		{
+			name:     "UTF-16 offsets after non-ASCII characters",
+			edits:    []types.TextEdit{textEdit(3, 24, 3, 25, "y")},
+			expected: "package main\n\nfunc main() {\n\tprintln(\"héllo\", \"😀\", y)\n}\n",
+		},
+		{
+			name:     "Inserts at the same position are applied in order",
+			edits:    []types.TextEdit{textEdit(1, 0, 1, 0, "import \"fmt\"\n"), textEdit(1, 0, 1, 0, "\n")},
+			expected: "package main\nimport \"fmt\"\n\n\nfunc main() {\n\tprintln(\"héllo\", \"😀\", x)\n}\n",
+		},
+		{
+			name:     "Unordered edits",
+			edits:    []types.TextEdit{textEdit(2, 5, 2, 9, "run"), textEdit(0, 8, 0, 12, "util")},
+			expected: "package util\n\nfunc run() {\n\tprintln(\"héllo\", \"😀\", x)\n}\n",
+		},
+		{
+			name:     "Insert at end of file",
+			edits:    []types.TextEdit{textEdit(5, 0, 5, 0, "\nvar x = 1\n")},
+			expected: content + "\nvar x = 1\n",
+		},
+		{
+			name:        "Overlapping edits",
+			edits:       []types.TextEdit{textEdit(0, 0, 0, 10, ""), textEdit(0, 5, 0, 12, "")},
+			expectError: true,
+		},
+		{
+			name:        "Line out of range",
+			edits:       []types.TextEdit{textEdit(10, 0, 10, 0, "x")},
+			expectError: true,
+		},
+		{
+			name:        "Character out of range",
+			edits:       []types.TextEdit{textEdit(0, 20, 0, 20, "x")},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ApplyTextEdits(content, tt.edits)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestEditSession(t *testing.T) {
+	root := t.TempDir()
+	mainPath := filepath.Join(root, "main.go")
+	utilPath := filepath.Join(root, "util.go")
+	oldPath := filepath.Join(root, "old.go")
+	assert.NoError(t, os.WriteFile(mainPath, []byte("package main\n\nfunc main() {\n\trun()\n}\n"), 0o644))
+	assert.NoError(t, os.WriteFile(utilPath, []byte("package main\n\nfunc run() {}\n"), 0o644))
+	assert.NoError(t, os.WriteFile(oldPath, []byte("package main\n"), 0o644))
+
+	session := NewEditSession(root)
+	err := session.ApplyWorkspaceEdit(types.WorkspaceEdit{
+		DocumentChanges: []types.DocumentChange{
+			{TextDocumentEdit: types.TextDocumentEdit{
+				TextDocument: types.TextDocumentIdentifier{URI: PathToUri(mainPath, root)},
+				Edits:        []types.TextEdit{textEdit(3, 1, 3, 4, "start")},
+			}},
+			{Kind: types.ResourceOperationCreate, URI: PathToUri("new/new.go", root)},
+			{TextDocumentEdit: types.TextDocumentEdit{
+				TextDocument: types.TextDocumentIdentifier{URI: PathToUri("new/new.go", root)},
+				Edits:        []types.TextEdit{textEdit(0, 0, 0, 0, "package new\n")},
+			}},
+			{Kind: types.ResourceOperationRename, OldURI: PathToUri(utilPath, root), NewURI: PathToUri("start.go", root)},
+			{Kind: types.ResourceOperationDelete, URI: PathToUri(oldPath, root)},
+		},
+	})
+	assert.NoError(t, err)
+
+	err = session.ApplyWorkspaceEdit(types.WorkspaceEdit{
+		Changes: map[string][]types.TextEdit{
+			PathToUri("start.go", root): {textEdit(2, 5, 2, 8, "start")},
+		},
+	})
+	assert.NoError(t, err)
+
+	expected := []results.FileChange{
+		{
+			File:      "main.go",
+			Operation: results.FileOperationModify,
+			Diff:      "--- a/main.go\n+++ b/main.go\n@@ -1,5 +1,5 @@\n package main\n \n func main() {\n-\trun()\n+\tstart()\n }\n",
+		},
+		{
+			File:      "new/new.go",
+			Operation: results.FileOperationCreate,
+			Diff:      "--- /dev/null\n+++ b/new/new.go\n@@ -0,0 +1 @@\n+package new\n",
+		},
+		{
+			File:      "start.go",
+			OldFile:   "util.go",
+			Operation: results.FileOperationRename,
+			Diff:      "--- a/util.go\n+++ b/start.go\n@@ -1,3 +1,3 @@\n package main\n \n-func run() {}\n+func start() {}\n",
+		},
+		{
+			File:      "old.go",
+			Operation: results.FileOperationDelete,
+			Diff:      "--- a/old.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-package main\n",
+		},
+	}
+	assert.Equal(t, expected, session.Changes())
+
+	// The workspace edit of the session makes the same changes
+	replayed := NewEditSession(root)
+	assert.NoError(t, replayed.ApplyWorkspaceEdit(session.WorkspaceEdit()))
+	assert.Equal(t, expected, replayed.Changes())
+
+	// Nothing is written until Write is called
+	_, err = os.Stat(filepath.Join(root, "start.go"))
+	assert.True(t, os.IsNotExist(err))
+
+	events, err := session.Write()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []types.FileEvent{
+		{URI: PathToUri(mainPath, root), Type: types.FileChangeTypeChanged},
+		{URI: PathToUri("new/new.go", root), Type: types.FileChangeTypeCreated},
+		{URI: PathToUri("start.go", root), Type: types.FileChangeTypeCreated},
+		{URI: PathToUri(utilPath, root), Type: types.FileChangeTypeDeleted},
+		{URI: PathToUri(oldPath, root), Type: types.FileChangeTypeDeleted},
+	}, events)
+
+	data, err := os.ReadFile(filepath.Join(root, "start.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package main\n\nfunc start() {}\n", string(data))
+	_, err = os.Stat(utilPath)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(oldPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLineTextEdits(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{"Unchanged", "a\nb\n", "a\nb\n"},
+		{"Replace line", "a\nb\nc\n", "a\nx\nc\n"},
+		{"Insert and delete", "a\nb\nc\n", "x\na\nc\ny\n"},
+		{"No final newline", "a\nb", "a\nc"},
+		{"From empty", "", "a\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := lineTextEdits(tt.from, tt.to)
+			edited, err := ApplyTextEdits(tt.from, edits)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, edited)
+			if tt.from == tt.to {
+				assert.Empty(t, edits)
+			}
+		})
+	}
+}
+
+func TestEditSessionErrors(t *testing.T) {
+	root := t.TempDir()
+	mainPath := filepath.Join(root, "main.go")
+	assert.NoError(t, os.WriteFile(mainPath, []byte("package main\n"), 0o644))
+
+	tests := []struct {
+		name string
+		edit types.WorkspaceEdit
+	}{
+		{"Edit missing file", types.WorkspaceEdit{Changes: map[string][]types.TextEdit{PathToUri("missing.go", root): {textEdit(0, 0, 0, 0, "x")}}}},
+		{"Create existing file", types.WorkspaceEdit{DocumentChanges: []types.DocumentChange{{Kind: types.ResourceOperationCreate, URI: PathToUri(mainPath, root)}}}},
+		{"Delete missing file", types.WorkspaceEdit{DocumentChanges: []types.DocumentChange{{Kind: types.ResourceOperationDelete, URI: PathToUri("missing.go", root)}}}},
+		{"Rename onto existing file", types.WorkspaceEdit{DocumentChanges: []types.DocumentChange{{Kind: types.ResourceOperationRename, OldURI: PathToUri("missing.go", root), NewURI: PathToUri(mainPath, root)}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, NewEditSession(root).ApplyWorkspaceEdit(tt.edit))
+		})
+	}
+}
+
+func TestOffsetPosition(t *testing.T) {
+	content := "package main\n\nvar s = \"😀x\"\n"
+
+	tests := []struct {
+		name     string
+		offset   int
+		expected types.Position
+	}{
+		{"Start", 0, types.Position{Line: 0, Character: 0}},
+		{"Empty line", 13, types.Position{Line: 1, Character: 0}},
+		{"After multi-byte rune", 27, types.Position{Line: 2, Character: 11}},
+		{"End", len(content), types.Position{Line: 3, Character: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, offsetPosition(content, tt.offset))
+		})
+	}
+}
+
+func TestMapPosition(t *testing.T) {
+	content := "package main\n\nfunc main() {\n\tf(1)\n\tf(2)\n}\n"
+
+	tests := []struct {
+		name        string
+		edits       []types.TextEdit
+		position    types.Position
+		expected    types.Position
+		expectedOk  bool
+		expectError bool
+	}{
+		{
+			name:       "No edits",
+			position:   types.Position{Line: 4, Character: 1},
+			expected:   types.Position{Line: 4, Character: 1},
+			expectedOk: true,
+		},
+		{
+			name:       "Edit after position",
+			edits:      []types.TextEdit{textEdit(4, 3, 4, 4, "20")},
+			position:   types.Position{Line: 4, Character: 1},
+			expected:   types.Position{Line: 4, Character: 1},
+			expectedOk: true,
+		},
+		{
+			name:       "Lines inserted before position",
+			edits:      []types.TextEdit{textEdit(1, 0, 1, 0, "import \"fmt\"\n\n")},
+			position:   types.Position{Line: 4, Character: 1},
+			expected:   types.Position{Line: 6, Character: 1},
+			expectedOk: true,
+		},
+		{
+			name:       "Edit on the same line before position",
+			edits:      []types.TextEdit{textEdit(3, 1, 3, 5, "x := 1; g()")},
+			position:   types.Position{Line: 3, Character: 5},
+			expected:   types.Position{Line: 3, Character: 12},
+			expectedOk: true,
+		},
+		{
+			name:       "Insertion and shorter replacement before position",
+			edits:      []types.TextEdit{textEdit(3, 0, 3, 0, "\t// f\n"), textEdit(3, 1, 3, 5, "1")},
+			position:   types.Position{Line: 4, Character: 1},
+			expected:   types.Position{Line: 5, Character: 1},
+			expectedOk: true,
+		},
+		{
+			name:       "Deletion before position",
+			edits:      []types.TextEdit{textEdit(3, 0, 4, 0, "")},
+			position:   types.Position{Line: 4, Character: 1},
+			expected:   types.Position{Line: 3, Character: 1},
+			expectedOk: true,
+		},
+		{
+			name:       "Insertions at position",
+			edits:      []types.TextEdit{textEdit(4, 1, 4, 1, "a"), textEdit(4, 1, 4, 1, "b")},
+			position:   types.Position{Line: 4, Character: 1},
+			expected:   types.Position{Line: 4, Character: 3},
+			expectedOk: true,
+		},
+		{
+			name:     "Position replaced",
+			edits:    []types.TextEdit{textEdit(4, 1, 4, 5, "2")},
+			position: types.Position{Line: 4, Character: 1},
+		},
+		{
+			name:        "Position out of range",
+			position:    types.Position{Line: 10, Character: 0},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok, err := MapPosition(content, tt.edits, tt.position)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOk, ok)
+			if tt.expectedOk {
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+var (
+	// extractedFunctionPattern matches the declaration of an extracted function or method, capturing its name
+	extractedFunctionPattern = regexp.MustCompile(`func\s+(?:\([^)]*\)\s*)?([\p{L}_][\p{L}\p{N}_]*)\s*[\[(]`)
+	// extractedVariablePattern matches the declaration of an extracted variable, capturing its name
+	extractedVariablePattern = regexp.MustCompile(`(?m)^\s*(?:var\s+)?([\p{L}_][\p{L}\p{N}_]*)(?:\s*,\s*[\p{L}_][\p{L}\p{N}_]*)*\s*:?=`)
+	// extractedConstantPattern matches the declaration of an extracted constant, capturing its name
+	extractedConstantPattern = regexp.MustCompile(`(?m)^\s*const\s+([\p{L}_][\p{L}\p{N}_]*)\s*=`)
+
+	// extractNamePatterns maps extract code action kinds to the patterns of the declarations they generate
+	extractNamePatterns = map[types.CodeActionKind]*regexp.Regexp{
+		types.CodeActionKindExtractFunction:    extractedFunctionPattern,
+		types.CodeActionKindExtractMethod:      extractedFunctionPattern,
+		types.CodeActionKindExtractVariable:    extractedVariablePattern,
+		types.CodeActionKindExtractVariableAll: extractedVariablePattern,
+		types.CodeActionKindExtractConstant:    extractedConstantPattern,
+		types.CodeActionKindExtractConstantAll: extractedConstantPattern,
+	}
+)
+
+// Extraction represents the result of an extract refactoring
+type Extraction struct {
+	Action        types.CodeAction
+	GeneratedName string // Name that gopls generated for the extracted declaration
+	Changes       []results.FileChange
+}
+
+// Extract applies the first available extract code action of the given kinds to a range, then renames the declaration
+// that gopls generated to name. Unless dryRun is set, the changes are written to disk and the language server is notified of them.
+func Extract(ctx context.Context, client types.Client, workspaceRoot string, uri string, rng types.Range, kinds []types.CodeActionKind, name string, dryRun bool) (*Extraction, error) {
+	actions, _, err := GetCodeActions(ctx, client, uri, rng, kinds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code actions: %w", err)
+	}
+	action, err := FindCodeActionByKind(actions, kinds, "the range must select complete statements or a complete expression")
+	if err != nil {
+		return nil, err
+	}
+
+	edits, err := CodeActionEdits(ctx, client, *action, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	path := UriToPath(uri)
+	session := NewEditSession(workspaceRoot)
+	extraction := &Extraction{Action: *action}
+	var namePosition types.Position
+	for _, edit := range edits {
+		content, err := session.Content(path)
+		if err != nil {
+			return nil, err
+		}
+		if extraction.GeneratedName == "" {
+			extraction.GeneratedName, namePosition = findGeneratedName(content, workspaceEditTextEdits(edit, path), extractNamePatterns[action.Kind])
+		}
+		if err := session.ApplyWorkspaceEdit(edit); err != nil {
+			return nil, err
+		}
+	}
+	if extraction.GeneratedName == "" {
+		return nil, fmt.Errorf("couldn't find the declaration generated by code action %q", action.Title)
+	}
+
+	slog.Debug("Extracted declaration",
+		"title", action.Title,
+		"generated_name", extraction.GeneratedName,
+		"line", namePosition.Line,
+		"character", namePosition.Character)
+
+	if name != extraction.GeneratedName {
+		if err := renameInSession(ctx, client, session, path, namePosition, name); err != nil {
+			return nil, fmt.Errorf("failed to rename %s to %s: %w", extraction.GeneratedName, name, err)
+		}
+	}
+
+	extraction.Changes = session.Changes()
+	if !dryRun && len(extraction.Changes) > 0 {
+		if err := WriteEditSession(ctx, client, session); err != nil {
+			return nil, err
+		}
+	}
+	return extraction, nil
+}
+
+// workspaceEditTextEdits returns the text edits of a workspace edit for a file
+func workspaceEditTextEdits(edit types.WorkspaceEdit, path string) []types.TextEdit {
+	var edits []types.TextEdit
+	for uri, changes := range edit.Changes {
+		if UriToPath(uri) == path {
+			edits = append(edits, changes...)
+		}
+	}
+	for _, change := range edit.DocumentChanges {
+		if change.Kind == "" && UriToPath(change.TextDocument.URI) == path {
+			edits = append(edits, change.Edits...)
+		}
+	}
+	return edits
+}
+
+// findGeneratedName finds the name of a declaration generated by text edits, using a pattern that captures the name of a declaration.
+// The pattern is matched against the edited content, since edits computed from diffs may split a declaration, but the name must be new text.
+// Returns the name and its position in the edited content, or an empty name if the edits don't declare one.
+func findGeneratedName(content string, edits []types.TextEdit, namePattern *regexp.Regexp) (string, types.Position) {
+	edited, offsets, err := applyTextEditOffsets(content, edits)
+	if err != nil {
+		return "", types.Position{}
+	}
+	for _, match := range namePattern.FindAllStringSubmatchIndex(edited, -1) {
+		start, end := match[2], match[3]
+		for i, edit := range edits {
+			if offsets[i] <= start && end <= offsets[i]+len(edit.NewText) {
+				return edited[start:end], offsetPosition(edited, start)
+			}
+		}
+	}
+	return "", types.Position{}
+}
+
+// renameInSession renames the identifier at a position of an edited file, opening its edited content in the language server
+// so that the rename sees the previous edits. The rename edits are applied to the session.
+func renameInSession(ctx context.Context, client types.Client, session *EditSession, path string, position types.Position, newName string) error {
+	content, err := session.Content(path)
+	if err != nil {
+		return err
+	}
+
+	uri := PathToUri(path, "")
+	if err := client.OpenDocument(ctx, uri, content); err != nil {
+		return err
+	}
+	defer func() {
+		if err := client.CloseDocument(ctx, uri); err != nil {
+			slog.Error("Failed to close document", "uri", uri, "error", err)
+		}
+	}()
+
+	edit, err := client.RenameSymbol(ctx, uri, position, newName)
+	if err != nil {
+		return err
+	}
+	if edit == nil {
+		return fmt.Errorf("no rename edits were returned")
+	}
+	return session.ApplyWorkspaceEdit(*edit)
+}
@@ -0,0 +1,174 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// extractClient is a fake client that offers an extract code action and renames in open documents
+type extractClient struct {
+	types.Client
+	actions        []types.CodeAction
+	renameEdits    []types.TextEdit
+	openedText     string
+	renamePosition *types.Position
+	closed         bool
+	fileEvents     []types.FileEvent
+}
+
+func (c *extractClient) GetDiagnostics(ctx context.Context, uri string) ([]types.Diagnostic, error) {
+	return nil, nil
+}
+
+func (c *extractClient) GetCodeActions(ctx context.Context, uri string, rng types.Range, actionContext types.CodeActionContext) ([]types.CodeAction, error) {
+	return c.actions, nil
+}
+
+func (c *extractClient) OpenDocument(ctx context.Context, uri string, text string) error {
+	c.openedText = text
+	return nil
+}
+
+func (c *extractClient) CloseDocument(ctx context.Context, uri string) error {
+	c.closed = true
+	return nil
+}
+
+func (c *extractClient) RenameSymbol(ctx context.Context, uri string, position types.Position, newName string) (*types.WorkspaceEdit, error) {
+	c.renamePosition = &position
+	return &types.WorkspaceEdit{Changes: map[string][]types.TextEdit{uri: c.renameEdits}}, nil
+}
+
+func (c *extractClient) DidChangeWatchedFiles(ctx context.Context, changes []types.FileEvent) error {
+	c.fileEvents = append(c.fileEvents, changes...)
+	return nil
+}
+
+func TestFindGeneratedName(t *testing.T) {
+	content := "package main\n\nfunc main() {\n\tx := 1\n\tresult := add(1, 2)\n\tprintln(result, x)\n}\n"
+
+	tests := []struct {
+		name             string
+		edits            []types.TextEdit
+		pattern          string
+		expectedName     string
+		expectedPosition types.Position
+	}{
+		{
+			name:             "Variable",
+			edits:            []types.TextEdit{textEdit(4, 1, 4, 1, "x1 := add(1, 2)\n\t"), textEdit(4, 11, 4, 20, "x1")},
+			pattern:          "variable",
+			expectedName:     "x1",
+			expectedPosition: types.Position{Line: 4, Character: 1},
+		},
+		{
+			name:             "Constant",
+			edits:            []types.TextEdit{textEdit(4, 1, 4, 1, "const k = 1 + 2\n\t"), textEdit(4, 11, 4, 20, "k")},
+			pattern:          "constant",
+			expectedName:     "k",
+			expectedPosition: types.Position{Line: 4, Character: 7},
+		},
+		{
+			name: "Function split across edits",
+			edits: []types.TextEdit{
+				textEdit(4, 1, 4, 20, "result := newFunction()"),
+				textEdit(7, 0, 7, 0, "\nfunc "),
+				textEdit(7, 0, 7, 0, "newFunction() int {\n\treturn add(1, 2)\n}\n"),
+			},
+			pattern:          "function",
+			expectedName:     "newFunction",
+			expectedPosition: types.Position{Line: 8, Character: 5},
+		},
+		{
+			name:    "No declaration",
+			edits:   []types.TextEdit{textEdit(4, 11, 4, 20, "3")},
+			pattern: "variable",
+		},
+	}
+
+	patterns := map[string]types.CodeActionKind{
+		"function": types.CodeActionKindExtractFunction,
+		"variable": types.CodeActionKindExtractVariable,
+		"constant": types.CodeActionKindExtractConstant,
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, position := findGeneratedName(content, tt.edits, extractNamePatterns[patterns[tt.pattern]])
+			assert.Equal(t, tt.expectedName, name)
+			assert.Equal(t, tt.expectedPosition, position)
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	content := "package main\n\nfunc main() {\n\tresult := add(1, 2)\n\tprintln(result)\n}\n"
+	extracted := "package main\n\nfunc main() {\n\tsum := add(1, 2)\n\tresult := sum\n\tprintln(result)\n}\n"
+
+	newClient := func(uri string) *extractClient {
+		edit := &types.WorkspaceEdit{Changes: map[string][]types.TextEdit{
+			uri: {textEdit(3, 1, 3, 1, "x := add(1, 2)\n\t"), textEdit(3, 11, 3, 20, "x")},
+		}}
+		return &extractClient{
+			actions:     []types.CodeAction{{Title: "Extract variable", Kind: types.CodeActionKindExtractVariable, Edit: edit}},
+			renameEdits: []types.TextEdit{textEdit(3, 1, 3, 2, "sum"), textEdit(4, 11, 4, 12, "sum")},
+		}
+	}
+	kinds := []types.CodeActionKind{types.CodeActionKindExtractVariable}
+	rng := types.Range{Start: types.Position{Line: 3, Character: 11}, End: types.Position{Line: 3, Character: 20}}
+
+	t.Run("Rename generated name", func(t *testing.T) {
+		root := t.TempDir()
+		path := filepath.Join(root, "main.go")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		uri := PathToUri(path, "")
+		client := newClient(uri)
+
+		extraction, err := Extract(context.Background(), client, root, uri, rng, kinds, "sum", true)
+		require.NoError(t, err)
+		assert.Equal(t, "x", extraction.GeneratedName)
+		assert.Equal(t, &types.Position{Line: 3, Character: 1}, client.renamePosition)
+		assert.Contains(t, client.openedText, "\tx := add(1, 2)\n\tresult := x\n", "Rename should see the extracted content")
+		assert.True(t, client.closed)
+		require.Len(t, extraction.Changes, 1)
+		assert.Contains(t, extraction.Changes[0].Diff, "+\tsum := add(1, 2)")
+
+		onDisk, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(onDisk), "Dry run should not write files")
+	})
+
+	t.Run("Keep generated name", func(t *testing.T) {
+		root := t.TempDir()
+		path := filepath.Join(root, "main.go")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		uri := PathToUri(path, "")
+		client := newClient(uri)
+
+		extraction, err := Extract(context.Background(), client, root, uri, rng, kinds, "x", true)
+		require.NoError(t, err)
+		assert.Nil(t, client.renamePosition, "Rename should be skipped")
+		assert.Len(t, extraction.Changes, 1)
+	})
+
+	t.Run("Write", func(t *testing.T) {
+		root := t.TempDir()
+		path := filepath.Join(root, "main.go")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		uri := PathToUri(path, "")
+		client := newClient(uri)
+
+		_, err := Extract(context.Background(), client, root, uri, rng, kinds, "sum", false)
+		require.NoError(t, err)
+
+		onDisk, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, extracted, string(onDisk))
+		assert.Equal(t, []types.FileEvent{{URI: uri, Type: types.FileChangeTypeChanged}}, client.fileEvents)
+	})
+}
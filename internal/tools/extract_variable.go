@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ExtractVariableTool handles extract variable requests
+type ExtractVariableTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewExtractVariableTool creates a new extract variable tool
+func NewExtractVariableTool(client types.Client, config types.Config) *ExtractVariableTool {
+	return &ExtractVariableTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *ExtractVariableTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Extract the expression selected by a range into a new local variable with the given name, declared before the enclosing statement. Constant expressions are extracted into constants. Returns a unified diff of each changed file; use dry_run to preview the changes without writing them."),
+		},
+		rangeToolOptions()...,
+	)
+	options = append(options,
+		mcp.WithString("name", mcp.Required(), mcp.Description("Name of the new variable or constant")),
+		mcp.WithBoolean("all_occurrences", mcp.Description("Whether to replace all occurrences of the expression in the enclosing function, instead of only the selected one (default: false)")),
+		mcp.WithBoolean("dry_run", mcp.Description("Whether to only preview the changes, without writing them to disk (default: false)")),
+	)
+	tool := mcp.NewTool("extract_variable", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *ExtractVariableTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name := mcp.ParseString(req, "name", "")
+	if name == "" {
+		slog.Debug("MCP tool called with missing name parameter", "tool", "extract_variable")
+		return mcp.NewToolResultError("name parameter is required"), nil
+	}
+
+	if !IsValidGoIdentifier(name) {
+		slog.Debug("Invalid Go identifier provided",
+			"tool", "extract_variable",
+			"name", name)
+		return mcp.NewToolResultError(fmt.Sprintf("'%s' is not a valid Go identifier", name)), nil
+	}
+
+	rangeArgs, err := parseRangeArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid range parameters", "tool", "extract_variable", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	allOccurrences := mcp.ParseBoolean(req, "all_occurrences", false)
+	dryRun := mcp.ParseBoolean(req, "dry_run", false)
+
+	slog.Debug("MCP tool called",
+		"tool", "extract_variable",
+		"symbol_anchor", rangeArgs.anchor,
+		"file_path", rangeArgs.filePath,
+		"line", rangeArgs.line,
+		"column", rangeArgs.column,
+		"end_line", rangeArgs.endLine,
+		"end_column", rangeArgs.endColumn,
+		"end_anchor", rangeArgs.endAnchor,
+		"name", name,
+		"all_occurrences", allOccurrences,
+		"dry_run", dryRun)
+
+	resolved, rng, err := rangeArgs.resolveForEdit(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve range",
+			"tool", "extract_variable",
+			"symbol_anchor", rangeArgs.anchor,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid range: %v", err)), nil
+	}
+
+	// gopls offers to extract constant expressions into constants instead of variables
+	kinds := []types.CodeActionKind{types.CodeActionKindExtractVariable, types.CodeActionKindExtractConstant}
+	if allOccurrences {
+		kinds = []types.CodeActionKind{types.CodeActionKindExtractVariableAll, types.CodeActionKindExtractConstantAll}
+	}
+	extraction, err := Extract(ctx, t.client, t.config.WorkspaceRoot, resolved.URI, rng, kinds, name, dryRun)
+	if err != nil {
+		slog.Debug("Failed to extract variable",
+			"tool", "extract_variable",
+			"uri", resolved.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to extract variable: %v", err)), nil
+	}
+
+	actionInfo := results.NewCodeActionInfo(extraction.Action)
+	toolResult := results.ExtractToolResult{
+		Arguments: results.ExtractToolArgs{
+			SymbolAnchor:   rangeArgs.anchor,
+			FilePath:       rangeArgs.filePath,
+			Line:           rangeArgs.line,
+			Column:         rangeArgs.column,
+			EndLine:        rangeArgs.endLine,
+			EndColumn:      rangeArgs.endColumn,
+			EndAnchor:      rangeArgs.endAnchor,
+			Name:           name,
+			AllOccurrences: allOccurrences,
+			DryRun:         dryRun,
+		},
+		CodeAction:    &actionInfo,
+		GeneratedName: extraction.GeneratedName,
+		Applied:       !dryRun && len(extraction.Changes) > 0,
+		FileChanges:   make([]results.FileChange, 0, len(extraction.Changes)),
+	}
+	toolResult.FileChanges = append(toolResult.FileChanges, extraction.Changes...)
+
+	if dryRun {
+		toolResult.Message = fmt.Sprintf("Extracting %s would change %d files. No changes were written (dry run).", name, len(extraction.Changes))
+	} else {
+		toolResult.Message = fmt.Sprintf("Extracted %s, changing %d files.", name, len(extraction.Changes))
+	}
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "extract_variable",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "extract_variable",
+		"generated_name", extraction.GeneratedName,
+		"file_change_count", len(toolResult.FileChanges),
+		"applied", toolResult.Applied,
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
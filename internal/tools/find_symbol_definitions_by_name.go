@@ -5,6 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/averycrespi/gopls-mcp/internal/results"
 	"github.com/averycrespi/gopls-mcp/pkg/types"
@@ -15,6 +19,20 @@ import (
 const (
 	// DefaultDefinitionsLimit is the default maximum number of symbol definitions to return
 	DefaultDefinitionsLimit = 50
+
+	// MatchModeFuzzy matches symbols fuzzily, like an editor's symbol search
+	MatchModeFuzzy = "fuzzy"
+	// MatchModeExact matches symbols whose name (or a qualified suffix of it) equals the query
+	MatchModeExact = "exact"
+	// MatchModeCaseSensitive matches symbols whose name contains the query, respecting case
+	MatchModeCaseSensitive = "case_sensitive"
+	// MatchModePrefix matches symbols whose name starts with the query, ignoring case
+	MatchModePrefix = "prefix"
+	// MatchModeQualified matches symbols by qualified name, like "pkg.Type.Method" or "example.com/pkg.Type.Method"
+	MatchModeQualified = "qualified"
+
+	// goplsMaxWorkspaceSymbols is the maximum number of symbols that gopls returns for a workspace symbol search
+	goplsMaxWorkspaceSymbols = 100
 )
 
 // FindSymbolDefinitionsByNameTool handles find symbol definitions by name requests
@@ -34,10 +52,32 @@ func NewFindSymbolDefinitionsByNameTool(client types.Client, config types.Config
 // GetTool returns the MCP tool definition
 func (t *FindSymbolDefinitionsByNameTool) GetTool() mcp.Tool {
 	tool := mcp.NewTool("find_symbol_definitions_by_name",
-		mcp.WithDescription("Find the definitions of a symbol by name in the Go workspace, returning a list of symbol definitions"),
-		mcp.WithString("symbol_name", mcp.Required(), mcp.Description("Symbol name to find the definitions for, with fuzzy matching")),
+		mcp.WithDescription(fmt.Sprintf("Find the definitions of a symbol by name in the Go workspace, returning a list of symbol definitions. Every match_mode filters the best %d fuzzy matches of gopls, so exact or prefix matches that rank below them are missed; use specific or qualified names for common symbols.", goplsMaxWorkspaceSymbols)),
+		mcp.WithString("symbol_name", mcp.Required(), mcp.Description("Symbol name to find the definitions for, matched according to match_mode")),
+		mcp.WithString(
+			"match_mode",
+			mcp.Enum(MatchModeFuzzy, MatchModeExact, MatchModeCaseSensitive, MatchModePrefix, MatchModeQualified),
+			mcp.Description("How to match the symbol name: 'fuzzy' (default), 'exact', 'case_sensitive' (substring), 'prefix' (ignoring case), or 'qualified' (e.g. 'pkg.Type.Method')"),
+		),
+		mcp.WithString(
+			"scope",
+			mcp.Enum(types.SymbolScopeWorkspace, types.SymbolScopeAll),
+			mcp.Description("Where to search: 'workspace' for workspace packages only, or 'all' to include dependencies and the standard library (default: all)"),
+		),
 		mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum number of symbol definitions to return (default: %d)", DefaultDefinitionsLimit))),
+		mcp.WithString("cursor", mcp.Description("Cursor from the next_cursor field of a previous response, to get the next page of results")),
 		mcp.WithBoolean("include_hover", mcp.Description("Whether to include hover information for symbols (default: false)")),
+		mcp.WithArray(
+			"kinds",
+			mcp.Items(map[string]any{"type": "string"}),
+			mcp.Description("Only include symbols of these kinds, e.g. [\"struct\", \"interface\", \"function\", \"method\"]"),
+		),
+		mcp.WithString("package_pattern", mcp.Description("Only include symbols in packages whose import path matches this pattern, where '...' matches any string (e.g. 'example.com/project/internal/...')")),
+		mcp.WithString("file_glob", mcp.Description("Only include symbols in files matching this glob, relative to the workspace root (e.g. 'internal/**.go'); patterns without a slash match file names")),
+		mcp.WithBoolean("exported_only", mcp.Description("Whether to only include exported symbols (default: false)")),
+		mcp.WithBoolean("exclude_tests", mcp.Description("Whether to exclude symbols defined in _test.go files (default: false)")),
+		mcp.WithBoolean("exclude_vendor", mcp.Description("Whether to exclude symbols defined in vendor directories (default: false)")),
+		mcp.WithBoolean("exclude_generated", mcp.Description("Whether to exclude symbols defined in generated files (default: false)")),
 	)
 	return tool
 }
@@ -50,15 +90,61 @@ func (t *FindSymbolDefinitionsByNameTool) Handle(ctx context.Context, req mcp.Ca
 		return mcp.NewToolResultError("symbol_name parameter is required"), nil
 	}
 
+	matchMode := mcp.ParseString(req, "match_mode", MatchModeFuzzy)
+	switch matchMode {
+	case MatchModeFuzzy, MatchModeExact, MatchModeCaseSensitive, MatchModePrefix:
+	case MatchModeQualified:
+		if !strings.Contains(symbolName, ".") {
+			slog.Debug("MCP tool called with unqualified symbol_name", "tool", "find_symbol_definitions_by_name", "symbol_name", symbolName)
+			return mcp.NewToolResultError("symbol_name must be qualified (e.g. 'pkg.Type.Method') when match_mode is 'qualified'"), nil
+		}
+	default:
+		slog.Debug("MCP tool called with invalid match_mode parameter", "tool", "find_symbol_definitions_by_name", "match_mode", matchMode)
+		return mcp.NewToolResultError(fmt.Sprintf("'%s' is not a valid match mode", matchMode)), nil
+	}
+
+	scope := mcp.ParseString(req, "scope", types.SymbolScopeAll)
+	if scope != types.SymbolScopeWorkspace && scope != types.SymbolScopeAll {
+		slog.Debug("MCP tool called with invalid scope parameter", "tool", "find_symbol_definitions_by_name", "scope", scope)
+		return mcp.NewToolResultError(fmt.Sprintf("scope parameter must be one of '%s' or '%s'", types.SymbolScopeWorkspace, types.SymbolScopeAll)), nil
+	}
+
 	limit := mcp.ParseInt(req, "limit", DefaultDefinitionsLimit)
 	if limit <= 0 {
 		limit = DefaultDefinitionsLimit
 	}
 
+	cursor := mcp.ParseString(req, "cursor", "")
+
 	includeHover := mcp.ParseBoolean(req, "include_hover", false)
 
-	slog.Debug("MCP tool called", "tool", "find_symbol_definitions_by_name", "symbol_name", symbolName, "limit", limit, "include_hover", includeHover)
+	filter := definitionFilter{
+		kinds:            ParseStringArray(req, "kinds"),
+		packagePattern:   mcp.ParseString(req, "package_pattern", ""),
+		fileGlob:         mcp.ParseString(req, "file_glob", ""),
+		exportedOnly:     mcp.ParseBoolean(req, "exported_only", false),
+		excludeTests:     mcp.ParseBoolean(req, "exclude_tests", false),
+		excludeVendor:    mcp.ParseBoolean(req, "exclude_vendor", false),
+		excludeGenerated: mcp.ParseBoolean(req, "exclude_generated", false),
+	}
+	for _, kind := range filter.kinds {
+		if !results.SymbolKind(kind).IsValid() {
+			slog.Debug("MCP tool called with invalid kind", "tool", "find_symbol_definitions_by_name", "kind", kind)
+			return mcp.NewToolResultError(fmt.Sprintf("'%s' is not a valid symbol kind", kind)), nil
+		}
+	}
 
+	slog.Debug("MCP tool called",
+		"tool", "find_symbol_definitions_by_name",
+		"symbol_name", symbolName,
+		"match_mode", matchMode,
+		"scope", scope,
+		"limit", limit,
+		"cursor", cursor,
+		"include_hover", includeHover,
+		"filter", filter.String())
+
+	// gopls matches symbols fuzzily with a fixed configuration, so the match mode and scope are applied to its results below
 	symbols, err := t.client.FuzzyFindSymbol(ctx, symbolName)
 	if err != nil {
 		slog.Error("Failed to search workspace symbols",
@@ -77,66 +163,150 @@ func (t *FindSymbolDefinitionsByNameTool) Handle(ctx context.Context, req mcp.Ca
 
 	toolResult := results.FindSymbolDefinitionsByNameToolResult{
 		Arguments: results.FindSymbolDefinitionByNameToolArgs{
-			SymbolName:   symbolName,
-			Limit:        limit,
-			IncludeHover: includeHover,
+			SymbolName:       symbolName,
+			MatchMode:        matchMode,
+			Scope:            scope,
+			Limit:            limit,
+			Cursor:           cursor,
+			IncludeHover:     includeHover,
+			Kinds:            filter.kinds,
+			PackagePattern:   filter.packagePattern,
+			FileGlob:         filter.fileGlob,
+			ExportedOnly:     filter.exportedOnly,
+			ExcludeTests:     filter.excludeTests,
+			ExcludeVendor:    filter.excludeVendor,
+			ExcludeGenerated: filter.excludeGenerated,
 		},
 		Definitions: make([]results.SymbolDefinition, 0),
 	}
+
+	// Filter, rank and paginate on the workspace symbols, whose locations are their declarations, so that definitions are only
+	// resolved for the current page. Definitions are ranked by match quality, then ordered by location.
+	definitions := make([]resolvedDefinition, 0)
+	seen := make(map[string]bool)
+	importPaths := make(map[string]string) // By directory, since symbols are often in the same packages
 	for _, sym := range symbols {
-		defLocations, err := t.client.GoToDefinition(ctx, sym.Location.URI, sym.Location.Range.Start)
-		if err != nil {
-			// Skip definition errors; we'll handle the empty result case later
+		if !filter.matchesSymbol(sym) {
 			continue
 		}
 
-		for _, loc := range defLocations {
-			location := results.SymbolLocation{
-				File:        GetRelativePath(UriToPath(loc.URI), t.config.WorkspaceRoot),
-				DisplayLine: loc.Range.Start.Line + 1,      // Convert LSP coordinates to display line
-				DisplayChar: loc.Range.Start.Character + 1, // Convert LSP coordinates to display character
-			}
-			entry := results.SymbolDefinition{
-				Name:     sym.Name,
-				Kind:     results.NewSymbolKind(sym.Kind),
-				Location: location,
-				Anchor:   location.ToAnchor(),
-			}
+		loc := sym.Location
+		filePath := UriToPath(loc.URI)
+		if scope == types.SymbolScopeWorkspace && !inWorkspaceScope(filePath, t.config.WorkspaceRoot) {
+			continue
+		}
 
-			// Try to enhance with hover information if requested
-			if includeHover {
-				if hoverInfo, err := t.client.GetHoverInfo(ctx, loc.URI, loc.Range.Start); err == nil && hoverInfo != "" {
-					entry.HoverInfo = hoverInfo
-				}
-			}
+		// Drop symbols that gopls matched more loosely than the match mode allows, matching their fully qualified names
+		// so that qualified queries match regardless of how much of the name gopls included
+		dir := filepath.Dir(filePath)
+		importPath, ok := importPaths[dir]
+		if !ok {
+			importPath = PackageImportPath(filePath)
+			importPaths[dir] = importPath
+		}
+		matchQuality := results.NewMatchQuality(qualifiedSymbolName(sym.Name, importPath), symbolName)
+		if !matchesMode(matchQuality, matchMode) {
+			continue
+		}
 
-			toolResult.Definitions = append(toolResult.Definitions, entry)
+		key := sym.Name + "@" + locationKey(loc.URI, loc.Range.Start)
+		if seen[key] || !filter.matchesFile(filePath, t.config.WorkspaceRoot) {
+			continue
+		}
+		seen[key] = true
 
-			// Apply limit to prevent token overflow
-			if len(toolResult.Definitions) >= limit {
-				break
+		resolved := resolvedDefinition{
+			definition: results.SymbolDefinition{
+				Name:         sym.Name,
+				Kind:         results.NewSymbolKind(sym.Kind),
+				Exported:     IsExportedName(sym.Name),
+				MatchQuality: matchQuality,
+			},
+			kind: sym.Kind,
+		}
+		resolved.setLocation(loc, t.config.WorkspaceRoot)
+		definitions = append(definitions, resolved)
+	}
+	sort.SliceStable(definitions, func(i, j int) bool {
+		a, b := definitions[i].definition, definitions[j].definition
+		if a.MatchQuality.Rank() != b.MatchQuality.Rank() {
+			return a.MatchQuality.Rank() < b.MatchQuality.Rank()
+		}
+		if a.Location != b.Location {
+			return a.Location.Less(b.Location)
+		}
+		return a.Name < b.Name
+	})
+
+	// Apply pagination to prevent token overflow
+	page, err := Paginate(definitions, symbolName+"|"+matchMode+"|"+scope+"|"+filter.String(), cursor, limit)
+	if err != nil {
+		slog.Debug("Invalid cursor",
+			"tool", "find_symbol_definitions_by_name",
+			"cursor", cursor,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid cursor: %v", err)), nil
+	}
+	toolResult.Total = page.Total
+	toolResult.Truncated = page.Truncated
+	toolResult.NextCursor = page.NextCursor
+
+	// Document symbols are only needed for the current page, and are shared by definitions in the same file
+	documentSymbols := make(map[string][]types.DocumentSymbol)
+	for _, resolved := range page.Items {
+		// Workspace symbols are located at their declarations, but resolve the definition in case gopls reports another position
+		if defLocations, err := t.client.GoToDefinition(ctx, resolved.location.URI, resolved.location.Range.Start); err == nil && len(defLocations) > 0 {
+			resolved.setLocation(defLocations[0], t.config.WorkspaceRoot)
+		}
+		entry := resolved.definition
+
+		// Generate a semantic anchor for package-level symbols and their members
+		uri := resolved.location.URI
+		if _, ok := documentSymbols[uri]; !ok {
+			symbols, _ := t.client.GetDocumentSymbols(ctx, uri)
+			documentSymbols[uri] = symbols
+		}
+		if symbolPath := SemanticSymbolPath(documentSymbols[uri], resolved.location.Range.Start); symbolPath != "" {
+			if importPath := PackageImportPath(UriToPath(uri)); importPath != "" {
+				entry.SemanticAnchor = results.NewSemanticSymbolAnchor(importPath, symbolPath)
 			}
 		}
 
-		// Break outer loop if limit reached
-		if len(toolResult.Definitions) >= limit {
-			break
+		// Try to enhance with hover information if requested
+		if includeHover {
+			entry.HoverInfo = GetHoverInfo(ctx, t.client, resolved.location.URI, resolved.location.Range.Start)
 		}
+
+		// Document symbols name methods after their receivers, and have details that workspace symbols lack
+		kind, name, detail := resolved.kind, entry.Name, ""
+		if docSym := FindDocumentSymbolAt(documentSymbols[uri], resolved.location.Range.Start); docSym != nil {
+			kind, name, detail = docSym.Kind, docSym.Name, docSym.Detail
+		}
+		entry.GoKind = GetGoSymbolKind(uri, resolved.location.Range.Start, kind, name, detail, entry.HoverInfo)
+
+		toolResult.Definitions = append(toolResult.Definitions, entry)
 	}
 
-	if len(toolResult.Definitions) == 0 {
+	if toolResult.Total == 0 {
 		toolResult.Message = "No symbol definitions found in the Go workspace. " +
 			"This could mean that the symbol name is incorrect, or that the symbol is not defined in the workspace."
+		if filter.isActive() {
+			toolResult.Message += " Filters were applied, so you can also try removing some of them."
+		}
 		slog.Debug("No definitions found",
 			"tool", "find_symbol_definitions_by_name",
 			"symbol_name", symbolName)
 	} else {
-		toolResult.Message = fmt.Sprintf("Found %d symbol definitions in the Go workspace.", len(toolResult.Definitions))
+		toolResult.Message = fmt.Sprintf("Found %d symbol definitions in the Go workspace.", toolResult.Total) + PageMessage(page)
 		slog.Debug("Found symbol definitions",
 			"tool", "find_symbol_definitions_by_name",
 			"symbol_name", symbolName,
+			"total_count", toolResult.Total,
 			"definition_count", len(toolResult.Definitions))
 	}
+	if len(symbols) >= goplsMaxWorkspaceSymbols {
+		toolResult.Message += fmt.Sprintf(" gopls returned its maximum of %d fuzzy matches, so other matches may be missing; use a more specific symbol name to find them.", goplsMaxWorkspaceSymbols)
+	}
 
 	jsonBytes, err := json.Marshal(toolResult)
 	if err != nil {
@@ -155,3 +325,113 @@ func (t *FindSymbolDefinitionsByNameTool) Handle(ctx context.Context, req mcp.Ca
 
 	return mcp.NewToolResultText(string(jsonBytes)), nil
 }
+
+// resolvedDefinition pairs a symbol definition with its LSP location and LSP symbol kind
+type resolvedDefinition struct {
+	definition results.SymbolDefinition
+	location   types.Location
+	kind       int
+}
+
+// setLocation sets the location of a definition, along with the fields that depend on it
+func (d *resolvedDefinition) setLocation(loc types.Location, workspaceRoot string) {
+	location := results.SymbolLocation{
+		File:        GetRelativePath(UriToPath(loc.URI), workspaceRoot),
+		DisplayLine: loc.Range.Start.Line + 1,      // Convert LSP coordinates to display line
+		DisplayChar: loc.Range.Start.Character + 1, // Convert LSP coordinates to display character
+	}
+	d.location = loc
+	d.definition.Location = location
+	d.definition.Anchor = location.ToAnchor().WithName(results.NewSymbolIdentifier(d.definition.Name))
+	d.definition.TestFunction = results.NewTestFunctionKind(d.kind, d.definition.Name, location.File)
+}
+
+// qualifiedSymbolName returns the fully qualified name of a workspace symbol, like "example.com/pkg.Type.Method".
+// gopls qualifies symbol names dynamically, so the name may already start with a suffix of the import path, like "pkg.Type.Method".
+func qualifiedSymbolName(name string, importPath string) string {
+	if importPath == "" {
+		return name
+	}
+	if strings.HasPrefix(name, importPath+".") {
+		return name
+	}
+	for i := 0; i < len(importPath); i++ {
+		if importPath[i] != '/' {
+			continue
+		}
+		if suffix := importPath[i+1:]; strings.HasPrefix(name, suffix+".") {
+			return importPath + "." + strings.TrimPrefix(name, suffix+".")
+		}
+	}
+	return importPath + "." + name
+}
+
+// inWorkspaceScope checks if a file belongs to the workspace symbol scope, which excludes dependencies and the standard library
+func inWorkspaceScope(filePath string, workspaceRoot string) bool {
+	rel, external := GetDisplayPath(filePath, workspaceRoot)
+	return !external && !IsVendorPath(rel)
+}
+
+// matchesMode checks if a match quality is acceptable for a match mode
+func matchesMode(matchQuality results.MatchQuality, matchMode string) bool {
+	switch matchMode {
+	case MatchModeExact, MatchModeQualified:
+		return matchQuality == results.MatchQualityExact
+	case MatchModeCaseSensitive:
+		return matchQuality == results.MatchQualityExact ||
+			matchQuality == results.MatchQualityPrefix ||
+			matchQuality == results.MatchQualitySubstring
+	case MatchModePrefix:
+		return matchQuality.Rank() <= results.MatchQualityPrefixIgnoreCase.Rank()
+	default:
+		return true
+	}
+}
+
+// definitionFilter filters symbol definitions by kind, name, package, and file
+type definitionFilter struct {
+	kinds            []string
+	packagePattern   string
+	fileGlob         string
+	exportedOnly     bool
+	excludeTests     bool
+	excludeVendor    bool
+	excludeGenerated bool
+}
+
+// isActive checks if any filter is set
+func (f definitionFilter) isActive() bool {
+	return len(f.kinds) > 0 || f.packagePattern != "" || f.fileGlob != "" ||
+		f.exportedOnly || f.excludeTests || f.excludeVendor || f.excludeGenerated
+}
+
+// String returns a stable representation of the filter, for logging and cursor fingerprints
+func (f definitionFilter) String() string {
+	return fmt.Sprintf("kinds=%s;package=%s;glob=%s;exported=%t;tests=%t;vendor=%t;generated=%t",
+		strings.Join(f.kinds, ","), f.packagePattern, f.fileGlob, f.exportedOnly, f.excludeTests, f.excludeVendor, f.excludeGenerated)
+}
+
+// matchesSymbol checks the filters which only depend on the workspace symbol
+func (f definitionFilter) matchesSymbol(sym types.SymbolInformation) bool {
+	if len(f.kinds) > 0 && !slices.Contains(f.kinds, string(results.NewSymbolKind(sym.Kind))) {
+		return false
+	}
+	return !f.exportedOnly || IsExportedName(sym.Name)
+}
+
+// matchesFile checks the filters which depend on the file containing the definition
+func (f definitionFilter) matchesFile(filePath string, workspaceRoot string) bool {
+	if f.excludeTests && IsTestFile(filePath) {
+		return false
+	}
+	if f.excludeVendor && IsVendorPath(GetRelativePath(filePath, workspaceRoot)) {
+		return false
+	}
+	if f.fileGlob != "" && !MatchFileGlob(f.fileGlob, GetRelativePath(filePath, workspaceRoot)) {
+		return false
+	}
+	if f.packagePattern != "" && !MatchPackagePattern(f.packagePattern, PackageImportPath(filePath)) {
+		return false
+	}
+	return !f.excludeGenerated || !IsGeneratedFile(filePath)
+}
@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQualifiedSymbolName(t *testing.T) {
+	tests := []struct {
+		name       string
+		symbol     string
+		importPath string
+		expected   string
+	}{
+		{name: "Unqualified", symbol: "Calculator.Add", importPath: "example.com/m/calc", expected: "example.com/m/calc.Calculator.Add"},
+		{name: "Package qualified", symbol: "calc.Calculator.Add", importPath: "example.com/m/calc", expected: "example.com/m/calc.Calculator.Add"},
+		{name: "Partially qualified", symbol: "m/calc.Calculator", importPath: "example.com/m/calc", expected: "example.com/m/calc.Calculator"},
+		{name: "Fully qualified", symbol: "example.com/m/calc.Calculator", importPath: "example.com/m/calc", expected: "example.com/m/calc.Calculator"},
+		{name: "Standard library", symbol: "Println", importPath: "fmt", expected: "fmt.Println"},
+		{name: "Unknown import path", symbol: "Calculator", importPath: "", expected: "Calculator"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, qualifiedSymbolName(tt.symbol, tt.importPath))
+		})
+	}
+}
+
+func TestQualifiedSymbolName_MatchModes(t *testing.T) {
+	qualified := qualifiedSymbolName("Calculator.Add", "example.com/m/calc")
+
+	assert.True(t, matchesMode(results.NewMatchQuality(qualified, "Add"), MatchModeExact))
+	assert.True(t, matchesMode(results.NewMatchQuality(qualified, "calc.Calculator.Add"), MatchModeQualified))
+	assert.True(t, matchesMode(results.NewMatchQuality(qualified, "example.com/m/calc.Calculator.Add"), MatchModeQualified))
+	assert.True(t, matchesMode(results.NewMatchQuality(qualified, "calcul"), MatchModePrefix))
+	assert.False(t, matchesMode(results.NewMatchQuality(qualified, "Calc"), MatchModeExact))
+	assert.False(t, matchesMode(results.NewMatchQuality(qualified, "add"), MatchModeCaseSensitive))
+	assert.False(t, matchesMode(results.NewMatchQuality(qualified, "other.Calculator.Add"), MatchModeQualified))
+}
+
+func TestInWorkspaceScope(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "workspace")
+
+	assert.True(t, inWorkspaceScope(filepath.Join(root, "calc", "calc.go"), root))
+	assert.False(t, inWorkspaceScope(filepath.Join(root, "vendor", "example.com", "dep", "dep.go"), root))
+	assert.False(t, inWorkspaceScope(filepath.Join(string(filepath.Separator), "go", "src", "fmt", "print.go"), root))
+}
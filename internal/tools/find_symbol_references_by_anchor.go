@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"path/filepath"
+	"sort"
 
 	"github.com/averycrespi/gopls-mcp/internal/results"
 	"github.com/averycrespi/gopls-mcp/pkg/types"
@@ -15,6 +17,14 @@ import (
 const (
 	// DefaultReferencesLimit is the default maximum number of symbol references to return
 	DefaultReferencesLimit = 100
+
+	// MaxReferenceContextLines is the maximum number of context lines to include on each side of a reference
+	MaxReferenceContextLines = 10
+
+	// ReferenceGroupByPackage groups references by package directory
+	ReferenceGroupByPackage = "package"
+	// ReferenceGroupByFile groups references by file
+	ReferenceGroupByFile = "file"
 )
 
 // FindSymbolReferencesByAnchorTool handles find symbol references by anchor requests
@@ -38,9 +48,21 @@ func (t *FindSymbolReferencesByAnchorTool) GetTool() mcp.Tool {
 		mcp.WithString(
 			"symbol_anchor",
 			mcp.Required(),
-			mcp.Description("Symbol anchor, which is included in tool responses. Don't try to parse or generate this yourself."),
+			mcp.Description("Symbol anchor, which is included in tool responses as 'anchor' or 'semantic_anchor'. Prefer semantic anchors (go://IMPORTPATH#Type.Method), which stay valid when the code is edited. Don't try to parse these yourself."),
+		),
+		mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum number of symbol references to return, or of groups when group_by is set without group (default: %d)", DefaultReferencesLimit))),
+		mcp.WithString("cursor", mcp.Description("Cursor from the next_cursor field of a previous response, to get the next page of results")),
+		mcp.WithBoolean("include_source", mcp.Description("Whether to include the source line and enclosing function for each reference (default: false)")),
+		mcp.WithNumber("context_lines", mcp.Description(fmt.Sprintf("Number of context lines to include before and after each source line, if include_source is true (default: 0, max: %d)", MaxReferenceContextLines))),
+		mcp.WithString(
+			"group_by",
+			mcp.Enum(ReferenceGroupByPackage, ReferenceGroupByFile),
+			mcp.Description("Summarize references by package directory or file instead of listing them, counting declarations, reads, writes, and test file references"),
+		),
+		mcp.WithString(
+			"group",
+			mcp.Description("Key of a single group from a previous group_by response; if set, lists only the references in that group"),
 		),
-		mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum number of symbol references to return (default: %d)", DefaultReferencesLimit))),
 	)
 	return tool
 }
@@ -58,27 +80,62 @@ func (t *FindSymbolReferencesByAnchorTool) Handle(ctx context.Context, req mcp.C
 		limit = DefaultReferencesLimit
 	}
 
-	slog.Debug("MCP tool called", "tool", "find_symbol_references_by_anchor", "symbol_anchor", anchorStr, "limit", limit)
+	cursor := mcp.ParseString(req, "cursor", "")
+
+	includeSource := mcp.ParseBoolean(req, "include_source", false)
+
+	contextLines := mcp.ParseInt(req, "context_lines", 0)
+	if contextLines < 0 {
+		contextLines = 0
+	}
+	if contextLines > MaxReferenceContextLines {
+		contextLines = MaxReferenceContextLines
+	}
+
+	groupBy := mcp.ParseString(req, "group_by", "")
+	if groupBy != "" && groupBy != ReferenceGroupByPackage && groupBy != ReferenceGroupByFile {
+		slog.Debug("MCP tool called with invalid group_by parameter", "tool", "find_symbol_references_by_anchor", "group_by", groupBy)
+		return mcp.NewToolResultError(
+			fmt.Sprintf("group_by parameter must be one of '%s' or '%s'", ReferenceGroupByPackage, ReferenceGroupByFile),
+		), nil
+	}
+
+	group := mcp.ParseString(req, "group", "")
+	if group != "" && groupBy == "" {
+		slog.Debug("MCP tool called with group but no group_by parameter", "tool", "find_symbol_references_by_anchor", "group", group)
+		return mcp.NewToolResultError("group_by parameter is required when group is set"), nil
+	}
+
+	slog.Debug("MCP tool called",
+		"tool", "find_symbol_references_by_anchor",
+		"symbol_anchor", anchorStr,
+		"limit", limit,
+		"cursor", cursor,
+		"include_source", includeSource,
+		"context_lines", contextLines,
+		"group_by", groupBy,
+		"group", group)
 
 	// Parse and validate the anchor
 	anchor := results.SymbolAnchor(anchorStr)
-	file, position, err := anchor.ToFilePosition()
+	resolved, err := ResolveSymbolAnchor(ctx, t.client, t.config.WorkspaceRoot, anchor)
 	if err != nil {
-		slog.Debug("Invalid anchor format",
+		slog.Debug("Failed to resolve symbol anchor",
 			"tool", "find_symbol_references_by_anchor",
 			"symbol_anchor", anchorStr,
 			"error", err)
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid anchor format: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid anchor: %v", err)), nil
 	}
 
-	slog.Debug("Parsed symbol anchor",
+	slog.Debug("Resolved symbol anchor",
 		"tool", "find_symbol_references_by_anchor",
 		"symbol_anchor", anchorStr,
-		"file", file,
-		"line", position.Line,
-		"character", position.Character)
+		"uri", resolved.URI,
+		"line", resolved.Position.Line,
+		"character", resolved.Position.Character,
+		"relocated_anchor", resolved.RelocatedAnchor)
+	uri, position := resolved.URI, resolved.Position
 
-	uri := PathToUri(file, t.config.WorkspaceRoot)
 	refLocations, err := t.client.FindReferences(ctx, uri, position)
 	if err != nil {
 		slog.Error("Failed to find references",
@@ -98,44 +155,143 @@ func (t *FindSymbolReferencesByAnchorTool) Handle(ctx context.Context, req mcp.C
 
 	toolResult := results.FindSymbolReferencesByAnchorToolResult{
 		Arguments: results.FindSymbolReferencesByAnchorToolArgs{
-			SymbolAnchor: anchorStr,
-			Limit:        limit,
+			SymbolAnchor:  anchorStr,
+			Limit:         limit,
+			Cursor:        cursor,
+			IncludeSource: includeSource,
+			ContextLines:  contextLines,
+			GroupBy:       groupBy,
+			Group:         group,
 		},
 		References: make([]results.SymbolReference, 0),
 	}
 
-	for _, refLoc := range refLocations {
-		// Apply limit to prevent token overflow
-		if len(toolResult.References) >= limit {
-			break
+	// Sort references so that the ordering is stable across pages
+	sort.SliceStable(refLocations, func(i, j int) bool {
+		a, b := refLocations[i], refLocations[j]
+		if a.URI != b.URI {
+			return a.URI < b.URI
 		}
+		if a.Range.Start.Line != b.Range.Start.Line {
+			return a.Range.Start.Line < b.Range.Start.Line
+		}
+		return a.Range.Start.Character < b.Range.Start.Character
+	})
 
+	references := make([]results.SymbolReference, len(refLocations))
+	for i, refLoc := range refLocations {
 		symbolLoc := results.SymbolLocation{
 			File:        GetRelativePath(UriToPath(refLoc.URI), t.config.WorkspaceRoot),
 			DisplayLine: refLoc.Range.Start.Line + 1,      // Convert LSP coordinates to display line
 			DisplayChar: refLoc.Range.Start.Character + 1, // Convert LSP coordinates to display character
 		}
-		toolResult.References = append(toolResult.References, results.SymbolReference{
+		references[i] = results.SymbolReference{
 			Location: symbolLoc,
-			Anchor:   symbolLoc.ToAnchor(),
-		})
+			Anchor:   symbolLoc.ToAnchor().WithName(resolved.Name),
+		}
 	}
 
-	if len(toolResult.References) == 0 {
-		toolResult.Message = "No references found for the symbol anchor. " +
-			"This could mean that the symbol has no references, or that your symbol anchor is out of date. " +
-			"You can try getting a fresh symbol anchor from another tool."
+	if groupBy != "" {
+		t.classifyReferences(ctx, uri, position, refLocations, references)
+
+		if group == "" {
+			// Summaries are paginated by group, so limit and cursor count groups rather than references
+			page, err := Paginate(groupReferences(references, groupBy), anchorStr+"|"+groupBy, cursor, limit)
+			if err != nil {
+				slog.Debug("Invalid cursor",
+					"tool", "find_symbol_references_by_anchor",
+					"cursor", cursor,
+					"error", err)
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid cursor: %v", err)), nil
+			}
+			toolResult.Total = page.Total
+			toolResult.Truncated = page.Truncated
+			toolResult.NextCursor = page.NextCursor
+			toolResult.Groups = page.Items
+			toolResult.References = nil
+			toolResult.Message = fmt.Sprintf("Found %d references in %d groups for the symbol anchor. "+
+				"Use the group parameter to list the references in a single group.", len(references), toolResult.Total) +
+				PageMessage(page) + RelocationMessage(resolved)
+			slog.Debug("Grouped symbol references",
+				"tool", "find_symbol_references_by_anchor",
+				"symbol_anchor", anchorStr,
+				"reference_count", len(references),
+				"group_count", toolResult.Total)
+
+			return t.marshalResult(anchorStr, toolResult)
+		}
+	}
+
+	// Select the references to list, by index into refLocations
+	selected := make([]int, 0, len(references))
+	for i, reference := range references {
+		if group == "" || referenceGroupKey(reference, groupBy) == group {
+			selected = append(selected, i)
+		}
+	}
+
+	// Apply pagination to prevent token overflow
+	page, err := Paginate(selected, anchorStr+"|"+groupBy+"|"+group, cursor, limit)
+	if err != nil {
+		slog.Debug("Invalid cursor",
+			"tool", "find_symbol_references_by_anchor",
+			"cursor", cursor,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid cursor: %v", err)), nil
+	}
+	toolResult.Total = page.Total
+	toolResult.Truncated = page.Truncated
+	toolResult.NextCursor = page.NextCursor
+
+	// Cache file contents and document symbols, since many references usually share a file
+	fileLines := make(map[string][]string)
+	fileSymbols := make(map[string][]types.DocumentSymbol)
+
+	for _, i := range page.Items {
+		reference := references[i]
+
+		// Try to enhance with source context if requested
+		if includeSource {
+			t.addSourceContext(ctx, &reference, refLocations[i], contextLines, fileLines, fileSymbols)
+		}
+
+		toolResult.References = append(toolResult.References, reference)
+	}
+
+	if toolResult.Total == 0 {
+		if group != "" {
+			toolResult.Message = fmt.Sprintf("No references found in group %s for the symbol anchor. "+
+				"Make sure that the group key comes from a response with the same group_by parameter.", group)
+		} else {
+			toolResult.Message = "No references found for the symbol anchor. " +
+				"This could mean that the symbol has no references, or that your symbol anchor is out of date. " +
+				"You can try getting a fresh symbol anchor from another tool."
+		}
 		slog.Debug("No references found",
 			"tool", "find_symbol_references_by_anchor",
-			"symbol_anchor", anchorStr)
+			"symbol_anchor", anchorStr,
+			"group", group)
 	} else {
-		toolResult.Message = fmt.Sprintf("Found %d references for the symbol anchor.", len(toolResult.References))
+		if group != "" {
+			toolResult.Message = fmt.Sprintf("Found %d references in group %s for the symbol anchor.", toolResult.Total, group)
+		} else {
+			toolResult.Message = fmt.Sprintf("Found %d references for the symbol anchor.", toolResult.Total)
+		}
+		toolResult.Message += PageMessage(page)
 		slog.Debug("Found symbol references",
 			"tool", "find_symbol_references_by_anchor",
 			"symbol_anchor", anchorStr,
+			"group", group,
+			"total_count", toolResult.Total,
 			"reference_count", len(toolResult.References))
 	}
+	toolResult.Message += RelocationMessage(resolved)
 
+	return t.marshalResult(anchorStr, toolResult)
+}
+
+// marshalResult marshals the tool result into a JSON tool response
+func (t *FindSymbolReferencesByAnchorTool) marshalResult(anchorStr string, toolResult results.FindSymbolReferencesByAnchorToolResult) (*mcp.CallToolResult, error) {
 	jsonBytes, err := json.Marshal(toolResult)
 	if err != nil {
 		slog.Error("Failed to marshal tool result",
@@ -148,7 +304,163 @@ func (t *FindSymbolReferencesByAnchorTool) Handle(ctx context.Context, req mcp.C
 	slog.Debug("MCP tool completed successfully",
 		"tool", "find_symbol_references_by_anchor",
 		"symbol_anchor", anchorStr,
-		"reference_count", len(toolResult.References))
+		"reference_count", len(toolResult.References),
+		"group_count", len(toolResult.Groups),
+		"response_size_bytes", len(jsonBytes))
 
 	return mcp.NewToolResultText(string(jsonBytes)), nil
 }
+
+// addSourceContext adds the source lines and enclosing function to a reference, using the caches to avoid repeated work
+func (t *FindSymbolReferencesByAnchorTool) addSourceContext(
+	ctx context.Context,
+	reference *results.SymbolReference,
+	refLoc types.Location,
+	contextLines int,
+	fileLines map[string][]string,
+	fileSymbols map[string][]types.DocumentSymbol,
+) {
+	lines, ok := fileLines[refLoc.URI]
+	if !ok {
+		var err error
+		if lines, err = ReadFileLines(UriToPath(refLoc.URI)); err != nil {
+			slog.Debug("Failed to read reference file",
+				"tool", "find_symbol_references_by_anchor",
+				"uri", refLoc.URI,
+				"error", err)
+		}
+		fileLines[refLoc.URI] = lines
+	}
+	reference.Source = GetSourceLines(lines, reference.Location.DisplayLine, contextLines)
+
+	symbols, ok := fileSymbols[refLoc.URI]
+	if !ok {
+		var err error
+		if symbols, err = t.client.GetDocumentSymbols(ctx, refLoc.URI); err != nil {
+			slog.Debug("Failed to get document symbols for reference file",
+				"tool", "find_symbol_references_by_anchor",
+				"uri", refLoc.URI,
+				"error", err)
+		}
+		fileSymbols[refLoc.URI] = symbols
+	}
+	if enclosing := FindEnclosingFunction(symbols, refLoc.Range.Start); enclosing != nil {
+		reference.EnclosingSymbol = enclosing.Name
+	}
+}
+
+// classifyReferences sets the kind and test file flag of each reference.
+// Declarations are found with a definition request, and writes are found with one document highlight request per file.
+func (t *FindSymbolReferencesByAnchorTool) classifyReferences(
+	ctx context.Context,
+	uri string,
+	position types.Position,
+	refLocations []types.Location,
+	references []results.SymbolReference,
+) {
+	declarations := make(map[string]bool)
+	if defLocations, err := t.client.GoToDefinition(ctx, uri, position); err == nil {
+		for _, defLoc := range defLocations {
+			declarations[locationKey(defLoc.URI, defLoc.Range.Start)] = true
+		}
+	} else {
+		slog.Debug("Failed to get definition for reference classification",
+			"tool", "find_symbol_references_by_anchor",
+			"uri", uri,
+			"error", err)
+	}
+
+	writes := make(map[string]bool)
+	highlightedFiles := make(map[string]bool)
+	for _, refLoc := range refLocations {
+		if highlightedFiles[refLoc.URI] {
+			continue
+		}
+		highlightedFiles[refLoc.URI] = true
+
+		// A single highlight request returns every occurrence of the symbol in the file
+		highlights, err := t.client.GetDocumentHighlights(ctx, refLoc.URI, refLoc.Range.Start)
+		if err != nil {
+			slog.Debug("Failed to get document highlights for reference classification",
+				"tool", "find_symbol_references_by_anchor",
+				"uri", refLoc.URI,
+				"error", err)
+			continue
+		}
+		for _, highlight := range highlights {
+			if highlight.Kind == types.DocumentHighlightKindWrite {
+				writes[locationKey(refLoc.URI, highlight.Range.Start)] = true
+			}
+		}
+	}
+
+	for i, refLoc := range refLocations {
+		key := locationKey(refLoc.URI, refLoc.Range.Start)
+		switch {
+		case declarations[key]:
+			references[i].Kind = results.ReferenceKindDeclaration
+		case writes[key]:
+			references[i].Kind = results.ReferenceKindWrite
+		default:
+			references[i].Kind = results.ReferenceKindRead
+		}
+		references[i].InTestFile = IsTestFile(references[i].Location.File)
+	}
+}
+
+// groupReferences summarizes classified references by package directory or file, largest groups first
+func groupReferences(references []results.SymbolReference, groupBy string) []results.ReferenceGroup {
+	groupsByKey := make(map[string]*results.ReferenceGroup)
+	filesByKey := make(map[string]map[string]bool)
+	for _, reference := range references {
+		key := referenceGroupKey(reference, groupBy)
+		group, ok := groupsByKey[key]
+		if !ok {
+			group = &results.ReferenceGroup{Key: key}
+			groupsByKey[key] = group
+			filesByKey[key] = make(map[string]bool)
+		}
+
+		group.Total++
+		switch reference.Kind {
+		case results.ReferenceKindDeclaration:
+			group.Declarations++
+		case results.ReferenceKindWrite:
+			group.Writes++
+		default:
+			group.Reads++
+		}
+		if reference.InTestFile {
+			group.TestReferences++
+		}
+		filesByKey[key][reference.Location.File] = true
+	}
+
+	groups := make([]results.ReferenceGroup, 0, len(groupsByKey))
+	for key, group := range groupsByKey {
+		if groupBy == ReferenceGroupByPackage {
+			group.Files = len(filesByKey[key])
+		}
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Total != groups[j].Total {
+			return groups[i].Total > groups[j].Total
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// referenceGroupKey returns the key of the group that a reference belongs to
+func referenceGroupKey(reference results.SymbolReference, groupBy string) string {
+	if groupBy == ReferenceGroupByPackage {
+		return filepath.Dir(reference.Location.File)
+	}
+	return reference.Location.File
+}
+
+// locationKey returns a comparable key for a position within a document
+func locationKey(uri string, position types.Position) string {
+	return fmt.Sprintf("%s#%d:%d", uri, position.Line, position.Character)
+}
@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupReferences(t *testing.T) {
+	newReference := func(file string, kind results.ReferenceKind, inTestFile bool) results.SymbolReference {
+		return results.SymbolReference{
+			Location:   results.SymbolLocation{File: file, DisplayLine: 1, DisplayChar: 1},
+			Kind:       kind,
+			InTestFile: inTestFile,
+		}
+	}
+	references := []results.SymbolReference{
+		newReference("calculator.go", results.ReferenceKindDeclaration, false),
+		newReference("calculator.go", results.ReferenceKindRead, false),
+		newReference("main.go", results.ReferenceKindWrite, false),
+		newReference("pkg/util/util.go", results.ReferenceKindRead, false),
+		newReference("pkg/util/util_test.go", results.ReferenceKindRead, true),
+	}
+
+	t.Run("Group by package", func(t *testing.T) {
+		groups := groupReferences(references, ReferenceGroupByPackage)
+		assert.Equal(t, []results.ReferenceGroup{
+			{Key: ".", Total: 3, Declarations: 1, Reads: 1, Writes: 1, Files: 2},
+			{Key: "pkg/util", Total: 2, Reads: 2, TestReferences: 1, Files: 2},
+		}, groups)
+	})
+
+	t.Run("Group by file", func(t *testing.T) {
+		groups := groupReferences(references, ReferenceGroupByFile)
+		assert.Equal(t, []results.ReferenceGroup{
+			{Key: "calculator.go", Total: 2, Declarations: 1, Reads: 1},
+			{Key: "main.go", Total: 1, Writes: 1},
+			{Key: "pkg/util/util.go", Total: 1, Reads: 1},
+			{Key: "pkg/util/util_test.go", Total: 1, Reads: 1, TestReferences: 1},
+		}, groups)
+	})
+
+	t.Run("No references", func(t *testing.T) {
+		groups := groupReferences(nil, ReferenceGroupByFile)
+		assert.Empty(t, groups)
+	})
+}
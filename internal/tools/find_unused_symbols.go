@@ -0,0 +1,332 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// DefaultUnusedSymbolsLimit is the default maximum number of unused symbols to return
+	DefaultUnusedSymbolsLimit = 50
+
+	// unusedDiagnosticsTimeout is how long to wait for gopls to publish the diagnostics of the files of a package
+	unusedDiagnosticsTimeout = 10 * time.Second
+)
+
+// FindUnusedSymbolsTool handles find unused symbols requests
+type FindUnusedSymbolsTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewFindUnusedSymbolsTool creates a new find unused symbols tool
+func NewFindUnusedSymbolsTool(client types.Client, config types.Config) *FindUnusedSymbolsTool {
+	return &FindUnusedSymbolsTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *FindUnusedSymbolsTool) GetTool() mcp.Tool {
+	tool := mcp.NewTool("find_unused_symbols",
+		mcp.WithDescription("Report dead code across the workspace: top-level functions, types, variables and constants that have no references outside their own declaration and test files, plus the unused parameters, functions and methods reported by the gopls unusedparams and unusedfunc analyzers. Methods are only reported by unusedfunc, which knows which methods are needed to satisfy interfaces. main and init functions are never reported, and public APIs can be excluded with an allowlist."),
+		mcp.WithString("pattern", mcp.Description("Import path pattern of the packages to check, where '...' matches any string (e.g. github.com/user/project/internal/...); all workspace packages are checked by default")),
+		mcp.WithBoolean("include_exported", mcp.Description("Whether to check exported symbols, which may be used outside the workspace (default: true)")),
+		mcp.WithArray("allowlist",
+			mcp.Description("Patterns of symbols to never report, in addition to the server's --unused-allowlist: import paths of packages (e.g. github.com/user/project/api/...), symbol paths (e.g. Calculator or Calculator.Add), or symbol paths qualified with their import path, where '...' matches any string"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithBoolean("include_diagnostics", mcp.Description("Whether to include the diagnostics of the gopls unusedparams and unusedfunc analyzers (default: true)")),
+		mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum number of unused symbols to return (default: %d)", DefaultUnusedSymbolsLimit))),
+		mcp.WithString("cursor", mcp.Description("Cursor from the next_cursor field of a previous response, to get the next page of results")),
+	)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *FindUnusedSymbolsTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	pattern := mcp.ParseString(req, "pattern", "")
+	includeExported := mcp.ParseBoolean(req, "include_exported", true)
+	includeDiagnostics := mcp.ParseBoolean(req, "include_diagnostics", true)
+	allowlist := append(append([]string(nil), t.config.UnusedAllowlist...), ParseStringArray(req, "allowlist")...)
+
+	limit := mcp.ParseInt(req, "limit", DefaultUnusedSymbolsLimit)
+	if limit <= 0 {
+		limit = DefaultUnusedSymbolsLimit
+	}
+
+	cursor := mcp.ParseString(req, "cursor", "")
+
+	slog.Debug("MCP tool called",
+		"tool", "find_unused_symbols",
+		"pattern", pattern,
+		"include_exported", includeExported,
+		"include_diagnostics", includeDiagnostics,
+		"allowlist", allowlist,
+		"limit", limit,
+		"cursor", cursor)
+
+	packagesResult, err := t.client.GetPackages(ctx, types.PackagesArgs{
+		Files:     []string{PathToUri(t.config.WorkspaceRoot, t.config.WorkspaceRoot)},
+		Recursive: true,
+	})
+	if err != nil {
+		slog.Error("Failed to get packages",
+			"tool", "find_unused_symbols",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list packages: %v", err)), nil
+	}
+
+	toolResult := results.FindUnusedSymbolsToolResult{
+		Arguments: results.FindUnusedSymbolsToolArgs{
+			Pattern:            pattern,
+			IncludeExported:    includeExported,
+			IncludeDiagnostics: includeDiagnostics,
+			Allowlist:          allowlist,
+			Limit:              limit,
+			Cursor:             cursor,
+		},
+		UnusedSymbols: make([]results.UnusedSymbol, 0),
+	}
+
+	var unused []results.UnusedSymbol
+	undiagnosedFiles := 0
+	for _, pkg := range packagesResult.Packages {
+		if pkg.ForTest != "" || !MatchPackagePattern(pattern, pkg.Path) {
+			continue
+		}
+		dir := packageDirectory(pkg, packagesResult.Module)
+		if dir == "" || IsVendorPath(dir) {
+			continue
+		}
+
+		packageUnused, checked, undiagnosed, err := t.findUnusedInPackage(ctx, pkg.Path, dir, allowlist, includeExported, includeDiagnostics)
+		if err != nil {
+			slog.Error("Failed to find unused symbols",
+				"tool", "find_unused_symbols",
+				"package", pkg.Path,
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to check package %s: %v", pkg.Path, err)), nil
+		}
+		toolResult.PackagesChecked++
+		toolResult.SymbolsChecked += checked
+		undiagnosedFiles += undiagnosed
+		unused = append(unused, packageUnused...)
+	}
+
+	sort.SliceStable(unused, func(i, j int) bool {
+		return unused[i].Location.Less(unused[j].Location)
+	})
+
+	// Apply pagination to prevent token overflow
+	query := fmt.Sprintf("%s:%t:%t:%s", pattern, includeExported, includeDiagnostics, strings.Join(allowlist, ","))
+	page, err := Paginate(unused, query, cursor, limit)
+	if err != nil {
+		slog.Debug("Invalid cursor",
+			"tool", "find_unused_symbols",
+			"cursor", cursor,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid cursor: %v", err)), nil
+	}
+	toolResult.Total = page.Total
+	toolResult.Truncated = page.Truncated
+	toolResult.NextCursor = page.NextCursor
+	toolResult.UnusedSymbols = append(toolResult.UnusedSymbols, page.Items...)
+
+	if toolResult.PackagesChecked == 0 {
+		toolResult.Message = "No workspace packages found."
+		if pattern != "" {
+			toolResult.Message = fmt.Sprintf("No workspace packages match %s.", pattern)
+		}
+	} else {
+		toolResult.Message = fmt.Sprintf("Found %d unused symbols in %d packages, checking the references of %d declarations.", toolResult.Total, toolResult.PackagesChecked, toolResult.SymbolsChecked) + PageMessage(page)
+		if undiagnosedFiles > 0 {
+			toolResult.Message += fmt.Sprintf(" The analyzer results may be incomplete, because gopls didn't publish the diagnostics of %d files in time.", undiagnosedFiles)
+		}
+	}
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "find_unused_symbols",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "find_unused_symbols",
+		"packages_checked", toolResult.PackagesChecked,
+		"symbols_checked", toolResult.SymbolsChecked,
+		"unused_count", toolResult.Total,
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// findUnusedInPackage finds the unused symbols of the non-test, non-generated files of a package, returning them with the number
+// of declarations whose references were checked and the number of files whose diagnostics gopls didn't publish in time
+func (t *FindUnusedSymbolsTool) findUnusedInPackage(ctx context.Context, importPath string, dir string, allowlist []string, includeExported bool, includeDiagnostics bool) ([]results.UnusedSymbol, int, int, error) {
+	allFiles, err := ListPackageFiles(dir, false)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	var files []string
+	for _, file := range allFiles {
+		if !IsGeneratedFile(file) {
+			files = append(files, file)
+		}
+	}
+	packageName, _ := PackageDoc(files)
+
+	var documentSymbols []packageDocumentSymbol
+	for _, filePath := range files {
+		uri := PathToUri(filePath, t.config.WorkspaceRoot)
+		fileSymbols, err := t.client.GetDocumentSymbols(ctx, uri)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("failed to get document symbols of %s: %w", filePath, err)
+		}
+		file, _ := GetDisplayPath(filePath, t.config.WorkspaceRoot)
+		for _, docSym := range fileSymbols {
+			documentSymbols = append(documentSymbols, packageDocumentSymbol{uri: uri, file: file, symbol: docSym})
+		}
+	}
+
+	var diagnostics []packageDiagnostic
+	undiagnosed := 0
+	if includeDiagnostics {
+		diagnostics, undiagnosed, err = t.unusedDiagnostics(ctx, files)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	var unused []results.UnusedSymbol
+	var unusedDecls []types.Location // Selection ranges of the unused declarations, to skip unusedfunc diagnostics that report them again
+	grouped := groupPackageSymbols(documentSymbols)
+	candidates := unusedCandidates(importPath, packageName, grouped, allowlist, includeExported)
+	for _, candidate := range candidates {
+		symbol := candidate.entry.symbol
+		references, err := t.client.FindReferences(ctx, candidate.entry.uri, symbol.SelectionRange.Start)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("failed to find references of %s: %w", symbol.Name, err)
+		}
+		uses, testUses := countUses(references, candidate.own)
+		slog.Debug("Checked references",
+			"tool", "find_unused_symbols",
+			"symbol", symbol.Name,
+			"uses", uses,
+			"test_uses", testUses)
+		if uses > 0 {
+			continue
+		}
+
+		location := results.SymbolLocation{
+			File:        candidate.entry.file,
+			DisplayLine: symbol.SelectionRange.Start.Line + 1,      // Convert LSP coordinates to display line
+			DisplayChar: symbol.SelectionRange.Start.Character + 1, // Convert LSP coordinates to display character
+		}
+		unused = append(unused, results.UnusedSymbol{
+			Name:           symbol.Name,
+			Kind:           results.NewSymbolKind(symbol.Kind),
+			Package:        importPath,
+			Location:       location,
+			Anchor:         location.ToAnchor().WithName(results.NewSymbolIdentifier(symbol.Name)),
+			Source:         results.UnusedSourceReferences,
+			TestReferences: testUses,
+		})
+		unusedDecls = append(unusedDecls, types.Location{URI: candidate.entry.uri, Range: symbol.SelectionRange})
+	}
+
+	skipped := append(unusedDecls, allowlistedMethods(importPath, grouped, allowlist)...)
+	for _, d := range diagnostics {
+		if d.source == results.UnusedSourceUnusedFunc && isOwnReference(types.Location{URI: d.uri, Range: d.diagnostic.Range}, skipped) {
+			continue
+		}
+		location := results.SymbolLocation{
+			File:        d.file,
+			DisplayLine: d.diagnostic.Range.Start.Line + 1,      // Convert LSP coordinates to display line
+			DisplayChar: d.diagnostic.Range.Start.Character + 1, // Convert LSP coordinates to display character
+		}
+		unused = append(unused, results.UnusedSymbol{
+			Package:  importPath,
+			Location: location,
+			Anchor:   location.ToAnchor(),
+			Source:   d.source,
+			Message:  d.diagnostic.Message,
+		})
+	}
+	return unused, len(candidates), undiagnosed, nil
+}
+
+// unusedDiagnostics returns the diagnostics of the unusedparams and unusedfunc analyzers for the files of a package, with the
+// number of files whose diagnostics gopls didn't publish in time. The files are opened, so that gopls diagnoses them and publishes
+// their diagnostics even if it hasn't diagnosed their package yet; the last published diagnostics are used for files that time out.
+func (t *FindUnusedSymbolsTool) unusedDiagnostics(ctx context.Context, files []string) ([]packageDiagnostic, int, error) {
+	var opened []string
+	defer func() {
+		for _, uri := range opened {
+			if err := t.client.CloseDocument(ctx, uri); err != nil {
+				slog.Error("Failed to close document", "uri", uri, "error", err)
+			}
+		}
+	}()
+	for _, filePath := range files {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		uri := PathToUri(filePath, t.config.WorkspaceRoot)
+		if err := t.client.OpenDocument(ctx, uri, string(content)); err != nil {
+			return nil, 0, fmt.Errorf("failed to open %s: %w", filePath, err)
+		}
+		opened = append(opened, uri)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, unusedDiagnosticsTimeout)
+	defer cancel()
+
+	var diagnostics []packageDiagnostic
+	undiagnosed := 0
+	for i, filePath := range files {
+		uri := opened[i]
+		fileDiagnostics, err := t.client.WaitForDiagnostics(waitCtx, uri)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, 0, ctx.Err()
+			}
+			slog.Debug("Diagnostics weren't published in time", "tool", "find_unused_symbols", "file", filePath)
+			undiagnosed++
+			if fileDiagnostics, err = t.client.GetDiagnostics(ctx, uri); err != nil {
+				return nil, 0, fmt.Errorf("failed to get diagnostics of %s: %w", filePath, err)
+			}
+		}
+
+		file, _ := GetDisplayPath(filePath, t.config.WorkspaceRoot)
+		for _, diagnostic := range fileDiagnostics {
+			if source, ok := unusedDiagnosticSource(diagnostic); ok {
+				diagnostics = append(diagnostics, packageDiagnostic{uri: uri, file: file, source: source, diagnostic: diagnostic})
+			}
+		}
+	}
+	return diagnostics, undiagnosed, nil
+}
+
+// packageDiagnostic represents a diagnostic of unused code in one of the files of a package
+type packageDiagnostic struct {
+	uri        string
+	file       string
+	source     results.UnusedSource
+	diagnostic types.Diagnostic
+}
@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// diagnosticsClient is a fake client that publishes the diagnostics of opened documents, except for documents in pending
+type diagnosticsClient struct {
+	types.Client
+	published map[string][]types.Diagnostic
+	cached    map[string][]types.Diagnostic
+	pending   map[string]bool
+	opened    []string
+	closed    []string
+}
+
+func (c *diagnosticsClient) OpenDocument(ctx context.Context, uri string, text string) error {
+	c.opened = append(c.opened, uri)
+	return nil
+}
+
+func (c *diagnosticsClient) CloseDocument(ctx context.Context, uri string) error {
+	c.closed = append(c.closed, uri)
+	return nil
+}
+
+func (c *diagnosticsClient) WaitForDiagnostics(ctx context.Context, uri string) ([]types.Diagnostic, error) {
+	if c.pending[uri] {
+		return nil, errors.New("diagnostics weren't published")
+	}
+	return c.published[uri], nil
+}
+
+func (c *diagnosticsClient) GetDiagnostics(ctx context.Context, uri string) ([]types.Diagnostic, error) {
+	return c.cached[uri], nil
+}
+
+func TestUnusedDiagnostics(t *testing.T) {
+	root := t.TempDir()
+	a, b := filepath.Join(root, "a.go"), filepath.Join(root, "b.go")
+	for _, path := range []string{a, b} {
+		assert.NoError(t, os.WriteFile(path, []byte("package p\n"), 0o644))
+	}
+	uriA, uriB := PathToUri(a, root), PathToUri(b, root)
+
+	client := &diagnosticsClient{
+		published: map[string][]types.Diagnostic{uriA: {{Source: "unusedfunc", Message: "function helper is unused"}, {Source: "compiler", Message: "other"}}},
+		cached:    map[string][]types.Diagnostic{uriB: {{Source: "unusedparams", Message: "unused parameter: x"}}},
+		pending:   map[string]bool{uriB: true},
+	}
+	tool := NewFindUnusedSymbolsTool(client, types.Config{WorkspaceRoot: root})
+
+	diagnostics, undiagnosed, err := tool.unusedDiagnostics(context.Background(), []string{a, b})
+	assert.NoError(t, err)
+
+	// Files whose diagnostics aren't published in time fall back to their last published diagnostics
+	assert.Equal(t, 1, undiagnosed)
+	if assert.Len(t, diagnostics, 2) {
+		assert.Equal(t, "function helper is unused", diagnostics[0].diagnostic.Message)
+		assert.Equal(t, "a.go", diagnostics[0].file)
+		assert.Equal(t, "unused parameter: x", diagnostics[1].diagnostic.Message)
+	}
+	assert.Equal(t, []string{uriA, uriB}, client.opened)
+	assert.Equal(t, []string{uriA, uriB}, client.closed)
+}
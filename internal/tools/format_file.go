@@ -0,0 +1,240 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// FormatFileTool handles format file requests
+type FormatFileTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewFormatFileTool creates a new format file tool
+func NewFormatFileTool(client types.Client, config types.Config) *FormatFileTool {
+	return &FormatFileTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *FormatFileTool) GetTool() mcp.Tool {
+	tool := mcp.NewTool("format_file",
+		mcp.WithDescription("Format Go files like gofmt and organize their imports like goimports (adding missing imports and removing unused ones), returning a unified diff of each changed file. Formats one file, a package, or all files changed since the last git commit."),
+		mcp.WithString("file_path", mcp.Description("Path to the Go file to format")),
+		mcp.WithString("package", mcp.Description("Import path of a package in the workspace module (e.g. github.com/user/project/pkg/util), or its directory (e.g. pkg/util), to format all of its files")),
+		mcp.WithBoolean("changed_files", mcp.Description("Whether to format all Go files in the workspace that changed since the last git commit, including untracked files")),
+		mcp.WithBoolean("organize_imports", mcp.Description("Whether to also organize imports (default: true)")),
+		mcp.WithBoolean("dry_run", mcp.Description("Whether to only preview the changes, without writing them to disk (default: false)")),
+	)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *FormatFileTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	filePath := mcp.ParseString(req, "file_path", "")
+	pkg := mcp.ParseString(req, "package", "")
+	changedFiles := mcp.ParseBoolean(req, "changed_files", false)
+	organizeImports := mcp.ParseBoolean(req, "organize_imports", true)
+	dryRun := mcp.ParseBoolean(req, "dry_run", false)
+
+	targets := 0
+	for _, set := range []bool{filePath != "", pkg != "", changedFiles} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		slog.Debug("MCP tool called with invalid target parameters", "tool", "format_file")
+		return mcp.NewToolResultError("exactly one of the file_path, package, or changed_files parameters is required"), nil
+	}
+
+	slog.Debug("MCP tool called",
+		"tool", "format_file",
+		"file_path", filePath,
+		"package", pkg,
+		"changed_files", changedFiles,
+		"organize_imports", organizeImports,
+		"dry_run", dryRun)
+
+	files, err := t.targetFiles(filePath, pkg, changedFiles)
+	if err != nil {
+		slog.Debug("Failed to find files to format",
+			"tool", "format_file",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to find files to format: %v", err)), nil
+	}
+
+	toolResult := results.FormatFileToolResult{
+		Arguments: results.FormatFileToolArgs{
+			FilePath:        filePath,
+			Package:         pkg,
+			ChangedFiles:    changedFiles,
+			OrganizeImports: organizeImports,
+			DryRun:          dryRun,
+		},
+		FileChanges: make([]results.FileChange, 0),
+	}
+
+	session := NewEditSession(t.config.WorkspaceRoot)
+	for _, file := range files {
+		if err := FormatFile(ctx, t.client, session, file, organizeImports, dryRun); err != nil {
+			slog.Debug("Failed to format file",
+				"tool", "format_file",
+				"file", file,
+				"error", err)
+			displayPath, _ := GetDisplayPath(file, t.config.WorkspaceRoot)
+			toolResult.Failures = append(toolResult.Failures, results.FileFailure{File: displayPath, Error: err.Error()})
+			continue
+		}
+		toolResult.FilesChecked++
+	}
+
+	changes := session.Changes()
+	if !dryRun && len(changes) > 0 {
+		if err := WriteEditSession(ctx, t.client, session); err != nil {
+			slog.Error("Failed to write formatted files",
+				"tool", "format_file",
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write formatted files: %v", err)), nil
+		}
+		toolResult.Applied = true
+	}
+	toolResult.FileChanges = append(toolResult.FileChanges, changes...)
+
+	switch {
+	case len(files) == 0:
+		toolResult.Message = "No Go files to format."
+	case len(changes) == 0:
+		toolResult.Message = fmt.Sprintf("All %d files are already formatted.", toolResult.FilesChecked)
+	case dryRun:
+		toolResult.Message = fmt.Sprintf("Formatting would change %d of %d files. No changes were written (dry run).", len(changes), toolResult.FilesChecked)
+	default:
+		toolResult.Message = fmt.Sprintf("Formatted %d of %d files.", len(changes), toolResult.FilesChecked)
+	}
+	if len(toolResult.Failures) > 0 {
+		toolResult.Message += fmt.Sprintf(" %d files couldn't be formatted.", len(toolResult.Failures))
+	}
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "format_file",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "format_file",
+		"files_checked", toolResult.FilesChecked,
+		"file_change_count", len(toolResult.FileChanges),
+		"applied", toolResult.Applied,
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// targetFiles returns the absolute paths of the files to format.
+// Generated files are skipped when formatting packages or changed files, since they'd be overwritten when regenerated.
+func (t *FormatFileTool) targetFiles(filePath string, pkg string, changedFiles bool) ([]string, error) {
+	if filePath != "" {
+		if !filepath.IsAbs(filePath) {
+			filePath = filepath.Join(t.config.WorkspaceRoot, filePath)
+		}
+		return []string{filePath}, nil
+	}
+
+	var files []string
+	if pkg != "" {
+		dir, err := ResolvePackageDir(t.config.WorkspaceRoot, pkg)
+		if err != nil {
+			return nil, err
+		}
+		if files, err = ListPackageFiles(dir, true); err != nil {
+			return nil, err
+		}
+	} else if changedFiles {
+		var err error
+		if files, err = ChangedGoFiles(t.config.WorkspaceRoot); err != nil {
+			return nil, err
+		}
+	}
+
+	var targets []string
+	for _, file := range files {
+		if !IsGeneratedFile(file) {
+			targets = append(targets, file)
+		}
+	}
+	return targets, nil
+}
+
+// FormatFile formats a file in an edit session, after organizing its imports if requested.
+// The edited content is opened in gopls while formatting, so that each step sees the result of the previous step.
+// Code actions that organize imports with a command are refused if dryRun is set.
+func FormatFile(ctx context.Context, client types.Client, session *EditSession, path string, organizeImports bool, dryRun bool) error {
+	content, err := session.Content(path)
+	if err != nil {
+		return err
+	}
+
+	uri := PathToUri(path, "")
+	if err := client.OpenDocument(ctx, uri, content); err != nil {
+		return err
+	}
+	defer func() {
+		if err := client.CloseDocument(ctx, uri); err != nil {
+			slog.Error("Failed to close document", "uri", uri, "error", err)
+		}
+	}()
+
+	if organizeImports {
+		actions, err := client.GetCodeActions(ctx, uri, types.Range{}, types.CodeActionContext{
+			Only: []types.CodeActionKind{types.CodeActionKindSourceOrganizeImports},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to organize imports: %w", err)
+		}
+
+		for _, action := range actions {
+			if action.Kind != types.CodeActionKindSourceOrganizeImports {
+				continue
+			}
+			edits, err := CodeActionEdits(ctx, client, action, dryRun)
+			if err != nil {
+				return fmt.Errorf("failed to organize imports: %w", err)
+			}
+			for _, edit := range edits {
+				if err := session.ApplyWorkspaceEdit(edit); err != nil {
+					return fmt.Errorf("failed to organize imports: %w", err)
+				}
+			}
+		}
+
+		organized, err := session.Content(path)
+		if err != nil {
+			return err
+		}
+		if organized != content {
+			if err := client.ChangeDocument(ctx, uri, 2, organized); err != nil {
+				return err
+			}
+		}
+	}
+
+	edits, err := client.FormatDocument(ctx, uri)
+	if err != nil {
+		return err
+	}
+	return session.EditFile(path, edits)
+}
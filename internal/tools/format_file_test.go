@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// formatClient is a fake client that organizes imports and formats open documents
+type formatClient struct {
+	types.Client
+	importEdits []types.TextEdit
+	formatEdits []types.TextEdit
+	calls       []string
+	texts       []string
+}
+
+func (c *formatClient) OpenDocument(ctx context.Context, uri string, text string) error {
+	c.calls = append(c.calls, "open")
+	c.texts = append(c.texts, text)
+	return nil
+}
+
+func (c *formatClient) ChangeDocument(ctx context.Context, uri string, version int, text string) error {
+	c.calls = append(c.calls, "change")
+	c.texts = append(c.texts, text)
+	return nil
+}
+
+func (c *formatClient) CloseDocument(ctx context.Context, uri string) error {
+	c.calls = append(c.calls, "close")
+	return nil
+}
+
+func (c *formatClient) GetCodeActions(ctx context.Context, uri string, rng types.Range, actionContext types.CodeActionContext) ([]types.CodeAction, error) {
+	c.calls = append(c.calls, "organize")
+	if len(c.importEdits) == 0 {
+		return nil, nil
+	}
+	edit := &types.WorkspaceEdit{Changes: map[string][]types.TextEdit{uri: c.importEdits}}
+	return []types.CodeAction{{Title: "Organize Imports", Kind: types.CodeActionKindSourceOrganizeImports, Edit: edit}}, nil
+}
+
+func (c *formatClient) FormatDocument(ctx context.Context, uri string) ([]types.TextEdit, error) {
+	c.calls = append(c.calls, "format")
+	return c.formatEdits, nil
+}
+
+func TestFormatFile(t *testing.T) {
+	content := "package main\n\nimport \"os\"\n\nfunc main() {\nfmt.Println()\n}\n"
+
+	tests := []struct {
+		name            string
+		organizeImports bool
+		importEdits     []types.TextEdit
+		formatEdits     []types.TextEdit
+		expectedCalls   []string
+		expectedContent string
+	}{
+		{
+			name:            "Organize imports and format",
+			organizeImports: true,
+			importEdits:     []types.TextEdit{textEdit(2, 8, 2, 10, "fmt")},
+			formatEdits:     []types.TextEdit{textEdit(5, 0, 5, 0, "\t")},
+			expectedCalls:   []string{"open", "organize", "change", "format", "close"},
+			expectedContent: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println()\n}\n",
+		},
+		{
+			name:            "Imports already organized",
+			organizeImports: true,
+			formatEdits:     []types.TextEdit{textEdit(5, 0, 5, 0, "\t")},
+			expectedCalls:   []string{"open", "organize", "format", "close"},
+			expectedContent: "package main\n\nimport \"os\"\n\nfunc main() {\n\tfmt.Println()\n}\n",
+		},
+		{
+			name:            "Format only",
+			organizeImports: false,
+			importEdits:     []types.TextEdit{textEdit(2, 8, 2, 10, "fmt")},
+			expectedCalls:   []string{"open", "format", "close"},
+			expectedContent: content,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			path := filepath.Join(root, "main.go")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+			client := &formatClient{importEdits: tt.importEdits, formatEdits: tt.formatEdits}
+			session := NewEditSession(root)
+			require.NoError(t, FormatFile(context.Background(), client, session, path, tt.organizeImports, false))
+
+			assert.Equal(t, tt.expectedCalls, client.calls)
+			assert.Equal(t, content, client.texts[0])
+
+			formatted, err := session.Content(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedContent, formatted)
+
+			// The session must not write to disk
+			onDisk, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, content, string(onDisk))
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// DefaultCompletionsLimit is the default maximum number of completion candidates to return
+	DefaultCompletionsLimit = 50
+)
+
+// GetCompletionsTool handles get completions requests
+type GetCompletionsTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewGetCompletionsTool creates a new get completions tool
+func NewGetCompletionsTool(client types.Client, config types.Config) *GetCompletionsTool {
+	return &GetCompletionsTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *GetCompletionsTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Get ranked completion candidates at a position in a Go file, like the fields and methods after 'x.', with their kinds, types or signatures, doc comments, and the imports they require. Use this to discover valid APIs instead of guessing them."),
+		},
+		positionToolOptions()...,
+	)
+	options = append(options,
+		mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum number of candidates to return (default: %d)", DefaultCompletionsLimit))),
+		mcp.WithString("cursor", mcp.Description("Cursor from the next_cursor field of a previous response, to get the next page of results")),
+	)
+	tool := mcp.NewTool("get_completions", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *GetCompletionsTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	position, err := parsePositionArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid position parameters", "tool", "get_completions", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	limit := mcp.ParseInt(req, "limit", DefaultCompletionsLimit)
+	if limit <= 0 {
+		limit = DefaultCompletionsLimit
+	}
+
+	cursor := mcp.ParseString(req, "cursor", "")
+
+	slog.Debug("MCP tool called",
+		"tool", "get_completions",
+		"symbol_anchor", position.anchor,
+		"file_path", position.filePath,
+		"line", position.line,
+		"column", position.column,
+		"limit", limit,
+		"cursor", cursor)
+
+	resolved, err := position.resolve(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve position",
+			"tool", "get_completions",
+			"symbol_anchor", position.anchor,
+			"error", err)
+		return mcp.NewToolResultError(position.resolveErrorMessage(err)), nil
+	}
+
+	list, err := t.client.GetCompletions(ctx, resolved.URI, resolved.Position)
+	if err != nil {
+		slog.Error("Failed to get completions",
+			"tool", "get_completions",
+			"uri", resolved.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get completions: %v", err)), nil
+	}
+
+	slog.Debug("Found completions from LSP",
+		"tool", "get_completions",
+		"uri", resolved.URI,
+		"completion_count", len(list.Items),
+		"incomplete", list.IsIncomplete)
+
+	toolResult := results.GetCompletionsToolResult{
+		Arguments: results.GetCompletionsToolArgs{
+			SymbolAnchor: position.anchor,
+			FilePath:     position.filePath,
+			Line:         position.line,
+			Column:       position.column,
+			Limit:        limit,
+			Cursor:       cursor,
+		},
+		Incomplete:  list.IsIncomplete,
+		Completions: make([]results.Completion, 0),
+	}
+
+	// Apply pagination to prevent token overflow
+	items := rankCompletionItems(list.Items)
+	query := fmt.Sprintf("%s:%d:%d", resolved.URI, resolved.Position.Line, resolved.Position.Character)
+	page, err := Paginate(items, query, cursor, limit)
+	if err != nil {
+		slog.Debug("Invalid cursor",
+			"tool", "get_completions",
+			"cursor", cursor,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid cursor: %v", err)), nil
+	}
+	toolResult.Total = page.Total
+	toolResult.Truncated = page.Truncated
+	toolResult.NextCursor = page.NextCursor
+
+	canResolve := true
+	for i, item := range page.Items {
+		// Only resolve candidates on the returned page, and stop if the language server doesn't support resolving
+		if canResolve && item.Documentation == "" {
+			resolvedItem, err := t.client.ResolveCompletionItem(ctx, item)
+			if err != nil {
+				slog.Debug("Failed to resolve completion item",
+					"tool", "get_completions",
+					"label", item.Label,
+					"error", err)
+				canResolve = false
+			} else {
+				item = mergeResolvedCompletionItem(item, *resolvedItem)
+			}
+		}
+		toolResult.Completions = append(toolResult.Completions, newCompletion(page.Offset+i+1, item))
+	}
+
+	if toolResult.Total == 0 {
+		toolResult.Message = "No completions found. " +
+			"The position should be right after a partial name or a selector like 'x.', in a file that has been saved."
+		slog.Debug("No completions found",
+			"tool", "get_completions",
+			"uri", resolved.URI)
+	} else {
+		toolResult.Message = fmt.Sprintf("Found %d completions.", toolResult.Total) + PageMessage(page)
+		if toolResult.Incomplete {
+			toolResult.Message += " The list is incomplete; type more of the name to get more precise candidates."
+		}
+	}
+	toolResult.Message += RelocationMessage(resolved)
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "get_completions",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "get_completions",
+		"completion_count", len(toolResult.Completions),
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// rankCompletionItems sorts completion items by their sort text, keeping the language server's order for ties
+func rankCompletionItems(items []types.CompletionItem) []types.CompletionItem {
+	ranked := append([]types.CompletionItem(nil), items...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return sortText(ranked[i]) < sortText(ranked[j])
+	})
+	return ranked
+}
+
+// sortText returns the text used to rank a completion item, which defaults to its label
+func sortText(item types.CompletionItem) string {
+	if item.SortText != "" {
+		return item.SortText
+	}
+	return item.Label
+}
+
+// mergeResolvedCompletionItem fills in the properties of a completion item that are computed lazily by completionItem/resolve
+func mergeResolvedCompletionItem(item types.CompletionItem, resolved types.CompletionItem) types.CompletionItem {
+	if resolved.Documentation != "" {
+		item.Documentation = resolved.Documentation
+	}
+	if resolved.Detail != "" {
+		item.Detail = resolved.Detail
+	}
+	if len(resolved.AdditionalTextEdits) > 0 {
+		item.AdditionalTextEdits = resolved.AdditionalTextEdits
+	}
+	return item
+}
+
+// newCompletion converts a completion item into a ranked completion candidate
+func newCompletion(rank int, item types.CompletionItem) results.Completion {
+	completion := results.Completion{
+		Rank:       rank,
+		Label:      item.Label,
+		Kind:       results.NewCompletionKind(item.Kind),
+		Detail:     item.Detail,
+		Doc:        item.Documentation,
+		InsertText: completionInsertText(item),
+		Deprecated: item.Deprecated,
+		Imports:    results.ImportPaths(item.AdditionalTextEdits),
+	}
+	for _, edit := range item.AdditionalTextEdits {
+		completion.AdditionalEdits = append(completion.AdditionalEdits, results.NewCompletionEdit(edit))
+	}
+	return completion
+}
+
+// completionInsertText returns the text inserted by a completion item, preferring its text edit over its insert text and label
+func completionInsertText(item types.CompletionItem) string {
+	switch {
+	case item.TextEdit != nil:
+		return item.TextEdit.NewText
+	case item.InsertText != "":
+		return item.InsertText
+	default:
+		return item.Label
+	}
+}
@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankCompletionItems(t *testing.T) {
+	items := []types.CompletionItem{
+		{Label: "Subtract", SortText: "00002"},
+		{Label: "Add", SortText: "00000"},
+		{Label: "Multiply"},
+		{Label: "Divide", SortText: "00001"},
+		{Label: "Clear", SortText: "00001"},
+	}
+
+	var labels []string
+	for _, item := range rankCompletionItems(items) {
+		labels = append(labels, item.Label)
+	}
+	assert.Equal(t, []string{"Add", "Divide", "Clear", "Subtract", "Multiply"}, labels)
+	assert.Equal(t, "Subtract", items[0].Label, "Input items should not be reordered")
+}
+
+func TestCompletionInsertText(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     types.CompletionItem
+		expected string
+	}{
+		{"Text edit", types.CompletionItem{Label: "Add", InsertText: "Add()", TextEdit: &types.TextEdit{NewText: "Add"}}, "Add"},
+		{"Insert text", types.CompletionItem{Label: "Add", InsertText: "Add()"}, "Add()"},
+		{"Label", types.CompletionItem{Label: "Add"}, "Add"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, completionInsertText(tt.item))
+		})
+	}
+}
+
+func TestMergeResolvedCompletionItem(t *testing.T) {
+	item := types.CompletionItem{Label: "Add", Detail: "func(x float64) float64"}
+	resolved := types.CompletionItem{
+		Label:               "Add",
+		Documentation:       "Add adds a number to the current value",
+		AdditionalTextEdits: []types.TextEdit{{NewText: "\"fmt\""}},
+	}
+
+	merged := mergeResolvedCompletionItem(item, resolved)
+	assert.Equal(t, "func(x float64) float64", merged.Detail, "Detail should be kept when not resolved")
+	assert.Equal(t, "Add adds a number to the current value", merged.Documentation)
+	assert.Equal(t, resolved.AdditionalTextEdits, merged.AdditionalTextEdits)
+}
+
+func TestNewCompletion(t *testing.T) {
+	item := types.CompletionItem{
+		Label:  "strings",
+		Kind:   9,
+		Detail: "\"strings\"",
+		TextEdit: &types.TextEdit{
+			Range:   types.Range{Start: types.Position{Line: 9, Character: 1}, End: types.Position{Line: 9, Character: 4}},
+			NewText: "strings",
+		},
+		AdditionalTextEdits: []types.TextEdit{{
+			Range:   types.Range{Start: types.Position{Line: 3, Character: 7}, End: types.Position{Line: 3, Character: 7}},
+			NewText: "\n\t\"strings\"",
+		}},
+	}
+
+	expected := results.Completion{
+		Rank:            2,
+		Label:           "strings",
+		Kind:            results.CompletionKindModule,
+		Detail:          "\"strings\"",
+		InsertText:      "strings",
+		Imports:         []string{"strings"},
+		AdditionalEdits: []results.CompletionEdit{{StartLine: 4, StartColumn: 8, EndLine: 4, EndColumn: 8, NewText: "\n\t\"strings\""}},
+	}
+	assert.Equal(t, expected, newCompletion(2, item))
+}
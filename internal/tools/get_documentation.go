@@ -0,0 +1,212 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// DefaultDocDeclarationsLimit is the default maximum number of package declarations to return
+	DefaultDocDeclarationsLimit = 100
+)
+
+// GetDocumentationTool handles get documentation requests
+type GetDocumentationTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewGetDocumentationTool creates a new get documentation tool
+func NewGetDocumentationTool(client types.Client, config types.Config) *GetDocumentationTool {
+	return &GetDocumentationTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *GetDocumentationTool) GetTool() mcp.Tool {
+	tool := mcp.NewTool("get_documentation",
+		mcp.WithDescription("Get the documentation of a Go package or symbol, like 'go doc', including the standard library and dependencies. Works offline."),
+		mcp.WithString(
+			"package",
+			mcp.Required(),
+			mcp.Description("Import path of the package (e.g. net/http, github.com/user/project/pkg/util), or its directory starting with ./, ../ or / for workspace packages (e.g. ./pkg/util)"),
+		),
+		mcp.WithString("symbol", mcp.Description("Symbol in the package, as 'NAME' or 'TYPE.MEMBER' (e.g. Client, Client.Do, Request.Header). Omit to document the package.")),
+		mcp.WithBoolean("include_unexported", mcp.Description("Whether to include unexported declarations (default: false)")),
+		mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum number of package declarations to return (default: %d)", DefaultDocDeclarationsLimit))),
+		mcp.WithString("cursor", mcp.Description("Cursor from the next_cursor field of a previous response, to get the next page of results")),
+	)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *GetDocumentationTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	pkg := mcp.ParseString(req, "package", "")
+	if pkg == "" {
+		slog.Debug("MCP tool called with missing package parameter", "tool", "get_documentation")
+		return mcp.NewToolResultError("package parameter is required"), nil
+	}
+
+	symbol := mcp.ParseString(req, "symbol", "")
+	includeUnexported := mcp.ParseBoolean(req, "include_unexported", false)
+
+	limit := mcp.ParseInt(req, "limit", DefaultDocDeclarationsLimit)
+	if limit <= 0 {
+		limit = DefaultDocDeclarationsLimit
+	}
+
+	cursor := mcp.ParseString(req, "cursor", "")
+
+	slog.Debug("MCP tool called",
+		"tool", "get_documentation",
+		"package", pkg,
+		"symbol", symbol,
+		"include_unexported", includeUnexported,
+		"limit", limit,
+		"cursor", cursor)
+
+	dir, err := FindPackageDir(t.config.WorkspaceRoot, pkg)
+	if err != nil {
+		slog.Debug("Failed to find package directory",
+			"tool", "get_documentation",
+			"package", pkg,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid package: %v", err)), nil
+	}
+	importPath := documentationImportPath(pkg, dir)
+
+	// Symbols are documented in the package that gopls resolves them to, including the file declaring them
+	var definitionFiles []string
+	if symbol != "" {
+		definitionFile, err := t.resolveSymbolDefinition(ctx, importPath, symbol)
+		if err != nil {
+			slog.Debug("Failed to resolve symbol",
+				"tool", "get_documentation",
+				"package", pkg,
+				"symbol", symbol,
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid symbol: %v", err)), nil
+		}
+		if definitionDir := filepath.Dir(definitionFile); definitionDir != dir {
+			dir, importPath = definitionDir, documentationImportPath(importPath, definitionDir)
+		}
+		definitionFiles = append(definitionFiles, definitionFile)
+	}
+
+	documentation, err := LoadPackageDocumentation(dir, importPath, t.config.WorkspaceRoot, includeUnexported, definitionFiles...)
+	if err != nil {
+		slog.Error("Failed to load package documentation",
+			"tool", "get_documentation",
+			"directory", dir,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load documentation: %v", err)), nil
+	}
+
+	toolResult := results.GetDocumentationToolResult{
+		Arguments: results.GetDocumentationToolArgs{
+			Package:           pkg,
+			Symbol:            symbol,
+			IncludeUnexported: includeUnexported,
+			Limit:             limit,
+			Cursor:            cursor,
+		},
+		ImportPath:  importPath,
+		PackageName: documentation.Package.Name,
+		Synopsis:    documentation.Package.Synopsis(documentation.Package.Doc),
+	}
+	toolResult.Directory, toolResult.External = GetDisplayPath(dir, t.config.WorkspaceRoot)
+
+	if symbol != "" {
+		toolResult.Symbol, err = documentation.Symbol(symbol)
+		if err != nil {
+			slog.Debug("Symbol not found in package documentation",
+				"tool", "get_documentation",
+				"package", pkg,
+				"symbol", symbol,
+				"error", err)
+			message := fmt.Sprintf("Symbol not found: %v.", err)
+			if !includeUnexported {
+				message += " Set include_unexported to document unexported symbols."
+			}
+			return mcp.NewToolResultError(message), nil
+		}
+		toolResult.Message = fmt.Sprintf("Found documentation for %s.%s.", documentation.Package.Name, symbol)
+	} else {
+		toolResult.Doc = strings.TrimSpace(documentation.Package.Doc)
+		toolResult.Examples = documentation.Examples()
+
+		// Apply pagination to prevent token overflow
+		query := fmt.Sprintf("%s:%t", dir, includeUnexported)
+		page, err := Paginate(documentation.Declarations(), query, cursor, limit)
+		if err != nil {
+			slog.Debug("Invalid cursor",
+				"tool", "get_documentation",
+				"cursor", cursor,
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid cursor: %v", err)), nil
+		}
+		toolResult.Total = page.Total
+		toolResult.Truncated = page.Truncated
+		toolResult.NextCursor = page.NextCursor
+		toolResult.Declarations = page.Items
+
+		toolResult.Message = fmt.Sprintf("Found documentation for package %s with %d declarations.", documentation.Package.Name, toolResult.Total) +
+			PageMessage(page) +
+			" Pass a symbol to get its full documentation, methods, and examples."
+	}
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "get_documentation",
+			"package", pkg,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "get_documentation",
+		"package", pkg,
+		"symbol", symbol,
+		"declaration_count", len(toolResult.Declarations),
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// resolveSymbolDefinition resolves a symbol of a package like a semantic anchor, and returns the file of its definition from gopls
+func (t *GetDocumentationTool) resolveSymbolDefinition(ctx context.Context, importPath string, symbol string) (string, error) {
+	resolved, err := ResolveSymbolAnchor(ctx, t.client, t.config.WorkspaceRoot, results.NewSemanticSymbolAnchor(importPath, symbol))
+	if err != nil {
+		return "", err
+	}
+
+	locations, err := t.client.GoToDefinition(ctx, resolved.URI, resolved.Position)
+	if err != nil {
+		return "", fmt.Errorf("failed to find the definition of %s: %w", symbol, err)
+	}
+	if len(locations) == 0 {
+		return UriToPath(resolved.URI), nil
+	}
+	return UriToPath(locations[0].URI), nil
+}
+
+// documentationImportPath returns the import path of the package in a directory, which is known for workspace packages,
+// or else the import path that the package was found with
+func documentationImportPath(importPath string, dir string) string {
+	if workspaceImportPath := PackageImportPath(filepath.Join(dir, "doc.go")); workspaceImportPath != "" {
+		return workspaceImportPath
+	}
+	return importPath
+}
@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GetSignatureHelpTool handles get signature help requests
+type GetSignatureHelpTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewGetSignatureHelpTool creates a new get signature help tool
+func NewGetSignatureHelpTool(client types.Client, config types.Config) *GetSignatureHelpTool {
+	return &GetSignatureHelpTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *GetSignatureHelpTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Get the signatures of the function or method being called at a position inside a call expression, with parameter names and types, the active parameter, and doc comments. Also accepts the anchor of a function or method."),
+		},
+		positionToolOptions()...,
+	)
+	tool := mcp.NewTool("get_signature_help", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *GetSignatureHelpTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	position, err := parsePositionArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid position parameters", "tool", "get_signature_help", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	slog.Debug("MCP tool called",
+		"tool", "get_signature_help",
+		"symbol_anchor", position.anchor,
+		"file_path", position.filePath,
+		"line", position.line,
+		"column", position.column)
+
+	resolved, err := position.resolve(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve position",
+			"tool", "get_signature_help",
+			"symbol_anchor", position.anchor,
+			"error", err)
+		return mcp.NewToolResultError(position.resolveErrorMessage(err)), nil
+	}
+
+	help, err := t.client.GetSignatureHelp(ctx, resolved.URI, resolved.Position)
+	if err != nil {
+		slog.Error("Failed to get signature help",
+			"tool", "get_signature_help",
+			"uri", resolved.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get signature help: %v", err)), nil
+	}
+
+	slog.Debug("Found signatures from LSP",
+		"tool", "get_signature_help",
+		"uri", resolved.URI,
+		"signature_count", len(help.Signatures))
+
+	toolResult := results.GetSignatureHelpToolResult{
+		Arguments: results.GetSignatureHelpToolArgs{
+			SymbolAnchor: position.anchor,
+			FilePath:     position.filePath,
+			Line:         position.line,
+			Column:       position.column,
+		},
+		Signatures: make([]results.CallSignature, 0, len(help.Signatures)),
+	}
+
+	if len(help.Signatures) > 0 {
+		toolResult.Source = results.SignatureSourceCall
+		toolResult.ActiveSignature = help.ActiveSignature
+		for i, signatureInfo := range help.Signatures {
+			var parameters []results.CallParameter
+			if len(signatureInfo.Parameters) > 0 {
+				parameters = make([]results.CallParameter, 0, len(signatureInfo.Parameters))
+				for _, parameterInfo := range signatureInfo.Parameters {
+					parameters = append(parameters, results.NewCallParameter(parameterInfo.Label, parameterInfo.Documentation))
+				}
+			}
+
+			signature := results.NewCallSignature(signatureInfo.Label, signatureInfo.Documentation, parameters)
+			if i == help.ActiveSignature {
+				signature.ActiveParameter = help.ActiveParameter
+				if signatureInfo.ActiveParameter != nil {
+					signature.ActiveParameter = signatureInfo.ActiveParameter
+				}
+			}
+			toolResult.Signatures = append(toolResult.Signatures, signature)
+		}
+	} else if hoverInfo := GetHoverInfo(ctx, t.client, resolved.URI, resolved.Position); hoverInfo != nil && strings.HasPrefix(hoverInfo.Signature, "func ") {
+		// Outside of calls, describe the function or method at the position instead
+		toolResult.Source = results.SignatureSourceHover
+		toolResult.Signatures = append(toolResult.Signatures, results.NewCallSignature(hoverInfo.Signature, hoverInfo.Doc, nil))
+	}
+
+	switch {
+	case len(toolResult.Signatures) == 0:
+		toolResult.Message = "No signature found. " +
+			"The position should be inside the parentheses of a call expression, or on the name of a function or method."
+		slog.Debug("No signatures found",
+			"tool", "get_signature_help",
+			"uri", resolved.URI)
+	case toolResult.Source == results.SignatureSourceHover:
+		toolResult.Message = "The position isn't inside a call expression, so the signature of the function or method at the position was returned instead."
+	default:
+		toolResult.Message = fmt.Sprintf("Found %d signatures.", len(toolResult.Signatures))
+	}
+	toolResult.Message += RelocationMessage(resolved)
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "get_signature_help",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "get_signature_help",
+		"signature_count", len(toolResult.Signatures),
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ChangedGoFiles returns the absolute paths of the Go files in a directory that have changed since the last commit,
+// including staged, unstaged, and untracked (but not ignored) files
+func ChangedGoFiles(dir string) ([]string, error) {
+	modified, err := gitFiles(dir, "diff", "--name-only", "--relative", "--diff-filter=ACMR", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := gitFiles(dir, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, file := range append(modified, untracked...) {
+		if !strings.HasSuffix(file, ".go") || seen[file] {
+			continue
+		}
+		seen[file] = true
+
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// gitFiles runs a git command in a directory that lists files, one per line
+func gitFiles(dir string, args ...string) ([]string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
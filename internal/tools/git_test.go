@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runGit runs a git command in a directory, failing the test if it fails
+func runGit(t *testing.T, dir string, args ...string) {
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, "git %v: %s", args, output)
+}
+
+func TestChangedGoFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	writeFile := func(name string, content string) {
+		path := filepath.Join(root, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	writeFile("main.go", "package main\n")
+	writeFile("util.go", "package main\n")
+	writeFile("deleted.go", "package main\n")
+	writeFile("README.md", "# Project\n")
+	writeFile(".gitignore", "ignored.go\n")
+	runGit(t, root, "init", "-q")
+	runGit(t, root, "add", ".")
+	runGit(t, root, "commit", "-q", "-m", "Initial commit")
+
+	writeFile("main.go", "package main\n\nfunc main() {}\n") // Unstaged change
+	writeFile("pkg/new.go", "package pkg\n")                 // Untracked file
+	writeFile("ignored.go", "package main\n")                // Ignored file
+	writeFile("README.md", "# Changed\n")                    // Not a Go file
+	assert.NoError(t, os.Remove(filepath.Join(root, "deleted.go")))
+
+	files, err := ChangedGoFiles(root)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(root, "main.go"), filepath.Join(root, "pkg", "new.go")}, files)
+
+	// Only changes in the directory are included
+	files, err = ChangedGoFiles(filepath.Join(root, "pkg"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(root, "pkg", "new.go")}, files)
+
+	_, err = ChangedGoFiles(t.TempDir())
+	assert.Error(t, err, "Directories outside of a git repository should be an error")
+}
@@ -0,0 +1,129 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GoToDefinitionByPositionTool handles go to definition by position requests
+type GoToDefinitionByPositionTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewGoToDefinitionByPositionTool creates a new go to definition by position tool
+func NewGoToDefinitionByPositionTool(client types.Client, config types.Config) *GoToDefinitionByPositionTool {
+	return &GoToDefinitionByPositionTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *GoToDefinitionByPositionTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Go to the definition of the identifier at a position in a Go file, including definitions in dependencies and the standard library"),
+		},
+		positionToolOptions()...,
+	)
+	options = append(options, mcp.WithBoolean("include_hover", mcp.Description("Whether to include hover information for definitions (default: false)")))
+	tool := mcp.NewTool("go_to_definition_by_position", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *GoToDefinitionByPositionTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	position, err := parsePositionArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid position parameters", "tool", "go_to_definition_by_position", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	includeHover := mcp.ParseBoolean(req, "include_hover", false)
+
+	slog.Debug("MCP tool called",
+		"tool", "go_to_definition_by_position",
+		"symbol_anchor", position.anchor,
+		"file_path", position.filePath,
+		"line", position.line,
+		"column", position.column,
+		"include_hover", includeHover)
+
+	resolved, err := position.resolve(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve position",
+			"tool", "go_to_definition_by_position",
+			"symbol_anchor", position.anchor,
+			"error", err)
+		return mcp.NewToolResultError(position.resolveErrorMessage(err)), nil
+	}
+
+	defLocations, err := t.client.GoToDefinition(ctx, resolved.URI, resolved.Position)
+	if err != nil {
+		slog.Error("Failed to go to definition",
+			"tool", "go_to_definition_by_position",
+			"uri", resolved.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to go to definition: %v", err)), nil
+	}
+
+	slog.Debug("Found definitions from LSP",
+		"tool", "go_to_definition_by_position",
+		"uri", resolved.URI,
+		"definition_count", len(defLocations))
+
+	toolResult := results.GoToDefinitionByPositionToolResult{
+		Arguments: results.GoToDefinitionByPositionToolArgs{
+			SymbolAnchor: position.anchor,
+			FilePath:     position.filePath,
+			Line:         position.line,
+			Column:       position.column,
+			IncludeHover: includeHover,
+		},
+		Definitions: make([]results.PositionDefinition, 0, len(defLocations)),
+	}
+
+	for _, loc := range defLocations {
+		toolResult.Definitions = append(toolResult.Definitions, NewPositionDefinition(ctx, t.client, t.config.WorkspaceRoot, loc, includeHover))
+	}
+
+	if len(toolResult.Definitions) == 0 {
+		toolResult.Message = "No definition found. " +
+			"This could mean that the position isn't on an identifier, or that the identifier is a built-in or a package name."
+		slog.Debug("No definitions found",
+			"tool", "go_to_definition_by_position",
+			"uri", resolved.URI)
+	} else {
+		toolResult.Message = fmt.Sprintf("Found %d definitions.", len(toolResult.Definitions))
+		for _, definition := range toolResult.Definitions {
+			if definition.External {
+				toolResult.Message += " Some definitions are outside the workspace, so their file paths are absolute."
+				break
+			}
+		}
+	}
+	toolResult.Message += RelocationMessage(resolved)
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "go_to_definition_by_position",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "go_to_definition_by_position",
+		"definition_count", len(toolResult.Definitions),
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
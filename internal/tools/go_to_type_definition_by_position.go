@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GoToTypeDefinitionByPositionTool handles go to type definition by position requests
+type GoToTypeDefinitionByPositionTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewGoToTypeDefinitionByPositionTool creates a new go to type definition by position tool
+func NewGoToTypeDefinitionByPositionTool(client types.Client, config types.Config) *GoToTypeDefinitionByPositionTool {
+	return &GoToTypeDefinitionByPositionTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *GoToTypeDefinitionByPositionTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Go to the declaration of the type of a variable, field, or expression at a position in a Go file. Pointers, slices, maps, channels, and generic instantiations are unwrapped to the named types they use."),
+		},
+		positionToolOptions()...,
+	)
+	tool := mcp.NewTool("go_to_type_definition_by_position", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *GoToTypeDefinitionByPositionTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	position, err := parsePositionArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid position parameters", "tool", "go_to_type_definition_by_position", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	slog.Debug("MCP tool called",
+		"tool", "go_to_type_definition_by_position",
+		"symbol_anchor", position.anchor,
+		"file_path", position.filePath,
+		"line", position.line,
+		"column", position.column)
+
+	resolved, err := position.resolve(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve position",
+			"tool", "go_to_type_definition_by_position",
+			"symbol_anchor", position.anchor,
+			"error", err)
+		return mcp.NewToolResultError(position.resolveErrorMessage(err)), nil
+	}
+
+	typeLocations, err := t.client.GoToTypeDefinition(ctx, resolved.URI, resolved.Position)
+	if err != nil {
+		slog.Error("Failed to go to type definition",
+			"tool", "go_to_type_definition_by_position",
+			"uri", resolved.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to go to type definition: %v", err)), nil
+	}
+
+	slog.Debug("Found type definitions from LSP",
+		"tool", "go_to_type_definition_by_position",
+		"uri", resolved.URI,
+		"type_definition_count", len(typeLocations))
+
+	toolResult := results.GoToTypeDefinitionByPositionToolResult{
+		Arguments: results.GoToTypeDefinitionByPositionToolArgs{
+			SymbolAnchor: position.anchor,
+			FilePath:     position.filePath,
+			Line:         position.line,
+			Column:       position.column,
+		},
+		TypeDefinitions: make([]results.TypeDefinition, 0, len(typeLocations)),
+	}
+
+	// The hover at the position describes the type expression, which the type definitions don't
+	if hoverInfo := GetHoverInfo(ctx, t.client, resolved.URI, resolved.Position); hoverInfo != nil {
+		toolResult.ExpressionType = results.NewExpressionType(hoverInfo.Signature)
+	}
+
+	for _, loc := range typeLocations {
+		typeDefinition := results.TypeDefinition{
+			PositionDefinition: NewPositionDefinition(ctx, t.client, t.config.WorkspaceRoot, loc, false),
+		}
+		if hoverInfo := GetHoverInfo(ctx, t.client, loc.URI, loc.Range.Start); hoverInfo != nil {
+			typeDefinition.Signature = hoverInfo.Signature
+		}
+		toolResult.TypeDefinitions = append(toolResult.TypeDefinitions, typeDefinition)
+	}
+
+	if len(toolResult.TypeDefinitions) == 0 {
+		toolResult.Message = "No type definition found. " +
+			"This could mean that the position isn't on a typed identifier, or that its type is a built-in like int or error."
+		slog.Debug("No type definitions found",
+			"tool", "go_to_type_definition_by_position",
+			"uri", resolved.URI)
+	} else {
+		toolResult.Message = fmt.Sprintf("Found %d type definitions.", len(toolResult.TypeDefinitions))
+		for _, typeDefinition := range toolResult.TypeDefinitions {
+			if typeDefinition.External {
+				toolResult.Message += " Some type definitions are outside the workspace, so their file paths are absolute."
+				break
+			}
+		}
+	}
+	toolResult.Message += RelocationMessage(resolved)
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "go_to_type_definition_by_position",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "go_to_type_definition_by_position",
+		"type_definition_count", len(toolResult.TypeDefinitions),
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
@@ -0,0 +1,329 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ImplementInterfaceTool handles implement interface requests
+type ImplementInterfaceTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewImplementInterfaceTool creates a new implement interface tool
+func NewImplementInterfaceTool(client types.Client, config types.Config) *ImplementInterfaceTool {
+	return &ImplementInterfaceTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *ImplementInterfaceTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Generate stubs for the methods of an interface that a concrete type is missing, with the exact signatures gopls derives from the interface, e.g. to make a type satisfy io.Reader or sort.Interface on the first try. The position must be on the name of the type. The stubs are placed after the existing methods of the type in the file declaring it, and their bodies panic until they are implemented. Returns the generated code, the LSP workspace edit and a unified diff of each changed file; use dry_run to preview the changes without writing them."),
+		},
+		positionToolOptions()...,
+	)
+	options = append(options,
+		mcp.WithString("interface", mcp.Required(), mcp.Description("Interface to implement: a qualified name like io.Reader or github.com/user/project/shapes.Shape, the name of an interface in the type's package, or a symbol anchor (e.g. go://io#Reader)")),
+		mcp.WithBoolean("pointer_receiver", mcp.Description("Whether the generated methods have pointer receivers (default: true)")),
+		mcp.WithBoolean("keep_assertion", mcp.Description("Whether to keep a compile-time assertion that the type implements the interface, like 'var _ io.Reader = (*Buffer)(nil)', at the end of the file (default: false)")),
+		mcp.WithBoolean("dry_run", mcp.Description("Whether to only preview the changes, without writing them to disk (default: false)")),
+	)
+	tool := mcp.NewTool("implement_interface", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *ImplementInterfaceTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	interfaceName := strings.TrimSpace(mcp.ParseString(req, "interface", ""))
+	if interfaceName == "" {
+		slog.Debug("MCP tool called with missing interface parameter", "tool", "implement_interface")
+		return mcp.NewToolResultError("interface parameter is required"), nil
+	}
+
+	position, err := parsePositionArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid position parameters", "tool", "implement_interface", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	pointerReceiver := mcp.ParseBoolean(req, "pointer_receiver", true)
+	keepAssertion := mcp.ParseBoolean(req, "keep_assertion", false)
+	dryRun := mcp.ParseBoolean(req, "dry_run", false)
+
+	slog.Debug("MCP tool called",
+		"tool", "implement_interface",
+		"symbol_anchor", position.anchor,
+		"file_path", position.filePath,
+		"line", position.line,
+		"column", position.column,
+		"interface", interfaceName,
+		"pointer_receiver", pointerReceiver,
+		"keep_assertion", keepAssertion,
+		"dry_run", dryRun)
+
+	resolved, err := position.resolveForEdit(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve position",
+			"tool", "implement_interface",
+			"symbol_anchor", position.anchor,
+			"error", err)
+		return mcp.NewToolResultError(position.resolveErrorMessage(err)), nil
+	}
+
+	definitions, err := t.client.GoToDefinition(ctx, resolved.URI, resolved.Position)
+	if err != nil || len(definitions) == 0 {
+		slog.Debug("Failed to find declaration",
+			"tool", "implement_interface",
+			"uri", resolved.URI,
+			"error", err)
+		return mcp.NewToolResultError("No declaration found. The position should be on the name of a type."), nil
+	}
+	definition := definitions[0]
+	path := UriToPath(definition.URI)
+	if IsReadOnlyFile(path) {
+		return mcp.NewToolResultError(fmt.Sprintf("The type is declared in %s, which is read-only.", path)), nil
+	}
+
+	decls, err := FindSymbolDeclarations(ctx, t.client, definition, false)
+	if err != nil {
+		slog.Debug("Failed to find type declaration",
+			"tool", "implement_interface",
+			"uri", definition.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("%v; the position should be on the name of a top-level type", err)), nil
+	}
+	if declarationKeyword(decls.Kind) != "type" || decls.Kind == results.SymbolKindInterface {
+		return mcp.NewToolResultError(fmt.Sprintf("%s is a %s, not a concrete type.", decls.Name, decls.Kind)), nil
+	}
+
+	typeImportPath := PackageImportPath(path)
+	iface, err := t.resolveInterface(ctx, interfaceName, typeImportPath)
+	if err != nil {
+		slog.Debug("Failed to resolve interface",
+			"tool", "implement_interface",
+			"interface", interfaceName,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid interface: %v", err)), nil
+	}
+
+	session := NewEditSession(t.config.WorkspaceRoot)
+	stubs, err := t.generateStubs(ctx, session, path, typeImportPath, decls.Name, iface, pointerReceiver, keepAssertion, dryRun)
+	if err != nil {
+		slog.Debug("Failed to generate method stubs",
+			"tool", "implement_interface",
+			"type", decls.Name,
+			"interface", iface.String(),
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to generate the methods of %s for %s: %v", iface, decls.Name, err)), nil
+	}
+
+	changes := session.Changes()
+	workspaceEdit := session.WorkspaceEdit()
+	if !dryRun && len(changes) > 0 {
+		if err := WriteEditSession(ctx, t.client, session); err != nil {
+			slog.Error("Failed to write method stubs",
+				"tool", "implement_interface",
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write the method stubs: %v", err)), nil
+		}
+	}
+
+	toolResult := results.ImplementInterfaceToolResult{
+		Arguments: results.ImplementInterfaceToolArgs{
+			SymbolAnchor:    position.anchor,
+			FilePath:        position.filePath,
+			Line:            position.line,
+			Column:          position.column,
+			Interface:       interfaceName,
+			PointerReceiver: pointerReceiver,
+			KeepAssertion:   keepAssertion,
+			DryRun:          dryRun,
+		},
+		Type:          decls.Name,
+		Interface:     iface.String(),
+		Methods:       stubs.methods,
+		Code:          stubs.code,
+		Applied:       !dryRun && len(changes) > 0,
+		WorkspaceEdit: workspaceEdit,
+		FileChanges:   make([]results.FileChange, 0, len(changes)),
+	}
+	if keepAssertion {
+		toolResult.Assertion = stubs.assertion
+	}
+	toolResult.FileChanges = append(toolResult.FileChanges, changes...)
+
+	if dryRun {
+		toolResult.Message = fmt.Sprintf("Implementing %s would add %d methods to %s. No changes were written (dry run).", iface, len(stubs.methods), decls.Name)
+	} else {
+		toolResult.Message = fmt.Sprintf("Added %d methods to %s to implement %s.", len(stubs.methods), decls.Name, iface)
+	}
+	toolResult.Message += " The generated methods panic until they are implemented."
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "implement_interface",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "implement_interface",
+		"type", toolResult.Type,
+		"interface", toolResult.Interface,
+		"method_count", len(toolResult.Methods),
+		"file_change_count", len(toolResult.FileChanges),
+		"applied", toolResult.Applied,
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// resolveInterface resolves an interface given by a symbol anchor or a qualified name, checking that it's declared as an interface
+func (t *ImplementInterfaceTool) resolveInterface(ctx context.Context, name string, typeImportPath string) (InterfaceReference, error) {
+	anchor := results.SymbolAnchor(name)
+	if !strings.HasPrefix(name, "go://") {
+		importPath, interfaceName, err := ParseInterfaceName(name, typeImportPath)
+		if err != nil {
+			return InterfaceReference{}, err
+		}
+		if importPath == "" {
+			return InterfaceReference{Name: interfaceName}, nil
+		}
+		anchor = results.NewSemanticSymbolAnchor(importPath, interfaceName)
+	}
+
+	resolved, err := ResolveSymbolAnchor(ctx, t.client, t.config.WorkspaceRoot, anchor)
+	if err != nil {
+		return InterfaceReference{}, fmt.Errorf("failed to find %s: %w", name, err)
+	}
+	definitions, err := t.client.GoToDefinition(ctx, resolved.URI, resolved.Position)
+	if err != nil || len(definitions) == 0 {
+		return InterfaceReference{}, fmt.Errorf("no declaration of %s found", name)
+	}
+	decls, err := FindSymbolDeclarations(ctx, t.client, definitions[0], false)
+	if err != nil {
+		return InterfaceReference{}, fmt.Errorf("%s isn't a top-level declaration: %w", name, err)
+	}
+	if decls.Kind != results.SymbolKindInterface {
+		return InterfaceReference{}, fmt.Errorf("%s is a %s, not an interface", decls.Name, decls.Kind)
+	}
+
+	path := UriToPath(definitions[0].URI)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return InterfaceReference{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	info, err := parseGoFileInfo(path, string(content))
+	if err != nil {
+		return InterfaceReference{}, err
+	}
+	importPath := PackageImportPath(path)
+	if importPath == "" {
+		return InterfaceReference{}, fmt.Errorf("failed to determine the import path of %s", path)
+	}
+	return InterfaceReference{ImportPath: importPath, PackageName: info.packageName, Name: decls.Name}, nil
+}
+
+// generatedStubs represents the method stubs generated for a type
+type generatedStubs struct {
+	methods   []string // Names of the generated methods
+	code      string   // Code of the generated methods
+	assertion string   // Assertion that the type implements the interface
+}
+
+// generateStubs generates the missing methods of an interface for a type in an edit session. An assertion that the type
+// implements the interface is added to the file declaring the type, which is opened in gopls with the assertion, so that
+// its quick fix declares the missing methods. The methods are then moved after the existing methods of the type, and the
+// assertion is removed again, along with its import, unless keepAssertion is set.
+func (t *ImplementInterfaceTool) generateStubs(ctx context.Context, session *EditSession, path string, typeImportPath string, typeName string, iface InterfaceReference, pointer bool, keepAssertion bool, dryRun bool) (*generatedStubs, error) {
+	content, err := session.Content(path)
+	if err != nil {
+		return nil, err
+	}
+	assertionEdits, assertion, err := interfaceAssertionEdits(path, content, typeImportPath, typeName, iface, pointer)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.EditFile(path, assertionEdits); err != nil {
+		return nil, err
+	}
+	withAssertion, err := session.Content(path)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := PathToUri(path, "")
+	if err := t.client.OpenDocument(ctx, uri, withAssertion); err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() {
+		if err := t.client.CloseDocument(ctx, uri); err != nil {
+			slog.Error("Failed to close document", "uri", uri, "error", err)
+		}
+	}()
+
+	offset := strings.LastIndex(withAssertion, assertion)
+	rng := types.Range{Start: offsetPosition(withAssertion, offset), End: offsetPosition(withAssertion, offset+len(assertion))}
+	actions, _, err := GetCodeActions(ctx, t.client, uri, rng, []types.CodeActionKind{types.CodeActionKindQuickFix})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code actions: %w", err)
+	}
+	action, err := findStubCodeAction(actions)
+	if err != nil {
+		return nil, err
+	}
+	edits, err := CodeActionEdits(ctx, t.client, *action, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	for _, edit := range edits {
+		if err := session.ApplyWorkspaceEdit(edit); err != nil {
+			return nil, err
+		}
+	}
+
+	stubbed, err := session.Content(path)
+	if err != nil {
+		return nil, err
+	}
+	placed, methods, code, err := PlaceStubMethods(path, withAssertion, stubbed, typeName)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("gopls didn't declare any methods of %s in %s", typeName, path)
+	}
+
+	if !keepAssertion {
+		removed, ok := removeInterfaceAssertion(placed, assertion)
+		if !ok {
+			return nil, fmt.Errorf("the assertion %q was changed by the generated code", assertion)
+		}
+		importEdits, _, err := UnusedImportEdits(path, placed, removed)
+		if err != nil {
+			return nil, err
+		}
+		if placed, err = ApplyTextEdits(removed, importEdits); err != nil {
+			return nil, err
+		}
+	}
+	if err := session.EditFile(path, lineTextEdits(stubbed, placed)); err != nil {
+		return nil, err
+	}
+	return &generatedStubs{methods: methods, code: code, assertion: assertion}, nil
+}
@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+// UnusedImportEdits returns the text edits that remove the imports of an edited Go file that were used in its original content
+// but aren't used anymore, with the import paths of the removed imports. Imports for side effects and dot imports are never
+// removed, and neither are imports whose package name can't be guessed from their import path.
+func UnusedImportEdits(path string, original string, edited string) ([]types.TextEdit, []string, error) {
+	fset := token.NewFileSet()
+	originalFile, err := parser.ParseFile(fset, path, original, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	editedFile, err := parser.ParseFile(fset, path, edited, parser.ParseComments)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	wasUsed := usedImports(originalFile)
+	isUsed := usedImports(editedFile)
+	var edits []types.TextEdit
+	var removed []string
+	for _, decl := range editedFile.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+		var unused []*ast.ImportSpec
+		for _, spec := range genDecl.Specs {
+			importSpec := spec.(*ast.ImportSpec)
+			importPath, err := strconv.Unquote(importSpec.Path.Value)
+			if err == nil && wasUsed[importPath] && !isUsed[importPath] {
+				unused = append(unused, importSpec)
+				removed = append(removed, importPath)
+			}
+		}
+
+		// Remove the whole import declaration if none of its imports are left
+		if len(unused) == len(genDecl.Specs) && len(unused) > 0 {
+			start, end := nodeLines(fset, edited, genDecl.Doc, genDecl)
+			start, end = collapseBlankLines(edited, start, end)
+			edits = append(edits, offsetTextEdit(edited, start, end, ""))
+			continue
+		}
+		for _, spec := range unused {
+			start, end := nodeLines(fset, edited, spec.Doc, spec)
+			edits = append(edits, offsetTextEdit(edited, start, end, ""))
+		}
+	}
+	return edits, removed, nil
+}
+
+// usedImports returns the import paths of the imports of a parsed file that are used, by the package names of their imports
+func usedImports(file *ast.File) map[string]bool {
+	// Package names aren't resolved by the parser, since they are declared by the imports of the file
+	qualifiers := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok && ident.Obj == nil {
+				qualifiers[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	used := make(map[string]bool)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := importName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		used[importPath] = used[importPath] || name == "_" || name == "." || qualifiers[name]
+	}
+	return used
+}
+
+// nodeLines returns the byte range of a node with its doc comment, extended to whole lines if nothing else is on its lines
+func nodeLines(fset *token.FileSet, content string, doc *ast.CommentGroup, node ast.Node) (int, int) {
+	start, end := fset.Position(node.Pos()).Offset, fset.Position(node.End()).Offset
+	if doc != nil {
+		start = fset.Position(doc.Pos()).Offset
+	}
+
+	lineStart := strings.LastIndex(content[:start], "\n") + 1
+	lineEnd := len(content)
+	if i := strings.IndexByte(content[end:], '\n'); i >= 0 {
+		lineEnd = end + i + 1
+	}
+	rest := strings.TrimSpace(content[end:lineEnd])
+	if strings.TrimSpace(content[lineStart:start]) != "" || (rest != "" && !strings.HasPrefix(rest, "//")) {
+		return start, end
+	}
+	return lineStart, lineEnd
+}
+
+// collapseBlankLines extends a removed range of whole lines that follows a blank line, so that blank lines don't pile up:
+// past the blank line after the range, or else back to the blank line before the range at the end of the content
+func collapseBlankLines(content string, start int, end int) (int, int) {
+	if start == 0 {
+		return start, end
+	}
+	previous := strings.LastIndex(content[:start-1], "\n") + 1
+	switch {
+	case strings.TrimSpace(content[previous:start-1]) != "":
+		return start, end
+	case end < len(content) && content[end] == '\n':
+		return start, end + 1
+	case end == len(content):
+		return start - 1, end
+	}
+	return start, end
+}
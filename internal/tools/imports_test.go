@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnusedImportEdits(t *testing.T) {
+	tests := []struct {
+		name            string
+		original        string
+		edited          string
+		expected        string
+		expectedRemoved []string
+	}{
+		{
+			name:            "Single import",
+			original:        "package p\n\nimport \"fmt\"\n\nfunc F() { fmt.Println() }\n\nfunc G() {}\n",
+			edited:          "package p\n\nimport \"fmt\"\n\nfunc G() {}\n",
+			expected:        "package p\n\nfunc G() {}\n",
+			expectedRemoved: []string{"fmt"},
+		},
+		{
+			name:            "Grouped imports with comments",
+			original:        "package p\n\nimport (\n\t// For printing\n\t\"fmt\"\n\tstr \"strings\" // For strings\n)\n\nvar s = str.ToUpper(\"x\")\n\nfunc F() { fmt.Println() }\n",
+			edited:          "package p\n\nimport (\n\t// For printing\n\t\"fmt\"\n\tstr \"strings\" // For strings\n)\n\nfunc F() { fmt.Println() }\n",
+			expected:        "package p\n\nimport (\n\t// For printing\n\t\"fmt\"\n)\n\nfunc F() { fmt.Println() }\n",
+			expectedRemoved: []string{"strings"},
+		},
+		{
+			name:            "Whole group",
+			original:        "package p\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc F() { fmt.Println(os.Args) }\n",
+			edited:          "package p\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n",
+			expected:        "package p\n",
+			expectedRemoved: []string{"fmt", "os"},
+		},
+		{
+			name:     "Shadowed and already unused imports",
+			original: "package p\n\nimport (\n\t\"fmt\"\n\t_ \"embed\"\n\t\"os\"\n)\n\nfunc F() { fmt.Println() }\n",
+			edited:   "package p\n\nimport (\n\t\"fmt\"\n\t_ \"embed\"\n\t\"os\"\n)\n\nfunc F(fmt T) { fmt.Println() }\n",
+			expected: "package p\n\nimport (\n\t_ \"embed\"\n\t\"os\"\n)\n\nfunc F(fmt T) { fmt.Println() }\n",
+			// Imports that weren't used before aren't removed, nor are imports for side effects
+			expectedRemoved: []string{"fmt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits, removed, err := UnusedImportEdits("p.go", tt.original, tt.edited)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRemoved, removed)
+
+			edited, err := ApplyTextEdits(tt.edited, edits)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, edited)
+		})
+	}
+}
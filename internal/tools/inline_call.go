@@ -0,0 +1,326 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// InlineCallTool handles inline call requests
+type InlineCallTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewInlineCallTool creates a new inline call tool
+func NewInlineCallTool(client types.Client, config types.Config) *InlineCallTool {
+	return &InlineCallTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *InlineCallTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Inline a function or method call, replacing the call with the body of the callee while preserving its behavior, e.g. to remove trivial wrappers. The position must be on the name of the called function. With all_call_sites, inlines every call of the function instead (the position may also be on its declaration). Returns a unified diff of each changed file; use dry_run to preview the changes without writing them."),
+		},
+		positionToolOptions()...,
+	)
+	options = append(options,
+		mcp.WithBoolean("all_call_sites", mcp.Description("Whether to inline all calls of the function in the workspace, found by its references (default: false)")),
+		mcp.WithBoolean("dry_run", mcp.Description("Whether to only preview the changes, without writing them to disk (default: false)")),
+	)
+	tool := mcp.NewTool("inline_call", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *InlineCallTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	position, err := parsePositionArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid position parameters", "tool", "inline_call", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	allCallSites := mcp.ParseBoolean(req, "all_call_sites", false)
+	dryRun := mcp.ParseBoolean(req, "dry_run", false)
+
+	slog.Debug("MCP tool called",
+		"tool", "inline_call",
+		"symbol_anchor", position.anchor,
+		"file_path", position.filePath,
+		"line", position.line,
+		"column", position.column,
+		"all_call_sites", allCallSites,
+		"dry_run", dryRun)
+
+	resolved, err := position.resolveForEdit(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve position",
+			"tool", "inline_call",
+			"symbol_anchor", position.anchor,
+			"error", err)
+		return mcp.NewToolResultError(position.resolveErrorMessage(err)), nil
+	}
+
+	callSites := []types.Location{{URI: resolved.URI, Range: types.Range{Start: resolved.Position, End: resolved.Position}}}
+	if allCallSites {
+		if callSites, err = t.findCallSites(ctx, resolved); err != nil {
+			slog.Error("Failed to find call sites",
+				"tool", "inline_call",
+				"uri", resolved.URI,
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to find call sites: %v", err)), nil
+		}
+		if len(callSites) == 0 {
+			return mcp.NewToolResultError("No call sites found. The position should be on the name of a function or method, or on a call of one."), nil
+		}
+	}
+
+	slog.Debug("Inlining call sites",
+		"tool", "inline_call",
+		"call_site_count", len(callSites))
+
+	session := NewEditSession(t.config.WorkspaceRoot)
+	sites := InlineCalls(ctx, t.client, session, callSites, dryRun)
+
+	toolResult := results.InlineCallToolResult{
+		Arguments: results.InlineCallToolArgs{
+			SymbolAnchor: position.anchor,
+			FilePath:     position.filePath,
+			Line:         position.line,
+			Column:       position.column,
+			AllCallSites: allCallSites,
+			DryRun:       dryRun,
+		},
+		Function:    resolved.Name,
+		CallSites:   sites,
+		FileChanges: make([]results.FileChange, 0),
+	}
+
+	inlined := 0
+	for _, site := range sites {
+		if site.Inlined {
+			inlined++
+		}
+	}
+	if !allCallSites && inlined == 0 {
+		slog.Debug("Failed to inline call",
+			"tool", "inline_call",
+			"uri", resolved.URI,
+			"error", sites[0].Error)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to inline call: %s", sites[0].Error)), nil
+	}
+
+	changes := session.Changes()
+	if !dryRun && len(changes) > 0 {
+		if err := WriteEditSession(ctx, t.client, session); err != nil {
+			slog.Error("Failed to write inlined calls",
+				"tool", "inline_call",
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write inlined calls: %v", err)), nil
+		}
+		toolResult.Applied = true
+	}
+	toolResult.FileChanges = append(toolResult.FileChanges, changes...)
+
+	function := "the function"
+	if resolved.Name != "" {
+		function = resolved.Name
+	}
+	if dryRun {
+		toolResult.Message = fmt.Sprintf("Inlining %d of %d calls of %s would change %d files. No changes were written (dry run).", inlined, len(sites), function, len(changes))
+	} else {
+		toolResult.Message = fmt.Sprintf("Inlined %d of %d calls of %s, changing %d files.", inlined, len(sites), function, len(changes))
+	}
+	if inlined < len(sites) {
+		toolResult.Message += " See the errors of the call sites that couldn't be inlined."
+	}
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "inline_call",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "inline_call",
+		"inlined_count", inlined,
+		"call_site_count", len(sites),
+		"file_change_count", len(toolResult.FileChanges),
+		"applied", toolResult.Applied,
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// findCallSites finds the references to the function at a position, excluding its declaration
+func (t *InlineCallTool) findCallSites(ctx context.Context, resolved ResolvedAnchor) ([]types.Location, error) {
+	references, err := t.client.FindReferences(ctx, resolved.URI, resolved.Position)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, _ := t.client.GoToDefinition(ctx, resolved.URI, resolved.Position)
+	var callSites []types.Location
+	for _, reference := range references {
+		isDefinition := false
+		for _, definition := range definitions {
+			if reference.URI == definition.URI && reference.Range.Start == definition.Range.Start {
+				isDefinition = true
+				break
+			}
+		}
+		if !isDefinition {
+			callSites = append(callSites, reference)
+		}
+	}
+	return callSites, nil
+}
+
+// InlineCalls inlines the calls at the given locations in an edit session, returning the outcome for each call site.
+// Call sites are sorted by location, and inlined one at a time: each file is opened in gopls with its edited content,
+// and the remaining call sites are mapped through the edits of each inlined call.
+// Inline code actions that run a command are refused if dryRun is set.
+func InlineCalls(ctx context.Context, client types.Client, session *EditSession, callSites []types.Location, dryRun bool) []results.InlineCallSite {
+	positionsByPath := make(map[string][]types.Position)
+	var paths []string
+	for _, site := range callSites {
+		path := UriToPath(site.URI)
+		if _, ok := positionsByPath[path]; !ok {
+			paths = append(paths, path)
+		}
+		positionsByPath[path] = append(positionsByPath[path], site.Range.Start)
+	}
+	sort.Strings(paths)
+
+	var sites []results.InlineCallSite
+	for _, path := range paths {
+		positions := positionsByPath[path]
+		sort.Slice(positions, func(i, j int) bool {
+			return comparePositions(positions[i], positions[j]) < 0
+		})
+
+		errs := inlineCallsInFile(ctx, client, session, path, positions, dryRun)
+		file, _ := GetDisplayPath(path, session.workspaceRoot)
+		for i, position := range positions {
+			location := results.SymbolLocation{
+				File:        file,
+				DisplayLine: position.Line + 1,      // Convert LSP coordinates to display line
+				DisplayChar: position.Character + 1, // Convert LSP coordinates to display character
+			}
+			site := results.InlineCallSite{Location: location, Anchor: location.ToAnchor(), Inlined: errs[i] == nil}
+			if errs[i] != nil {
+				site.Error = errs[i].Error()
+			}
+			sites = append(sites, site)
+		}
+	}
+	return sites
+}
+
+// inlineCallsInFile inlines the calls at sorted positions of a file in an edit session, returning an error for each call that wasn't inlined
+func inlineCallsInFile(ctx context.Context, client types.Client, session *EditSession, path string, positions []types.Position, dryRun bool) []error {
+	errs := make([]error, len(positions))
+	fail := func(err error) []error {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+
+	content, err := session.Content(path)
+	if err != nil {
+		return fail(err)
+	}
+	uri := PathToUri(path, "")
+	if err := client.OpenDocument(ctx, uri, content); err != nil {
+		return fail(err)
+	}
+	defer func() {
+		if err := client.CloseDocument(ctx, uri); err != nil {
+			slog.Error("Failed to close document", "uri", uri, "error", err)
+		}
+	}()
+
+	current := append([]types.Position(nil), positions...) // Positions in the edited content
+	removed := make([]bool, len(positions))
+	version := 1
+	for i := range positions {
+		if removed[i] {
+			errs[i] = errors.New("the call was removed by inlining an enclosing call")
+			continue
+		}
+
+		edits, err := inlineCallEdits(ctx, client, uri, current[i], dryRun)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		for _, edit := range edits {
+			before, err := session.Content(path)
+			if err != nil {
+				return fail(err)
+			}
+			fileEdits := workspaceEditTextEdits(edit, path)
+			for j := i + 1; j < len(positions); j++ {
+				if removed[j] {
+					continue
+				}
+				mapped, ok, err := MapPosition(before, fileEdits, current[j])
+				if err != nil || !ok {
+					removed[j] = true
+					continue
+				}
+				current[j] = mapped
+			}
+			if err := session.ApplyWorkspaceEdit(edit); err != nil {
+				errs[i] = err
+				break
+			}
+		}
+		if errs[i] != nil {
+			continue
+		}
+
+		after, err := session.Content(path)
+		if err != nil {
+			return fail(err)
+		}
+		if after != content {
+			version++
+			if err := client.ChangeDocument(ctx, uri, version, after); err != nil {
+				return fail(err)
+			}
+			content = after
+		}
+	}
+	return errs
+}
+
+// inlineCallEdits computes the edits of the inline call code action for the call at a position
+func inlineCallEdits(ctx context.Context, client types.Client, uri string, position types.Position, dryRun bool) ([]types.WorkspaceEdit, error) {
+	kinds := []types.CodeActionKind{types.CodeActionKindInlineCall}
+	actions, _, err := GetCodeActions(ctx, client, uri, types.Range{Start: position, End: position}, kinds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code actions: %w", err)
+	}
+	action, err := FindCodeActionByKind(actions, kinds, "the position must be on the name of the called function in a call expression")
+	if err != nil {
+		return nil, err
+	}
+	return CodeActionEdits(ctx, client, *action, dryRun)
+}
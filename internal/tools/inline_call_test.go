@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inlineClient is a fake client that inlines calls of double(N) in open documents,
+// adding a comment line before the call so that later lines move
+type inlineClient struct {
+	types.Client
+	text     string
+	versions []int
+	closed   bool
+}
+
+func (c *inlineClient) OpenDocument(ctx context.Context, uri string, text string) error {
+	c.text = text
+	return nil
+}
+
+func (c *inlineClient) ChangeDocument(ctx context.Context, uri string, version int, text string) error {
+	c.text = text
+	c.versions = append(c.versions, version)
+	return nil
+}
+
+func (c *inlineClient) CloseDocument(ctx context.Context, uri string) error {
+	c.closed = true
+	return nil
+}
+
+func (c *inlineClient) GetDiagnostics(ctx context.Context, uri string) ([]types.Diagnostic, error) {
+	return nil, nil
+}
+
+func (c *inlineClient) GetCodeActions(ctx context.Context, uri string, rng types.Range, actionContext types.CodeActionContext) ([]types.CodeAction, error) {
+	line := strings.Split(c.text, "\n")[rng.Start.Line]
+	call := line[rng.Start.Character:]
+	if !strings.HasPrefix(call, "double(") {
+		return nil, nil
+	}
+	end := strings.Index(call, ")")
+	arg := call[len("double("):end]
+
+	edits := []types.TextEdit{
+		textEdit(rng.Start.Line, 0, rng.Start.Line, 0, "\t// inlined\n"),
+		textEdit(rng.Start.Line, rng.Start.Character, rng.Start.Line, rng.Start.Character+end+1, arg+" * 2"),
+	}
+	edit := &types.WorkspaceEdit{Changes: map[string][]types.TextEdit{uri: edits}}
+	return []types.CodeAction{{Title: "Inline call to double", Kind: types.CodeActionKindInlineCall, Edit: edit}}, nil
+}
+
+func TestInlineCalls(t *testing.T) {
+	content := "package main\n\nfunc main() {\n\ta := double(1)\n\tb := double(2) + double(3)\n\tprintln(a, b, helper)\n}\n"
+	expected := "package main\n\nfunc main() {\n\t// inlined\n\ta := 1 * 2\n\t// inlined\n\t// inlined\n\tb := 2 * 2 + 3 * 2\n\tprintln(a, b, helper)\n}\n"
+
+	root := t.TempDir()
+	path := filepath.Join(root, "main.go")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	uri := PathToUri(path, "")
+
+	location := func(line, character int) types.Location {
+		position := types.Position{Line: line, Character: character}
+		return types.Location{URI: uri, Range: types.Range{Start: position, End: position}}
+	}
+	callSites := []types.Location{location(5, 15), location(4, 18), location(3, 6), location(4, 6)}
+
+	client := &inlineClient{}
+	session := NewEditSession(root)
+	sites := InlineCalls(context.Background(), client, session, callSites, false)
+
+	require.Len(t, sites, 4)
+	for i, site := range sites[:3] {
+		assert.True(t, site.Inlined, "Call site %d should be inlined: %s", i, site.Error)
+	}
+	assert.Equal(t, 5, sites[1].Location.DisplayLine, "Call sites should be sorted and keep their original locations")
+	assert.Equal(t, 19, sites[2].Location.DisplayChar)
+	assert.Equal(t, "go://main.go#6:16", sites[3].Anchor.String())
+	assert.False(t, sites[3].Inlined, "References that aren't calls can't be inlined")
+	assert.Contains(t, sites[3].Error, "no refactor.inline.call code action")
+
+	edited, err := session.Content(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, edited)
+	assert.Equal(t, []int{2, 3, 4}, client.versions)
+	assert.True(t, client.closed)
+}
@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ListCodeActionsTool handles list code actions requests
+type ListCodeActionsTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewListCodeActionsTool creates a new list code actions tool
+func NewListCodeActionsTool(client types.Client, config types.Config) *ListCodeActionsTool {
+	return &ListCodeActionsTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *ListCodeActionsTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("List the code actions available for a range or position in a Go file, like quick fixes for diagnostics, filling structs, adding imports, and extracting or inlining code. Apply one with apply_code_action."),
+		},
+		rangeToolOptions()...,
+	)
+	options = append(options,
+		mcp.WithArray(
+			"kinds",
+			mcp.Items(map[string]any{"type": "string"}),
+			mcp.Description("Only include code actions of these kinds or their subkinds, e.g. [\"quickfix\", \"refactor.extract\", \"refactor.inline\", \"source.organizeImports\"] (default: all kinds)"),
+		),
+	)
+	tool := mcp.NewTool("list_code_actions", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *ListCodeActionsTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	rangeArgs, err := parseRangeArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid range parameters", "tool", "list_code_actions", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	kinds := ParseStringArray(req, "kinds")
+
+	slog.Debug("MCP tool called",
+		"tool", "list_code_actions",
+		"symbol_anchor", rangeArgs.anchor,
+		"file_path", rangeArgs.filePath,
+		"line", rangeArgs.line,
+		"column", rangeArgs.column,
+		"end_line", rangeArgs.endLine,
+		"end_column", rangeArgs.endColumn,
+		"end_anchor", rangeArgs.endAnchor,
+		"kinds", kinds)
+
+	resolved, rng, err := rangeArgs.resolve(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve range",
+			"tool", "list_code_actions",
+			"symbol_anchor", rangeArgs.anchor,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid range: %v", err)), nil
+	}
+
+	actions, diagnostics, err := GetCodeActions(ctx, t.client, resolved.URI, rng, codeActionKinds(kinds))
+	if err != nil {
+		slog.Error("Failed to get code actions",
+			"tool", "list_code_actions",
+			"uri", resolved.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get code actions: %v", err)), nil
+	}
+
+	slog.Debug("Found code actions from LSP",
+		"tool", "list_code_actions",
+		"uri", resolved.URI,
+		"code_action_count", len(actions),
+		"diagnostic_count", len(diagnostics))
+
+	toolResult := results.ListCodeActionsToolResult{
+		Arguments: results.ListCodeActionsToolArgs{
+			SymbolAnchor: rangeArgs.anchor,
+			FilePath:     rangeArgs.filePath,
+			Line:         rangeArgs.line,
+			Column:       rangeArgs.column,
+			EndLine:      rangeArgs.endLine,
+			EndColumn:    rangeArgs.endColumn,
+			EndAnchor:    rangeArgs.endAnchor,
+			Kinds:        kinds,
+		},
+		Diagnostics: make([]results.CodeDiagnostic, 0, len(diagnostics)),
+		CodeActions: make([]results.CodeActionInfo, 0, len(actions)),
+	}
+	for _, diagnostic := range diagnostics {
+		toolResult.Diagnostics = append(toolResult.Diagnostics, results.NewCodeDiagnostic(diagnostic))
+	}
+	for _, action := range actions {
+		toolResult.CodeActions = append(toolResult.CodeActions, results.NewCodeActionInfo(action))
+	}
+
+	if len(toolResult.CodeActions) == 0 {
+		toolResult.Message = "No code actions found. " +
+			"Many refactorings require a range that selects a complete expression or statements, rather than a position."
+		slog.Debug("No code actions found",
+			"tool", "list_code_actions",
+			"uri", resolved.URI)
+	} else {
+		toolResult.Message = fmt.Sprintf("Found %d code actions.", len(toolResult.CodeActions))
+	}
+	if len(toolResult.Diagnostics) > 0 {
+		toolResult.Message += fmt.Sprintf(" The range overlaps %d diagnostics.", len(toolResult.Diagnostics))
+	}
+	toolResult.Message += RelocationMessage(resolved)
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "list_code_actions",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "list_code_actions",
+		"code_action_count", len(toolResult.CodeActions),
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// codeActionKinds converts code action kind parameters to LSP code action kinds
+func codeActionKinds(kinds []string) []types.CodeActionKind {
+	if len(kinds) == 0 {
+		return nil
+	}
+	codeActionKinds := make([]types.CodeActionKind, 0, len(kinds))
+	for _, kind := range kinds {
+		codeActionKinds = append(codeActionKinds, types.CodeActionKind(kind))
+	}
+	return codeActionKinds
+}
@@ -0,0 +1,266 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// DefaultPackagesLimit is the default maximum number of packages to return
+	DefaultPackagesLimit = 100
+)
+
+// ListPackagesTool handles list packages requests
+type ListPackagesTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewListPackagesTool creates a new list packages tool
+func NewListPackagesTool(client types.Client, config types.Config) *ListPackagesTool {
+	return &ListPackagesTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *ListPackagesTool) GetTool() mcp.Tool {
+	tool := mcp.NewTool("list_packages",
+		mcp.WithDescription("List the packages in the Go workspace, with their import paths, directories, modules, and files"),
+		mcp.WithString("pattern", mcp.Description("Import path pattern to filter packages, where '...' matches any string (e.g. github.com/user/project/internal/...)")),
+		mcp.WithBoolean("include_tests", mcp.Description("Whether to include test variants of packages, with their test files and tests (default: false)")),
+		mcp.WithBoolean("include_external", mcp.Description("Whether to include importable packages outside the workspace, like the standard library and dependencies (default: false)")),
+		mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum number of packages to return (default: %d)", DefaultPackagesLimit))),
+		mcp.WithString("cursor", mcp.Description("Cursor from the next_cursor field of a previous response, to get the next page of results")),
+	)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *ListPackagesTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	pattern := mcp.ParseString(req, "pattern", "")
+	includeTests := mcp.ParseBoolean(req, "include_tests", false)
+	includeExternal := mcp.ParseBoolean(req, "include_external", false)
+
+	limit := mcp.ParseInt(req, "limit", DefaultPackagesLimit)
+	if limit <= 0 {
+		limit = DefaultPackagesLimit
+	}
+
+	cursor := mcp.ParseString(req, "cursor", "")
+
+	slog.Debug("MCP tool called",
+		"tool", "list_packages",
+		"pattern", pattern,
+		"include_tests", includeTests,
+		"include_external", includeExternal,
+		"limit", limit,
+		"cursor", cursor)
+
+	args := types.PackagesArgs{
+		Files:     []string{PathToUri(t.config.WorkspaceRoot, t.config.WorkspaceRoot)},
+		Recursive: true,
+	}
+	if includeTests {
+		args.Mode |= types.PackagesModeNeedTests
+	}
+	packagesResult, err := t.client.GetPackages(ctx, args)
+	if err != nil {
+		slog.Error("Failed to get packages",
+			"tool", "list_packages",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list packages: %v", err)), nil
+	}
+
+	slog.Debug("Found packages from LSP",
+		"tool", "list_packages",
+		"package_count", len(packagesResult.Packages),
+		"module_count", len(packagesResult.Module))
+
+	toolResult := results.ListPackagesToolResult{
+		Arguments: results.ListPackagesToolArgs{
+			Pattern:         pattern,
+			IncludeTests:    includeTests,
+			IncludeExternal: includeExternal,
+			Limit:           limit,
+			Cursor:          cursor,
+		},
+		Packages: make([]results.PackageInfo, 0),
+	}
+
+	var packages []results.PackageInfo
+	workspacePackages := make(map[string]bool)
+	modulePaths := make(map[string]bool)
+	var anyFile string // Any Go file in the workspace, to find the packages it can import
+	for _, pkg := range packagesResult.Packages {
+		workspacePackages[pkg.Path] = true
+		dir := packageDirectory(pkg, packagesResult.Module)
+		if anyFile == "" && dir != "" {
+			if files, _ := ListPackageFiles(dir, false); len(files) > 0 {
+				anyFile = files[0]
+			}
+		}
+
+		if pkg.ForTest != "" && !includeTests {
+			continue
+		}
+		if !MatchPackagePattern(pattern, pkg.Path) && !(pkg.ForTest != "" && MatchPackagePattern(pattern, pkg.ForTest)) {
+			continue
+		}
+
+		info := results.PackageInfo{
+			ImportPath: pkg.Path,
+			ModulePath: pkg.ModulePath,
+			ForTest:    pkg.ForTest,
+		}
+		if dir != "" {
+			info.Directory = GetRelativePath(dir, t.config.WorkspaceRoot)
+			if pkg.ForTest == "" {
+				// gopls.packages doesn't list the files of packages, so they are matched against the build context like gopls does
+				files, _ := ListPackageFiles(dir, false)
+				for _, file := range files {
+					if MatchesBuildContext(file) {
+						info.Files = append(info.Files, GetRelativePath(file, t.config.WorkspaceRoot))
+					}
+				}
+			}
+		}
+		for _, testFile := range pkg.TestFiles {
+			packageTestFile := results.PackageTestFile{File: GetRelativePath(UriToPath(testFile.URI), t.config.WorkspaceRoot)}
+			for _, test := range testFile.Tests {
+				packageTestFile.Tests = append(packageTestFile.Tests, test.Name)
+			}
+			info.TestFiles = append(info.TestFiles, packageTestFile)
+		}
+
+		if pkg.ModulePath != "" {
+			modulePaths[pkg.ModulePath] = true
+		}
+		packages = append(packages, info)
+	}
+
+	if includeExternal && anyFile != "" {
+		knownPackages, err := t.client.ListKnownPackages(ctx, PathToUri(anyFile, t.config.WorkspaceRoot))
+		if err != nil {
+			slog.Error("Failed to list known packages",
+				"tool", "list_packages",
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list external packages: %v", err)), nil
+		}
+		for _, importPath := range knownPackages {
+			if !workspacePackages[importPath] && MatchPackagePattern(pattern, importPath) {
+				packages = append(packages, results.PackageInfo{ImportPath: importPath, External: true})
+			}
+		}
+	}
+
+	for modulePath := range modulePaths {
+		module, ok := packagesResult.Module[modulePath]
+		if !ok {
+			continue
+		}
+		moduleInfo := results.ModuleInfo{
+			Path:      module.Path,
+			Version:   module.Version,
+			Workspace: module.Version == "",
+		}
+		if module.GoMod != "" {
+			moduleInfo.GoMod, _ = GetDisplayPath(UriToPath(module.GoMod), t.config.WorkspaceRoot)
+		}
+		toolResult.Modules = append(toolResult.Modules, moduleInfo)
+	}
+	sort.Slice(toolResult.Modules, func(i, j int) bool {
+		return toolResult.Modules[i].Path < toolResult.Modules[j].Path
+	})
+
+	// Sort packages so that the ordering is stable across pages, with test variants after the package under test
+	sort.SliceStable(packages, func(i, j int) bool {
+		a, b := packages[i], packages[j]
+		if a.External != b.External {
+			return !a.External
+		}
+		if a.ImportPath != b.ImportPath {
+			return a.ImportPath < b.ImportPath
+		}
+		return a.ForTest < b.ForTest
+	})
+
+	// Apply pagination to prevent token overflow
+	query := fmt.Sprintf("%s:%t:%t", pattern, includeTests, includeExternal)
+	page, err := Paginate(packages, query, cursor, limit)
+	if err != nil {
+		slog.Debug("Invalid cursor",
+			"tool", "list_packages",
+			"cursor", cursor,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid cursor: %v", err)), nil
+	}
+	toolResult.Total = page.Total
+	toolResult.Truncated = page.Truncated
+	toolResult.NextCursor = page.NextCursor
+	toolResult.Packages = append(toolResult.Packages, page.Items...)
+
+	if toolResult.Total == 0 {
+		toolResult.Message = "No packages found. " +
+			"This could mean that the pattern doesn't match any packages, or that the workspace has no Go packages."
+		slog.Debug("No packages found",
+			"tool", "list_packages",
+			"pattern", pattern)
+	} else {
+		toolResult.Message = fmt.Sprintf("Found %d packages.", toolResult.Total) + PageMessage(page)
+		slog.Debug("Found packages",
+			"tool", "list_packages",
+			"total_count", toolResult.Total,
+			"package_count", len(toolResult.Packages))
+	}
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "list_packages",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "list_packages",
+		"package_count", len(toolResult.Packages),
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// packageDirectory returns the directory of a package from the go.mod file of its module, or from its test files.
+// External test packages (like "calc_test") live in the directory of the package under test.
+func packageDirectory(pkg types.Package, modules map[string]types.Module) string {
+	importPath := pkg.Path
+	if pkg.ForTest != "" {
+		importPath = pkg.ForTest
+	}
+
+	if module, ok := modules[pkg.ModulePath]; ok && module.GoMod != "" {
+		moduleDir := filepath.Dir(UriToPath(module.GoMod))
+		if importPath == pkg.ModulePath {
+			return moduleDir
+		}
+		if rel, found := strings.CutPrefix(importPath, pkg.ModulePath+"/"); found {
+			return filepath.Join(moduleDir, filepath.FromSlash(rel))
+		}
+	}
+
+	if len(pkg.TestFiles) > 0 {
+		return filepath.Dir(UriToPath(pkg.TestFiles[0].URI))
+	}
+	return ""
+}
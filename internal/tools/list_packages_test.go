@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageDirectory(t *testing.T) {
+	modules := map[string]types.Module{
+		"example.com/project": {Path: "example.com/project", GoMod: "file:///home/user/project/go.mod"},
+	}
+
+	tests := []struct {
+		name     string
+		pkg      types.Package
+		expected string
+	}{
+		{
+			name:     "Module root package",
+			pkg:      types.Package{Path: "example.com/project", ModulePath: "example.com/project"},
+			expected: "/home/user/project",
+		},
+		{
+			name:     "Nested package",
+			pkg:      types.Package{Path: "example.com/project/pkg/util", ModulePath: "example.com/project"},
+			expected: "/home/user/project/pkg/util",
+		},
+		{
+			name:     "External test package",
+			pkg:      types.Package{Path: "example.com/project/pkg/util_test", ForTest: "example.com/project/pkg/util", ModulePath: "example.com/project"},
+			expected: "/home/user/project/pkg/util",
+		},
+		{
+			name: "Package without a module",
+			pkg: types.Package{
+				Path:      "command-line-arguments",
+				TestFiles: []types.TestFile{{URI: "file:///tmp/scratch/main_test.go"}},
+			},
+			expected: "/tmp/scratch",
+		},
+		{
+			name:     "Unknown directory",
+			pkg:      types.Package{Path: "command-line-arguments"},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, packageDirectory(tt.pkg, modules))
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strings"
 
 	"github.com/averycrespi/gopls-mcp/internal/results"
 	"github.com/averycrespi/gopls-mcp/pkg/types"
@@ -37,6 +39,7 @@ func (t *ListSymbolsInFileTool) GetTool() mcp.Tool {
 		mcp.WithDescription("List all symbols in a Go file, returning a list of symbols with hierarchical structure"),
 		mcp.WithString("file_path", mcp.Required(), mcp.Description("Path to the Go file")),
 		mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum number of symbols to return (default: %d)", DefaultFileSymbolsLimit))),
+		mcp.WithString("cursor", mcp.Description("Cursor from the next_cursor field of a previous response, to get the next page of results")),
 		mcp.WithBoolean("include_hover", mcp.Description("Whether to include hover information for symbols (default: false)")),
 	)
 	return tool
@@ -55,9 +58,16 @@ func (t *ListSymbolsInFileTool) Handle(ctx context.Context, req mcp.CallToolRequ
 		limit = DefaultFileSymbolsLimit
 	}
 
+	cursor := mcp.ParseString(req, "cursor", "")
+
 	includeHover := mcp.ParseBoolean(req, "include_hover", false)
 
-	slog.Debug("MCP tool called", "tool", "list_symbols_in_file", "file_path", filePath, "limit", limit, "include_hover", includeHover)
+	slog.Debug("MCP tool called",
+		"tool", "list_symbols_in_file",
+		"file_path", filePath,
+		"limit", limit,
+		"cursor", cursor,
+		"include_hover", includeHover)
 
 	uri := PathToUri(filePath, t.config.WorkspaceRoot)
 	slog.Debug("Converted file path to URI",
@@ -86,30 +96,51 @@ func (t *ListSymbolsInFileTool) Handle(ctx context.Context, req mcp.CallToolRequ
 		Arguments: results.ListSymbolsInFileToolArgs{
 			FilePath:     filePath,
 			Limit:        limit,
+			Cursor:       cursor,
 			IncludeHover: includeHover,
 		},
 		FileSymbols: make([]results.FileSymbol, 0),
 	}
-	for _, docSym := range documentSymbols {
-		// Apply limit to prevent token overflow
-		if len(toolResult.FileSymbols) >= limit {
-			break
+
+	// Sort top-level symbols by position so that the ordering is stable across pages
+	sort.SliceStable(documentSymbols, func(i, j int) bool {
+		a, b := documentSymbols[i].SelectionRange.Start, documentSymbols[j].SelectionRange.Start
+		if a.Line != b.Line {
+			return a.Line < b.Line
 		}
+		return a.Character < b.Character
+	})
 
-		symbolResult := t.convertDocumentSymbol(ctx, uri, docSym, filePath, includeHover)
+	// Apply pagination to prevent token overflow
+	page, err := Paginate(documentSymbols, filePath, cursor, limit)
+	if err != nil {
+		slog.Debug("Invalid cursor",
+			"tool", "list_symbols_in_file",
+			"cursor", cursor,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid cursor: %v", err)), nil
+	}
+	toolResult.Total = page.Total
+	toolResult.Truncated = page.Truncated
+	toolResult.NextCursor = page.NextCursor
+
+	importPath := PackageImportPath(UriToPath(uri))
+	for _, docSym := range page.Items {
+		symbolResult := NewFileSymbol(ctx, t.client, t.config.WorkspaceRoot, uri, docSym, importPath, results.NewSemanticSymbolPath(docSym.Name), includeHover)
 		toolResult.FileSymbols = append(toolResult.FileSymbols, symbolResult)
 	}
-	if len(toolResult.FileSymbols) == 0 {
+	if toolResult.Total == 0 {
 		toolResult.Message = "No symbols found in file. " +
 			"This could mean that the file is missing, empty, or not a Go file."
 		slog.Debug("No symbols found in file",
 			"tool", "list_symbols_in_file",
 			"file_path", filePath)
 	} else {
-		toolResult.Message = fmt.Sprintf("Found %d symbols in file.", len(toolResult.FileSymbols))
+		toolResult.Message = fmt.Sprintf("Found %d symbols in file.", toolResult.Total) + PageMessage(page)
 		slog.Debug("Found file symbols",
 			"tool", "list_symbols_in_file",
 			"file_path", filePath,
+			"total_count", toolResult.Total,
 			"symbol_count", len(toolResult.FileSymbols))
 	}
 
@@ -131,32 +162,41 @@ func (t *ListSymbolsInFileTool) Handle(ctx context.Context, req mcp.CallToolRequ
 	return mcp.NewToolResultText(string(jsonBytes)), nil
 }
 
-// convertDocumentSymbol converts a DocumentSymbol to FileSymbol recursively
-func (t *ListSymbolsInFileTool) convertDocumentSymbol(ctx context.Context, uri string, docSym types.DocumentSymbol, filePath string, includeHover bool) results.FileSymbol {
+// NewFileSymbol converts a DocumentSymbol in a file to a FileSymbol recursively.
+// The symbol path is empty for symbols that can't have a semantic anchor, like members of members.
+func NewFileSymbol(ctx context.Context, client types.Client, workspaceRoot string, uri string, docSym types.DocumentSymbol, importPath string, symbolPath string, includeHover bool) results.FileSymbol {
 	location := results.SymbolLocation{
-		File:        GetRelativePath(UriToPath(PathToUri(filePath, t.config.WorkspaceRoot)), t.config.WorkspaceRoot),
+		File:        GetRelativePath(UriToPath(uri), workspaceRoot),
 		DisplayLine: docSym.SelectionRange.Start.Line + 1,      // Convert LSP coordinates to display line
 		DisplayChar: docSym.SelectionRange.Start.Character + 1, // Convert LSP coordinates to display character
 	}
 	result := results.FileSymbol{
-		Name:     docSym.Name,
-		Kind:     results.NewSymbolKind(docSym.Kind),
-		Location: location,
-		Anchor:   location.ToAnchor(),
+		Name:         docSym.Name,
+		Kind:         results.NewSymbolKind(docSym.Kind),
+		Exported:     IsExportedName(docSym.Name),
+		TestFunction: results.NewTestFunctionKind(docSym.Kind, docSym.Name, location.File),
+		Location:     location,
+		Anchor:       location.ToAnchor().WithName(results.NewSymbolIdentifier(docSym.Name)),
+	}
+	if importPath != "" && symbolPath != "" {
+		result.SemanticAnchor = results.NewSemanticSymbolAnchor(importPath, symbolPath)
 	}
 
 	// Try to enhance with hover information if requested
 	if includeHover {
-		if hoverInfo, hoverErr := t.client.GetHoverInfo(ctx, uri, docSym.SelectionRange.Start); hoverErr == nil && hoverInfo != "" {
-			result.HoverInfo = hoverInfo
-		}
+		result.HoverInfo = GetHoverInfo(ctx, client, uri, docSym.SelectionRange.Start)
 	}
+	result.GoKind = GetGoSymbolKind(uri, docSym.SelectionRange.Start, docSym.Kind, docSym.Name, docSym.Detail, result.HoverInfo)
 
 	// Convert children recursively
 	if len(docSym.Children) > 0 {
 		result.Children = make([]results.FileSymbol, len(docSym.Children))
 		for i, child := range docSym.Children {
-			result.Children[i] = t.convertDocumentSymbol(ctx, uri, child, filePath, includeHover)
+			childPath := ""
+			if symbolPath != "" && !strings.Contains(symbolPath, ".") {
+				childPath = symbolPath + "." + child.Name
+			}
+			result.Children[i] = NewFileSymbol(ctx, client, workspaceRoot, uri, child, importPath, childPath, includeHover)
 		}
 	}
 
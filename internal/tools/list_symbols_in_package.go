@@ -0,0 +1,379 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// DefaultPackageSymbolsLimit is the default maximum number of package symbols to return
+	DefaultPackageSymbolsLimit = 100
+)
+
+// ListSymbolsInPackageTool handles list symbols in package requests
+type ListSymbolsInPackageTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewListSymbolsInPackageTool creates a new list symbols in package tool
+func NewListSymbolsInPackageTool(client types.Client, config types.Config) *ListSymbolsInPackageTool {
+	return &ListSymbolsInPackageTool{
+		client: client,
+		config: config,
+	}
+}
+
+// packageDocumentSymbol represents a top-level document symbol in one of the files of a package
+type packageDocumentSymbol struct {
+	uri     string
+	file    string
+	symbol  types.DocumentSymbol
+	methods []packageDocumentSymbol // Only for types, with the methods declared in any file of the package
+}
+
+// GetTool returns the MCP tool definition
+func (t *ListSymbolsInPackageTool) GetTool() mcp.Tool {
+	tool := mcp.NewTool("list_symbols_in_package",
+		mcp.WithDescription("List the symbols of a Go package across all of its files, with methods grouped under their receiver types, plus the package doc comment and exported API"),
+		mcp.WithString(
+			"package",
+			mcp.Required(),
+			mcp.Description("Import path of a package in the workspace module (e.g. github.com/user/project/pkg/util), or its directory (e.g. pkg/util)"),
+		),
+		mcp.WithBoolean("include_tests", mcp.Description("Whether to include symbols from _test.go files (default: false)")),
+		mcp.WithBoolean("exported_only", mcp.Description("Whether to only list exported symbols (default: false)")),
+		mcp.WithNumber("limit", mcp.Description(fmt.Sprintf("Maximum number of top-level symbols to return (default: %d)", DefaultPackageSymbolsLimit))),
+		mcp.WithString("cursor", mcp.Description("Cursor from the next_cursor field of a previous response, to get the next page of results")),
+		mcp.WithBoolean("include_hover", mcp.Description("Whether to include hover information for symbols (default: false)")),
+	)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *ListSymbolsInPackageTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	pkg := mcp.ParseString(req, "package", "")
+	if pkg == "" {
+		slog.Debug("MCP tool called with missing package parameter", "tool", "list_symbols_in_package")
+		return mcp.NewToolResultError("package parameter is required"), nil
+	}
+
+	limit := mcp.ParseInt(req, "limit", DefaultPackageSymbolsLimit)
+	if limit <= 0 {
+		limit = DefaultPackageSymbolsLimit
+	}
+
+	cursor := mcp.ParseString(req, "cursor", "")
+	includeTests := mcp.ParseBoolean(req, "include_tests", false)
+	exportedOnly := mcp.ParseBoolean(req, "exported_only", false)
+	includeHover := mcp.ParseBoolean(req, "include_hover", false)
+
+	slog.Debug("MCP tool called",
+		"tool", "list_symbols_in_package",
+		"package", pkg,
+		"include_tests", includeTests,
+		"exported_only", exportedOnly,
+		"limit", limit,
+		"cursor", cursor,
+		"include_hover", includeHover)
+
+	dir, err := ResolvePackageDir(t.config.WorkspaceRoot, pkg)
+	if err != nil {
+		slog.Debug("Failed to resolve package directory",
+			"tool", "list_symbols_in_package",
+			"package", pkg,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid package: %v", err)), nil
+	}
+
+	files, err := ListPackageFiles(dir, includeTests)
+	if err != nil {
+		slog.Error("Failed to list package files",
+			"tool", "list_symbols_in_package",
+			"directory", dir,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list files in package directory: %s: %v", dir, err)), nil
+	}
+
+	toolResult := results.ListSymbolsInPackageToolResult{
+		Arguments: results.ListSymbolsInPackageToolArgs{
+			Package:      pkg,
+			IncludeTests: includeTests,
+			ExportedOnly: exportedOnly,
+			Limit:        limit,
+			Cursor:       cursor,
+			IncludeHover: includeHover,
+		},
+		Directory:      GetRelativePath(dir, t.config.WorkspaceRoot),
+		Files:          make([]string, 0, len(files)),
+		PackageSymbols: make([]results.PackageSymbol, 0),
+	}
+	toolResult.PackageName, toolResult.Doc = PackageDoc(files)
+	if len(files) > 0 {
+		toolResult.ImportPath = PackageImportPath(files[0])
+	}
+
+	var documentSymbols []packageDocumentSymbol
+	for _, filePath := range files {
+		uri := PathToUri(filePath, t.config.WorkspaceRoot)
+		fileSymbols, err := t.client.GetDocumentSymbols(ctx, uri)
+		if err != nil {
+			slog.Error("Failed to get document symbols",
+				"tool", "list_symbols_in_package",
+				"uri", uri,
+				"error", err)
+			return mcp.NewToolResultError(
+				fmt.Sprintf("Failed to get document symbols for file: %s: %v", filePath, err),
+			), nil
+		}
+
+		file := GetRelativePath(filePath, t.config.WorkspaceRoot)
+		toolResult.Files = append(toolResult.Files, file)
+		for _, docSym := range fileSymbols {
+			documentSymbols = append(documentSymbols, packageDocumentSymbol{uri: uri, file: file, symbol: docSym})
+		}
+	}
+
+	slog.Debug("Found document symbols from LSP",
+		"tool", "list_symbols_in_package",
+		"directory", dir,
+		"file_count", len(files),
+		"symbol_count", len(documentSymbols))
+
+	toolResult.ExportedAPI = exportedAPI(documentSymbols)
+	packageSymbols := groupPackageSymbols(documentSymbols)
+	if exportedOnly {
+		packageSymbols = filterExportedPackageSymbols(packageSymbols)
+	}
+
+	// Apply pagination to prevent token overflow
+	query := fmt.Sprintf("%s:%t:%t", dir, includeTests, exportedOnly)
+	page, err := Paginate(packageSymbols, query, cursor, limit)
+	if err != nil {
+		slog.Debug("Invalid cursor",
+			"tool", "list_symbols_in_package",
+			"cursor", cursor,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid cursor: %v", err)), nil
+	}
+	toolResult.Total = page.Total
+	toolResult.Truncated = page.Truncated
+	toolResult.NextCursor = page.NextCursor
+
+	for _, entry := range page.Items {
+		packageSymbol := results.PackageSymbol{
+			FileSymbol: NewFileSymbol(ctx, t.client, t.config.WorkspaceRoot, entry.uri, entry.symbol, toolResult.ImportPath, results.NewSemanticSymbolPath(entry.symbol.Name), includeHover),
+		}
+		for _, method := range entry.methods {
+			packageSymbol.Methods = append(packageSymbol.Methods,
+				NewFileSymbol(ctx, t.client, t.config.WorkspaceRoot, method.uri, method.symbol, toolResult.ImportPath, results.NewSemanticSymbolPath(method.symbol.Name), includeHover))
+		}
+		toolResult.PackageSymbols = append(toolResult.PackageSymbols, packageSymbol)
+	}
+
+	if len(files) == 0 {
+		toolResult.Message = "No Go files found in package directory."
+		if !includeTests {
+			toolResult.Message += " Set include_tests to list symbols in test files."
+		}
+	} else if toolResult.Total == 0 {
+		toolResult.Message = fmt.Sprintf("No symbols found in %d files.", len(files))
+	} else {
+		toolResult.Message = fmt.Sprintf("Found %d top-level symbols in %d files.", toolResult.Total, len(files)) + PageMessage(page)
+	}
+	slog.Debug("Found package symbols",
+		"tool", "list_symbols_in_package",
+		"directory", dir,
+		"total_count", toolResult.Total,
+		"symbol_count", len(toolResult.PackageSymbols))
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "list_symbols_in_package",
+			"package", pkg,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "list_symbols_in_package",
+		"package", pkg,
+		"symbol_count", len(toolResult.PackageSymbols),
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// receiverTypeName returns the receiver type of a method document symbol, like "Calculator" for "(*Calculator).Add",
+// or an empty string if the symbol isn't a method
+func receiverTypeName(docSym types.DocumentSymbol) string {
+	if results.NewSymbolKind(docSym.Kind) != results.SymbolKindMethod {
+		return ""
+	}
+	typeName, _, found := strings.Cut(results.NewSemanticSymbolPath(docSym.Name), ".")
+	if !found {
+		return ""
+	}
+	return typeName
+}
+
+// groupPackageSymbols groups the methods of a package under their receiver types, which may be declared in other files.
+// Symbols are ordered by file and then by position; methods whose receiver type isn't found stay at the top level.
+func groupPackageSymbols(documentSymbols []packageDocumentSymbol) []packageDocumentSymbol {
+	sorted := make([]packageDocumentSymbol, len(documentSymbols))
+	copy(sorted, documentSymbols)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].file != sorted[j].file {
+			return sorted[i].file < sorted[j].file
+		}
+		return comparePositions(sorted[i].symbol.SelectionRange.Start, sorted[j].symbol.SelectionRange.Start) < 0
+	})
+
+	typeIndexes := make(map[string]int)
+	typeDecls := make(typeDeclarations)
+	grouped := make([]packageDocumentSymbol, 0, len(sorted))
+	for _, entry := range sorted {
+		if receiverTypeName(entry.symbol) == "" {
+			if typeDecls.isTypeSymbol(entry) {
+				typeIndexes[entry.symbol.Name] = len(grouped)
+			}
+			grouped = append(grouped, entry)
+		}
+	}
+
+	var orphans []packageDocumentSymbol
+	for _, entry := range sorted {
+		typeName := receiverTypeName(entry.symbol)
+		if typeName == "" {
+			continue
+		}
+		if i, ok := typeIndexes[typeName]; ok {
+			grouped[i].methods = append(grouped[i].methods, entry)
+		} else {
+			orphans = append(orphans, entry)
+		}
+	}
+	return append(grouped, orphans...)
+}
+
+// typeDeclarations caches the names of the top-level types declared in Go files, by file path
+type typeDeclarations map[string]map[string]bool
+
+// isTypeSymbol checks if a top-level document symbol declares a type, from the type declarations of its file, since gopls reports
+// types with the kinds of their underlying types (e.g. named func types are functions). Symbols of files that can't be parsed
+// are types if their LSP kind is one of the kinds of types.
+func (d typeDeclarations) isTypeSymbol(entry packageDocumentSymbol) bool {
+	path := UriToPath(entry.uri)
+	names, ok := d[path]
+	if !ok {
+		names = topLevelTypeNames(path)
+		d[path] = names
+	}
+	if names != nil {
+		return names[entry.symbol.Name]
+	}
+
+	switch results.NewSymbolKind(entry.symbol.Kind) {
+	case results.SymbolKindStruct, results.SymbolKindInterface, results.SymbolKindClass:
+		return true
+	default:
+		return false
+	}
+}
+
+// topLevelTypeNames returns the names of the top-level types declared in a Go file, or nil if it can't be parsed
+func topLevelTypeNames(path string) map[string]bool {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+	names := make(map[string]bool)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			names[spec.(*ast.TypeSpec).Name.Name] = true
+		}
+	}
+	return names
+}
+
+// filterExportedPackageSymbols keeps only exported symbols, along with their exported members and methods
+func filterExportedPackageSymbols(packageSymbols []packageDocumentSymbol) []packageDocumentSymbol {
+	filtered := make([]packageDocumentSymbol, 0, len(packageSymbols))
+	for _, entry := range packageSymbols {
+		if !IsExportedName(entry.symbol.Name) {
+			continue
+		}
+
+		var children []types.DocumentSymbol
+		for _, child := range entry.symbol.Children {
+			if IsExportedName(child.Name) {
+				children = append(children, child)
+			}
+		}
+		entry.symbol.Children = children
+
+		var methods []packageDocumentSymbol
+		for _, method := range entry.methods {
+			if IsExportedName(method.symbol.Name) {
+				methods = append(methods, method)
+			}
+		}
+		entry.methods = methods
+
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+// exportedAPI returns the sorted symbol paths of the exported API of a package, like "Calculator" and "Calculator.Add".
+// Members are only part of the API if their type is exported, and symbols in test files never are.
+func exportedAPI(documentSymbols []packageDocumentSymbol) []string {
+	exportedTypes := make(map[string]bool)
+	typeDecls := make(typeDeclarations)
+	for _, entry := range documentSymbols {
+		if !IsTestFile(entry.file) && typeDecls.isTypeSymbol(entry) && IsExportedName(entry.symbol.Name) {
+			exportedTypes[entry.symbol.Name] = true
+		}
+	}
+
+	var api []string
+	for _, entry := range documentSymbols {
+		if IsTestFile(entry.file) || !IsExportedName(entry.symbol.Name) {
+			continue
+		}
+		if typeName := receiverTypeName(entry.symbol); typeName != "" {
+			if exportedTypes[typeName] {
+				api = append(api, results.NewSemanticSymbolPath(entry.symbol.Name))
+			}
+			continue
+		}
+
+		api = append(api, entry.symbol.Name)
+		if exportedTypes[entry.symbol.Name] {
+			for _, child := range entry.symbol.Children {
+				if IsExportedName(child.Name) {
+					api = append(api, entry.symbol.Name+"."+child.Name)
+				}
+			}
+		}
+	}
+	sort.Strings(api)
+	return api
+}
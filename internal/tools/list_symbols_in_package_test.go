@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPackageDocumentSymbol(file string, line int, name string, kind int, children ...types.DocumentSymbol) packageDocumentSymbol {
+	return packageDocumentSymbol{
+		uri:  "file:///project/" + file,
+		file: file,
+		symbol: types.DocumentSymbol{
+			Name:           name,
+			Kind:           kind,
+			SelectionRange: types.Range{Start: types.Position{Line: line}},
+			Children:       children,
+		},
+	}
+}
+
+func testPackageDocumentSymbols() []packageDocumentSymbol {
+	return []packageDocumentSymbol{
+		newPackageDocumentSymbol("utils.go", 3, "(*Calculator).Reset", 6),
+		newPackageDocumentSymbol("utils.go", 1, "helper", 12),
+		newPackageDocumentSymbol("calculator.go", 10, "(*Calculator).Add", 6),
+		newPackageDocumentSymbol("calculator.go", 20, "(*Calculator).value", 6),
+		newPackageDocumentSymbol("calculator.go", 5, "Calculator", 23,
+			types.DocumentSymbol{Name: "Value", Kind: 8},
+			types.DocumentSymbol{Name: "memory", Kind: 8},
+		),
+		newPackageDocumentSymbol("calculator.go", 30, "(Missing).Method", 6),
+		newPackageDocumentSymbol("calculator_test.go", 5, "TestAdd", 12),
+	}
+}
+
+func TestGroupPackageSymbols(t *testing.T) {
+	grouped := groupPackageSymbols(testPackageDocumentSymbols())
+
+	var names []string
+	for _, entry := range grouped {
+		names = append(names, entry.symbol.Name)
+	}
+	assert.Equal(t, []string{"Calculator", "TestAdd", "helper", "(Missing).Method"}, names)
+
+	var methods []string
+	for _, method := range grouped[0].methods {
+		methods = append(methods, method.symbol.Name)
+	}
+	assert.Equal(t, []string{"(*Calculator).Add", "(*Calculator).value", "(*Calculator).Reset"}, methods)
+}
+
+func TestGroupPackageSymbols_TypeDeclarations(t *testing.T) {
+	root := t.TempDir()
+	source := "package p\n\ntype Handler func()\n\nfunc (h Handler) Serve() {}\n\nfunc handle() {}\n\ntype (\n\tCelsius float64\n)\n\nfunc (c Celsius) String() string { return \"\" }\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, "p.go"), []byte(source), 0o644))
+
+	// gopls reports named func types as functions, so types are found from their declarations
+	symbol := func(line int, name string, kind int) packageDocumentSymbol {
+		entry := newPackageDocumentSymbol("p.go", line, name, kind)
+		entry.uri = PathToUri("p.go", root)
+		return entry
+	}
+	grouped := groupPackageSymbols([]packageDocumentSymbol{
+		symbol(2, "Handler", 12),
+		symbol(4, "(Handler).Serve", 6),
+		symbol(6, "handle", 12),
+		symbol(9, "Celsius", 16),
+		symbol(12, "(Celsius).String", 6),
+	})
+
+	methods := make(map[string][]string)
+	for _, entry := range grouped {
+		for _, method := range entry.methods {
+			methods[entry.symbol.Name] = append(methods[entry.symbol.Name], method.symbol.Name)
+		}
+	}
+	assert.Len(t, grouped, 3)
+	assert.Equal(t, map[string][]string{"Handler": {"(Handler).Serve"}, "Celsius": {"(Celsius).String"}}, methods)
+}
+
+func TestFilterExportedPackageSymbols(t *testing.T) {
+	filtered := filterExportedPackageSymbols(groupPackageSymbols(testPackageDocumentSymbols()))
+
+	var names []string
+	for _, entry := range filtered {
+		names = append(names, entry.symbol.Name)
+	}
+	assert.Equal(t, []string{"Calculator", "TestAdd", "(Missing).Method"}, names)
+	assert.Len(t, filtered[0].symbol.Children, 1)
+	assert.Equal(t, "Value", filtered[0].symbol.Children[0].Name)
+	assert.Len(t, filtered[0].methods, 2)
+}
+
+func TestExportedAPI(t *testing.T) {
+	assert.Equal(t, []string{"Calculator", "Calculator.Add", "Calculator.Reset", "Calculator.Value"}, exportedAPI(testPackageDocumentSymbols()))
+}
@@ -0,0 +1,749 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+var (
+	// majorVersionPattern matches the major version suffix of an import path element, like "v2"
+	majorVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+)
+
+// SymbolMove describes a move of a top-level type or function, with the methods of a type, to another file
+type SymbolMove struct {
+	Name         string                // Name of the moved type or function
+	Declarations []TopLevelDeclaration // Declarations of the symbol and its methods
+	References   []types.Location      // References to the symbol, which may include its declaration
+	Destination  string                // Absolute path of the destination file, which may not exist yet
+}
+
+// SymbolMovePlan represents the edits of a symbol move
+type SymbolMovePlan struct {
+	Edit              types.WorkspaceEdit
+	FromPackage       string   // Import path of the package the symbol is moved from
+	ToPackage         string   // Import path of the package the symbol is moved to
+	MovedDeclarations []string // Names of the moved declarations, excluding those already in the destination file
+	UpdatedReferences int      // Number of references whose qualifiers were changed
+	EditedFiles       []string // Absolute paths of the edited files, whose imports should be organized
+}
+
+// movedBlock represents the text of a moved or deleted declaration, and the byte range it's removed from in its file
+type movedBlock struct {
+	decl       TopLevelDeclaration
+	text       string // Declaration with its doc comment, as a top-level declaration
+	start, end int    // Byte range removed from the file, with a separating blank line
+}
+
+// importSpec represents an import of a Go file
+type importSpec struct {
+	Name string // Explicit name of the import, if any
+	Path string
+}
+
+// String returns the import as it's written in an import declaration
+func (s importSpec) String() string {
+	if s.Name != "" {
+		return s.Name + " " + strconv.Quote(s.Path)
+	}
+	return strconv.Quote(s.Path)
+}
+
+// goFileInfo represents the package clause and imports of a Go file
+type goFileInfo struct {
+	content     string
+	packageName string
+	nameEnd     int          // Byte offset of the end of the package name
+	imports     []importSpec // Imports, with names inferred from the import paths if not explicit
+	explicit    []bool       // Whether each import has an explicit name
+	importDecl  *ast.GenDecl // Last import declaration, if any
+	fset        *token.FileSet
+}
+
+// PlanSymbolMove computes the edits that move a symbol to another file, updating the qualifiers of its references and
+// the imports of the edited files. Before computing the edits, checks that the move doesn't create an import cycle,
+// and that the moved declarations and the code remaining in the source package don't depend on each other's unexported names.
+// Unused imports aren't removed, so the imports of the edited files should be organized afterwards.
+func PlanSymbolMove(session *EditSession, move SymbolMove) (*SymbolMovePlan, error) {
+	if len(move.Declarations) == 0 {
+		return nil, errors.New("no declarations to move")
+	}
+	sourcePath := move.Declarations[0].Path
+	sourceDir, destDir := filepath.Dir(sourcePath), filepath.Dir(move.Destination)
+	samePackage := sourceDir == destDir
+
+	source, err := readGoFileInfo(session, sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	destPackage, err := destinationPackageName(session, move.Destination, source.packageName, samePackage)
+	if err != nil {
+		return nil, err
+	}
+	plan := &SymbolMovePlan{FromPackage: PackageImportPath(sourcePath), ToPackage: PackageImportPath(move.Destination)}
+
+	blocks, err := movedBlocks(session, move)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("%s is already declared in %s", move.Name, filepath.Base(move.Destination))
+	}
+
+	edits := newFileEdits()
+	for _, block := range blocks {
+		content, _ := session.Content(block.decl.Path)
+		edits.add(block.decl.Path, offsetTextEdit(content, block.start, block.end, ""))
+		plan.MovedDeclarations = append(plan.MovedDeclarations, block.decl.Name)
+	}
+
+	var problems []string
+	newImports := make(map[string]map[string]bool) // New imports of packages of the module, by importing package
+	addImportEdge := func(from string, to string) {
+		if newImports[from] == nil {
+			newImports[from] = make(map[string]bool)
+		}
+		newImports[from][to] = true
+	}
+
+	// Rewrite the moved declarations, qualifying the names of the source package that they use
+	moved, imports, usesSource, movedProblems, err := rewriteMovedCode(session, move.Name, blocks, source.packageName, plan.ToPackage, samePackage)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, movedProblems...)
+	if usesSource {
+		if source.packageName == "main" {
+			problems = append(problems, fmt.Sprintf("%s uses names of package main, which can't be imported", move.Name))
+		}
+		imports = append(imports, importSpec{Path: plan.FromPackage})
+	}
+	for _, spec := range imports {
+		addImportEdge(plan.ToPackage, spec.Path)
+	}
+
+	// Update the qualifiers of the references in other packages, and qualify the references in the source package
+	if !samePackage {
+		exported := token.IsExported(move.Name)
+		infos := make(map[string]*goFileInfo)
+		for _, reference := range move.References {
+			path := UriToPath(reference.URI)
+			if isReferenceInBlocks(session, blocks, path, reference.Range.Start) {
+				continue
+			}
+			info, ok := infos[path]
+			if !ok {
+				if info, err = readGoFileInfo(session, path); err != nil {
+					return nil, err
+				}
+				infos[path] = info
+			}
+
+			file, _ := GetDisplayPath(path, session.workspaceRoot)
+			location := fmt.Sprintf("%s:%d:%d", file, reference.Range.Start.Line+1, reference.Range.Start.Character+1)
+			offset, err := positionOffset(info.content, lineOffsets(info.content), reference.Range.Start)
+			if err != nil {
+				return nil, err
+			}
+			qualifier, qualifierStart := referenceQualifier(info.content, offset)
+			refDir := filepath.Dir(path)
+
+			switch {
+			case refDir == destDir && info.packageName == destPackage:
+				if qualifier != "" {
+					edits.add(path, offsetTextEdit(info.content, qualifierStart, offset, ""))
+				}
+			case refDir == sourceDir && info.packageName == source.packageName:
+				if !exported {
+					problems = append(problems, fmt.Sprintf("%s is unexported, but it's referenced at %s, which stays in package %s", move.Name, location, source.packageName))
+					continue
+				}
+				if destPackage == "main" {
+					problems = append(problems, fmt.Sprintf("%s is referenced at %s, but package main can't be imported", move.Name, location))
+					continue
+				}
+				edits.add(path, offsetTextEdit(info.content, offset, offset, info.qualifierFor(plan.ToPackage, destPackage)+"."))
+				edits.addImport(path, info, importSpec{Path: plan.ToPackage})
+				if !IsTestFile(path) {
+					addImportEdge(plan.FromPackage, plan.ToPackage)
+				}
+			default:
+				if qualifier == "" {
+					problems = append(problems, fmt.Sprintf("the reference at %s isn't qualified by the package name, e.g. because of a dot import", location))
+					continue
+				}
+				if destPackage == "main" {
+					problems = append(problems, fmt.Sprintf("%s is referenced at %s, but package main can't be imported", move.Name, location))
+					continue
+				}
+				edits.add(path, offsetTextEdit(info.content, qualifierStart, offset, info.qualifierFor(plan.ToPackage, destPackage)+"."))
+				edits.addImport(path, info, importSpec{Path: plan.ToPackage})
+				if importPath := PackageImportPath(path); !IsTestFile(path) && importPath != "" {
+					addImportEdge(importPath, plan.ToPackage)
+				}
+			}
+			plan.UpdatedReferences++
+		}
+	}
+
+	if !samePackage && len(newImports) > 0 {
+		if cycle, err := findNewImportCycle(move.Destination, plan.ToPackage, newImports); err != nil {
+			return nil, err
+		} else if cycle != nil {
+			problems = append(problems, fmt.Sprintf("the move would create an import cycle: %s", strings.Join(cycle, " -> ")))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("can't move %s: %s", move.Name, strings.Join(problems, "; "))
+	}
+
+	// Append the moved declarations to the destination file, creating it if needed
+	if content, err := session.Content(move.Destination); err == nil {
+		info, err := parseGoFileInfo(move.Destination, content)
+		if err != nil {
+			return nil, err
+		}
+		separator := "\n"
+		if !strings.HasSuffix(content, "\n") {
+			separator = "\n\n"
+		}
+		for _, spec := range imports {
+			edits.addImport(move.Destination, info, spec)
+		}
+		edits.add(move.Destination, offsetTextEdit(content, len(content), len(content), separator+moved+"\n"))
+	} else {
+		var header strings.Builder
+		header.WriteString("package " + destPackage + "\n\n")
+		if len(imports) > 0 {
+			header.WriteString("import (\n")
+			for _, spec := range imports {
+				header.WriteString("\t" + spec.String() + "\n")
+			}
+			header.WriteString(")\n\n")
+		}
+		edits.create(move.Destination, header.String()+moved+"\n")
+	}
+
+	plan.Edit = edits.workspaceEdit()
+	plan.EditedFiles = edits.order
+	return plan, nil
+}
+
+// destinationPackageName returns the package name of the destination file of a move, from its package clause, the other files of its
+// directory, or the name of its directory
+func destinationPackageName(session *EditSession, destination string, sourcePackage string, samePackage bool) (string, error) {
+	if content, err := session.Content(destination); err == nil {
+		info, err := parseGoFileInfo(destination, content)
+		if err != nil {
+			return "", err
+		}
+		return info.packageName, nil
+	}
+	if samePackage {
+		return sourcePackage, nil
+	}
+
+	files, _ := ListPackageFiles(filepath.Dir(destination), false)
+	if name, _ := PackageDoc(files); name != "" {
+		return name, nil
+	}
+	name := filepath.Base(filepath.Dir(destination))
+	if !IsValidGoIdentifier(name) {
+		return "", fmt.Errorf("can't infer the package name of %s from its directory; create the file with a package clause first", destination)
+	}
+	return name, nil
+}
+
+// movedBlocks returns the blocks of the moved declarations that aren't already in the destination file, sorted by file and offset
+func movedBlocks(session *EditSession, move SymbolMove) ([]movedBlock, error) {
+	var decls []TopLevelDeclaration
+	for _, decl := range move.Declarations {
+		if decl.Path != move.Destination {
+			decls = append(decls, decl)
+		}
+	}
+	return declarationBlocks(session, decls)
+}
+
+// declarationBlocks returns the blocks of declarations, sorted by file and offset
+func declarationBlocks(session *EditSession, decls []TopLevelDeclaration) ([]movedBlock, error) {
+	var blocks []movedBlock
+	for _, decl := range decls {
+		content, err := session.Content(decl.Path)
+		if err != nil {
+			return nil, err
+		}
+		block, err := declarationBlock(content, decl)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if blocks[i].decl.Path != blocks[j].decl.Path {
+			return blocks[i].decl.Path < blocks[j].decl.Path
+		}
+		return blocks[i].start < blocks[j].start
+	})
+	// Adjacent blocks may both remove the blank line between them
+	for i := 1; i < len(blocks); i++ {
+		if blocks[i].decl.Path == blocks[i-1].decl.Path && blocks[i].start < blocks[i-1].end {
+			blocks[i].start = blocks[i-1].end
+		}
+	}
+	return blocks, nil
+}
+
+// declarationBlock returns the block of a declaration: its text with its doc comment, and the range of lines it's removed from.
+// Specs of grouped declarations are moved as separate declarations.
+func declarationBlock(content string, decl TopLevelDeclaration) (movedBlock, error) {
+	lineStarts := lineOffsets(content)
+	start, err := positionOffset(content, lineStarts, decl.Range.Start)
+	if err != nil {
+		return movedBlock{}, err
+	}
+	end, err := positionOffset(content, lineStarts, decl.Range.End)
+	if err != nil {
+		return movedBlock{}, err
+	}
+
+	lineStart := strings.LastIndex(content[:start], "\n") + 1
+	grouped := false
+	switch prefix := strings.TrimSpace(content[lineStart:start]); {
+	case prefix == decl.Keyword && decl.Keyword != "":
+	case prefix == "":
+		grouped = decl.Keyword != "" && !isKeywordAt(content, start, decl.Keyword)
+	default:
+		return movedBlock{}, fmt.Errorf("the declaration of %s doesn't start its line", decl.Name)
+	}
+	lineEnd := len(content)
+	if i := strings.IndexByte(content[end:], '\n'); i >= 0 {
+		lineEnd = end + i
+	}
+
+	// Include the doc comment
+	docStart := lineStart
+	for docStart > 0 {
+		previous := strings.LastIndex(content[:docStart-1], "\n") + 1
+		if !strings.HasPrefix(strings.TrimSpace(content[previous:docStart-1]), "//") {
+			break
+		}
+		docStart = previous
+	}
+
+	block := movedBlock{decl: decl, text: content[docStart:lineEnd], start: docStart, end: min(lineEnd+1, len(content))}
+	if grouped {
+		block.text = dedent(content[docStart:lineStart]) + decl.Keyword + " " + dedent(content[start:lineEnd])
+	}
+
+	// Remove a blank line after the declaration, or else before it, so that blank lines don't pile up
+	if next := strings.IndexByte(content[block.end:], '\n'); next >= 0 && strings.TrimSpace(content[block.end:block.end+next]) == "" {
+		block.end += next + 1
+	} else if block.start > 0 {
+		previous := strings.LastIndex(content[:block.start-1], "\n") + 1
+		if strings.TrimSpace(content[previous:block.start-1]) == "" {
+			block.start = previous
+		}
+	}
+	return block, nil
+}
+
+// isKeywordAt checks if a keyword is at a byte offset of content
+func isKeywordAt(content string, offset int, keyword string) bool {
+	rest := content[offset:]
+	if !strings.HasPrefix(rest, keyword) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(rest[len(keyword):])
+	return unicode.IsSpace(r) || r == '('
+}
+
+// dedent removes one level of tab indentation from each line of text
+func dedent(text string) string {
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, "\t")
+	}
+	return strings.Join(lines, "")
+}
+
+// rewriteMovedCode joins the texts of moved blocks, qualifying the names of the source package that they use and removing the
+// qualifiers of the destination package. Returns the moved code, the imports it needs, whether it uses the source package,
+// and the problems that prevent the move, like uses of unexported names of the source package.
+func rewriteMovedCode(session *EditSession, name string, blocks []movedBlock, sourcePackage string, destImportPath string, samePackage bool) (string, []importSpec, bool, []string, error) {
+	header := "package " + sourcePackage + "\n\n"
+	var code strings.Builder
+	code.WriteString(header)
+	blockStarts := make([]int, len(blocks))
+	for i, block := range blocks {
+		if i > 0 {
+			code.WriteString("\n\n")
+		}
+		blockStarts[i] = code.Len()
+		code.WriteString(block.text)
+	}
+	content := code.String()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "moved.go", content, parser.ParseComments)
+	if err != nil {
+		return "", nil, false, nil, fmt.Errorf("failed to parse the moved declarations: %w", err)
+	}
+	blockPath := func(pos token.Pos) string {
+		offset := fset.Position(pos).Offset
+		i := sort.SearchInts(blockStarts, offset+1) - 1
+		return blocks[max(i, 0)].decl.Path
+	}
+
+	infos := make(map[string]*goFileInfo)
+	fileInfo := func(path string) (*goFileInfo, error) {
+		if info, ok := infos[path]; ok {
+			return info, nil
+		}
+		info, err := readGoFileInfo(session, path)
+		infos[path] = info
+		return info, err
+	}
+	sourceNames, err := packageLevelNames(session, filepath.Dir(blocks[0].decl.Path), sourcePackage)
+	if err != nil {
+		return "", nil, false, nil, err
+	}
+
+	unresolved := make(map[*ast.Ident]bool)
+	for _, ident := range file.Unresolved {
+		unresolved[ident] = true
+	}
+
+	type offsetEdit struct {
+		start, end int
+		text       string
+	}
+	var edits []offsetEdit
+	qualifiers := make(map[*ast.Ident]bool)
+	importsByPath := make(map[string]importSpec)
+	var inspectErr error
+	ast.Inspect(file, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return inspectErr == nil
+		}
+		ident, ok := selector.X.(*ast.Ident)
+		if !ok || !unresolved[ident] {
+			return true
+		}
+		info, err := fileInfo(blockPath(ident.Pos()))
+		if err != nil {
+			inspectErr = err
+			return false
+		}
+		spec, ok := info.importNamed(ident.Name)
+		if !ok {
+			return true
+		}
+		qualifiers[ident] = true
+		if spec.Path == destImportPath && !samePackage {
+			edits = append(edits, offsetEdit{start: fset.Position(ident.Pos()).Offset, end: fset.Position(selector.Sel.Pos()).Offset})
+		} else {
+			importsByPath[spec.Path] = spec
+		}
+		return true
+	})
+	if inspectErr != nil {
+		return "", nil, false, nil, inspectErr
+	}
+
+	usesSource := false
+	var problems []string
+	reported := make(map[string]bool)
+	for _, ident := range file.Unresolved {
+		if samePackage || qualifiers[ident] || ident.Name == name || !sourceNames[ident.Name] {
+			continue
+		}
+		if !token.IsExported(ident.Name) {
+			if !reported[ident.Name] {
+				reported[ident.Name] = true
+				problems = append(problems, fmt.Sprintf("the moved declarations use %s, which is unexported and stays in package %s", ident.Name, sourcePackage))
+			}
+			continue
+		}
+		offset := fset.Position(ident.Pos()).Offset
+		edits = append(edits, offsetEdit{start: offset, end: offset, text: sourcePackage + "."})
+		usesSource = true
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for _, edit := range edits {
+		content = content[:edit.start] + edit.text + content[edit.end:]
+	}
+
+	imports := make([]importSpec, 0, len(importsByPath))
+	for _, spec := range importsByPath {
+		imports = append(imports, spec)
+	}
+	sort.Slice(imports, func(i, j int) bool { return imports[i].Path < imports[j].Path })
+	return strings.TrimPrefix(content, header), imports, usesSource, problems, nil
+}
+
+// packageLevelNames returns the names of the package-level declarations of the non-test files of a package
+func packageLevelNames(session *EditSession, dir string, packageName string) (map[string]bool, error) {
+	files, err := ListPackageFiles(dir, false)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for _, path := range files {
+		content, err := session.Content(path)
+		if err != nil {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, content, parser.SkipObjectResolution)
+		if err != nil || file.Name.Name != packageName {
+			continue
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					names[decl.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						names[spec.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, ident := range spec.Names {
+							names[ident.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+// isReferenceInBlocks checks if a reference is inside one of the blocks of moved or deleted declarations
+func isReferenceInBlocks(session *EditSession, blocks []movedBlock, path string, position types.Position) bool {
+	for _, block := range blocks {
+		if block.decl.Path != path {
+			continue
+		}
+		content, err := session.Content(path)
+		if err != nil {
+			return false
+		}
+		if offset, err := positionOffset(content, lineOffsets(content), position); err == nil && block.start <= offset && offset < block.end {
+			return true
+		}
+	}
+	return false
+}
+
+// referenceQualifier returns the package qualifier of the reference at a byte offset, like "calc" in "calc.Add",
+// and the offset where the qualifier starts, or an empty qualifier if the reference isn't qualified
+func referenceQualifier(content string, offset int) (string, int) {
+	if offset == 0 || content[offset-1] != '.' {
+		return "", offset
+	}
+	start := offset - 1
+	for start > 0 {
+		r, size := utf8.DecodeLastRuneInString(content[:start])
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		start -= size
+	}
+	if start == offset-1 {
+		return "", offset
+	}
+	return content[start : offset-1], start
+}
+
+// findNewImportCycle finds an import cycle through the destination package of a move, after adding new imports to the import graph of its module
+func findNewImportCycle(destination string, destImportPath string, newImports map[string]map[string]bool) ([]string, error) {
+	modulePath, moduleDir, err := FindModule(filepath.Dir(destination))
+	if err != nil {
+		return nil, err
+	}
+	graph, err := ModuleImportGraph(moduleDir, modulePath)
+	if err != nil {
+		return nil, err
+	}
+	for from, imports := range newImports {
+		for to := range imports {
+			if to != from && (to == modulePath || strings.HasPrefix(to, modulePath+"/")) {
+				graph[from] = append(graph[from], to)
+			}
+		}
+		sort.Strings(graph[from])
+	}
+	return FindImportCycle(graph, destImportPath), nil
+}
+
+// readGoFileInfo reads the package clause and imports of a Go file in an edit session
+func readGoFileInfo(session *EditSession, path string) (*goFileInfo, error) {
+	content, err := session.Content(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseGoFileInfo(path, content)
+}
+
+// parseGoFileInfo parses the package clause and imports of a Go file
+func parseGoFileInfo(path string, content string) (*goFileInfo, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	info := &goFileInfo{content: content, packageName: file.Name.Name, nameEnd: fset.Position(file.Name.End()).Offset, fset: fset}
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		imported := importSpec{Path: importPath, Name: importName(importPath)}
+		if spec.Name != nil {
+			imported.Name = spec.Name.Name
+		}
+		info.imports = append(info.imports, imported)
+		info.explicit = append(info.explicit, spec.Name != nil)
+	}
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			info.importDecl = genDecl
+		}
+	}
+	return info, nil
+}
+
+// importName guesses the package name of an import path from its last element, skipping major version suffixes like "v2"
+func importName(importPath string) string {
+	name := path.Base(importPath)
+	if majorVersionPattern.MatchString(name) && strings.Contains(importPath, "/") {
+		name = path.Base(path.Dir(importPath))
+	}
+	name, _, _ = strings.Cut(name, ".")
+	name = strings.TrimPrefix(name, "go-")
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// importNamed returns the import of a file that a qualifier refers to
+func (f *goFileInfo) importNamed(name string) (importSpec, bool) {
+	for i, spec := range f.imports {
+		if spec.Name == name {
+			if !f.explicit[i] {
+				spec.Name = ""
+			}
+			return spec, true
+		}
+	}
+	return importSpec{}, false
+}
+
+// qualifierFor returns the qualifier of a package in a file: the name of its import if the file already imports it, or else its package name
+func (f *goFileInfo) qualifierFor(importPath string, packageName string) string {
+	for i, spec := range f.imports {
+		if spec.Path == importPath && f.explicit[i] && spec.Name != "_" && spec.Name != "." {
+			return spec.Name
+		}
+	}
+	return packageName
+}
+
+// hasImport checks if a file imports a package, other than for side effects
+func (f *goFileInfo) hasImport(importPath string) bool {
+	for _, spec := range f.imports {
+		if spec.Path == importPath && spec.Name != "_" {
+			return true
+		}
+	}
+	return false
+}
+
+// importEdit returns the text edit that adds an import to a file
+func (f *goFileInfo) importEdit(spec importSpec) types.TextEdit {
+	switch {
+	case f.importDecl == nil:
+		return offsetTextEdit(f.content, f.nameEnd, f.nameEnd, "\n\nimport "+spec.String())
+	case f.importDecl.Rparen.IsValid():
+		offset := f.fset.Position(f.importDecl.Rparen).Offset
+		return offsetTextEdit(f.content, offset, offset, "\t"+spec.String()+"\n")
+	default:
+		offset := f.fset.Position(f.importDecl.End()).Offset
+		return offsetTextEdit(f.content, offset, offset, "\nimport "+spec.String())
+	}
+}
+
+// fileEdits collects the text edits of files, in the order the files are first edited
+type fileEdits struct {
+	edits   map[string][]types.TextEdit
+	created map[string]bool
+	imports map[string]map[string]bool // Imports added to each file, by import path
+	order   []string
+}
+
+// newFileEdits creates an empty collection of file edits
+func newFileEdits() *fileEdits {
+	return &fileEdits{
+		edits:   make(map[string][]types.TextEdit),
+		created: make(map[string]bool),
+		imports: make(map[string]map[string]bool),
+	}
+}
+
+// add adds a text edit of a file
+func (e *fileEdits) add(path string, edit types.TextEdit) {
+	if _, ok := e.edits[path]; !ok {
+		e.order = append(e.order, path)
+	}
+	e.edits[path] = append(e.edits[path], edit)
+}
+
+// create adds a new file with content
+func (e *fileEdits) create(path string, content string) {
+	e.created[path] = true
+	e.add(path, types.TextEdit{NewText: content})
+}
+
+// addImport adds an import to a file, unless the file already imports the package
+func (e *fileEdits) addImport(path string, info *goFileInfo, spec importSpec) {
+	if info.hasImport(spec.Path) || e.imports[path][spec.Path] {
+		return
+	}
+	if e.imports[path] == nil {
+		e.imports[path] = make(map[string]bool)
+	}
+	e.imports[path][spec.Path] = true
+	e.add(path, info.importEdit(spec))
+}
+
+// workspaceEdit returns the workspace edit that applies the collected edits
+func (e *fileEdits) workspaceEdit() types.WorkspaceEdit {
+	var edit types.WorkspaceEdit
+	for _, path := range e.order {
+		uri := PathToUri(path, "")
+		if e.created[path] {
+			edit.DocumentChanges = append(edit.DocumentChanges, types.DocumentChange{Kind: types.ResourceOperationCreate, URI: uri})
+		}
+		edit.DocumentChanges = append(edit.DocumentChanges, textDocumentChange(uri, e.edits[path]))
+	}
+	return edit
+}
@@ -0,0 +1,234 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MoveSymbolTool handles move symbol requests
+type MoveSymbolTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewMoveSymbolTool creates a new move symbol tool
+func NewMoveSymbolTool(client types.Client, config types.Config) *MoveSymbolTool {
+	return &MoveSymbolTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *MoveSymbolTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Move a top-level type or function to another file, in the same package or in another package, updating imports and package qualifiers of its references everywhere. The methods of a type are moved with it. Moves that would create an import cycle or break references to unexported names are refused before anything is changed. Returns the LSP workspace edit and a unified diff of each changed file; use dry_run to preview the changes without writing them."),
+		},
+		positionToolOptions()...,
+	)
+	options = append(options,
+		mcp.WithString("destination", mcp.Required(), mcp.Description("Path to the destination Go file, relative to the workspace root or absolute. The file is created if it doesn't exist; its directory determines the destination package.")),
+		mcp.WithBoolean("dry_run", mcp.Description("Whether to only preview the changes, without writing them to disk (default: false)")),
+	)
+	tool := mcp.NewTool("move_symbol", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *MoveSymbolTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	destination := mcp.ParseString(req, "destination", "")
+	if destination == "" {
+		slog.Debug("MCP tool called with missing destination parameter", "tool", "move_symbol")
+		return mcp.NewToolResultError("destination parameter is required"), nil
+	}
+
+	position, err := parsePositionArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid position parameters", "tool", "move_symbol", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	dryRun := mcp.ParseBoolean(req, "dry_run", false)
+
+	slog.Debug("MCP tool called",
+		"tool", "move_symbol",
+		"symbol_anchor", position.anchor,
+		"file_path", position.filePath,
+		"line", position.line,
+		"column", position.column,
+		"destination", destination,
+		"dry_run", dryRun)
+
+	destPath := destination
+	if !filepath.IsAbs(destPath) {
+		destPath = filepath.Join(t.config.WorkspaceRoot, destPath)
+	}
+	destPath = filepath.Clean(destPath)
+	switch {
+	case !strings.HasSuffix(destPath, ".go"):
+		return mcp.NewToolResultError(fmt.Sprintf("The destination %s isn't a Go file.", destination)), nil
+	case IsTestFile(destPath):
+		return mcp.NewToolResultError(fmt.Sprintf("The destination %s is a test file; symbols can only be moved to non-test files.", destination)), nil
+	case IsReadOnlyFile(destPath):
+		return mcp.NewToolResultError(fmt.Sprintf("The destination %s is read-only.", destination)), nil
+	}
+
+	resolved, err := position.resolveForEdit(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve position",
+			"tool", "move_symbol",
+			"symbol_anchor", position.anchor,
+			"error", err)
+		return mcp.NewToolResultError(position.resolveErrorMessage(err)), nil
+	}
+
+	definitions, err := t.client.GoToDefinition(ctx, resolved.URI, resolved.Position)
+	if err != nil || len(definitions) == 0 {
+		slog.Debug("Failed to find declaration",
+			"tool", "move_symbol",
+			"uri", resolved.URI,
+			"error", err)
+		return mcp.NewToolResultError("No declaration found. The position should be on the name of a top-level type or function."), nil
+	}
+	definition := definitions[0]
+	if IsReadOnlyFile(UriToPath(definition.URI)) {
+		return mcp.NewToolResultError(fmt.Sprintf("The symbol is declared in %s, which is read-only.", UriToPath(definition.URI))), nil
+	}
+
+	move, err := t.symbolMove(ctx, definition, destPath)
+	if err != nil {
+		slog.Debug("Failed to find moved declarations",
+			"tool", "move_symbol",
+			"uri", definition.URI,
+			"error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	move.References, err = t.client.FindReferences(ctx, definition.URI, definition.Range.Start)
+	if err != nil {
+		slog.Error("Failed to find references",
+			"tool", "move_symbol",
+			"uri", definition.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to find references of %s: %v", move.Name, err)), nil
+	}
+
+	session := NewEditSession(t.config.WorkspaceRoot)
+	plan, err := PlanSymbolMove(session, *move)
+	if err != nil {
+		slog.Debug("Failed to plan move",
+			"tool", "move_symbol",
+			"symbol", move.Name,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to move %s: %v", move.Name, err)), nil
+	}
+	if err := session.ApplyWorkspaceEdit(plan.Edit); err != nil {
+		slog.Error("Failed to apply move edits",
+			"tool", "move_symbol",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to move %s: %v", move.Name, err)), nil
+	}
+	for _, path := range plan.EditedFiles {
+		if err := FormatFile(ctx, t.client, session, path, true, dryRun); err != nil {
+			slog.Error("Failed to organize imports",
+				"tool", "move_symbol",
+				"path", path,
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to organize the imports of %s: %v", path, err)), nil
+		}
+	}
+
+	changes := session.Changes()
+	workspaceEdit := session.WorkspaceEdit()
+	if !dryRun && len(changes) > 0 {
+		if err := WriteEditSession(ctx, t.client, session); err != nil {
+			slog.Error("Failed to write move",
+				"tool", "move_symbol",
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write the move: %v", err)), nil
+		}
+	}
+
+	destFile, _ := GetDisplayPath(destPath, t.config.WorkspaceRoot)
+	toolResult := results.MoveSymbolToolResult{
+		Arguments: results.MoveSymbolToolArgs{
+			SymbolAnchor: position.anchor,
+			FilePath:     position.filePath,
+			Line:         position.line,
+			Column:       position.column,
+			Destination:  destination,
+			DryRun:       dryRun,
+		},
+		Symbol:            move.Name,
+		MovedDeclarations: plan.MovedDeclarations,
+		FromPackage:       plan.FromPackage,
+		ToPackage:         plan.ToPackage,
+		Destination:       destFile,
+		UpdatedReferences: plan.UpdatedReferences,
+		Applied:           !dryRun && len(changes) > 0,
+		WorkspaceEdit:     workspaceEdit,
+		FileChanges:       make([]results.FileChange, 0, len(changes)),
+	}
+	toolResult.FileChanges = append(toolResult.FileChanges, changes...)
+
+	if dryRun {
+		toolResult.Message = fmt.Sprintf("Moving %s (%d declarations) to %s would update %d references and change %d files. No changes were written (dry run).", move.Name, len(plan.MovedDeclarations), destFile, plan.UpdatedReferences, len(changes))
+	} else {
+		toolResult.Message = fmt.Sprintf("Moved %s (%d declarations) to %s, updating %d references and changing %d files.", move.Name, len(plan.MovedDeclarations), destFile, plan.UpdatedReferences, len(changes))
+	}
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "move_symbol",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "move_symbol",
+		"moved_declaration_count", len(plan.MovedDeclarations),
+		"updated_reference_count", plan.UpdatedReferences,
+		"file_change_count", len(toolResult.FileChanges),
+		"applied", toolResult.Applied,
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// symbolMove finds the declarations of the top-level type or function declared at a location, with the methods of a type
+// declared in the files of its package
+func (t *MoveSymbolTool) symbolMove(ctx context.Context, definition types.Location, destination string) (*SymbolMove, error) {
+	samePackage := filepath.Dir(UriToPath(definition.URI)) == filepath.Dir(destination)
+	decls, err := FindSymbolDeclarations(ctx, t.client, definition, !samePackage)
+	if err != nil {
+		return nil, fmt.Errorf("%w; only top-level types and functions can be moved", err)
+	}
+
+	switch decls.Kind {
+	case results.SymbolKindMethod:
+		return nil, fmt.Errorf("%s is a method; move its receiver type instead, which moves its methods too", decls.Name)
+	case results.SymbolKindConstant, results.SymbolKindVariable:
+		return nil, fmt.Errorf("%s is a %s; only top-level types and functions can be moved", decls.Name, decls.Kind)
+	}
+	if main := decls.Declarations[0]; IsTestFile(main.Path) {
+		return nil, fmt.Errorf("%s is declared in the test file %s; only symbols declared in non-test files can be moved", decls.Name, filepath.Base(main.Path))
+	}
+	// Methods in test files are only found for moves to another package, where they can't follow their type
+	for _, decl := range decls.Declarations[1:] {
+		if IsTestFile(decl.Path) {
+			return nil, fmt.Errorf("method %s is declared in the test file %s, and methods must be declared in the package of their type, so %s can't be moved to another package", decl.Name, filepath.Base(decl.Path), decls.Name)
+		}
+	}
+	return &SymbolMove{Name: decls.Name, Declarations: decls.Declarations, Destination: destination}, nil
+}
@@ -0,0 +1,388 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const moveShapesSource = `package shapes
+
+import "fmt"
+
+// Square is a square
+type Square struct {
+	Side float64
+}
+
+// Area returns the area of the square
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+func (s *Square) String() string {
+	return fmt.Sprintf("square %v", s.Side)
+}
+
+// Describe describes a square
+func Describe(s Square) string {
+	return s.String()
+}
+`
+
+const moveMainSource = `package main
+
+import "example.com/m/shapes"
+
+func main() {
+	println(shapes.Describe(shapes.Square{Side: 2}))
+}
+`
+
+// writeMoveModule writes a module with a shapes package and a main package, returning its root directory
+func writeMoveModule(t *testing.T, shapes string) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":           "module example.com/m\n\ngo 1.23\n",
+		"main.go":          moveMainSource,
+		"shapes/shapes.go": shapes,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return root
+}
+
+// textRange returns the range of content from the first occurrence of start to the end of the following occurrence of end
+func textRange(t *testing.T, content string, start string, end string) types.Range {
+	startOffset := strings.Index(content, start)
+	require.GreaterOrEqual(t, startOffset, 0, "missing %q", start)
+	endOffset := strings.Index(content[startOffset:], end)
+	require.GreaterOrEqual(t, endOffset, 0, "missing %q", end)
+	return types.Range{Start: offsetPosition(content, startOffset), End: offsetPosition(content, startOffset+endOffset+len(end))}
+}
+
+// textLocation returns the location of the nth occurrence (starting at 0) of text in a file
+func textLocation(t *testing.T, path string, content string, text string, n int) types.Location {
+	offset := -1
+	for i := 0; i <= n; i++ {
+		next := strings.Index(content[offset+1:], text)
+		require.GreaterOrEqual(t, next, 0, "missing occurrence %d of %q", n, text)
+		offset += next + 1
+	}
+	position := offsetPosition(content, offset)
+	return types.Location{URI: PathToUri(path, ""), Range: types.Range{Start: position, End: position}}
+}
+
+// squareMove returns the move of Square in a module written by writeMoveModule to a destination relative to its root
+func squareMove(t *testing.T, root string, shapes string, destination string) SymbolMove {
+	shapesPath := filepath.Join(root, "shapes", "shapes.go")
+	mainPath := filepath.Join(root, "main.go")
+	return SymbolMove{
+		Name: "Square",
+		Declarations: []TopLevelDeclaration{
+			{Name: "Square", Path: shapesPath, Range: textRange(t, shapes, "Square struct", "}"), Keyword: "type"},
+			{Name: "(Square).Area", Path: shapesPath, Range: textRange(t, shapes, "func (s Square)", "\n}")},
+			{Name: "(*Square).String", Path: shapesPath, Range: textRange(t, shapes, "func (s *Square)", "\n}")},
+		},
+		References: []types.Location{
+			textLocation(t, shapesPath, shapes, "Square", 1), // Declaration
+			textLocation(t, shapesPath, shapes, "Square", 2), // Area receiver
+			textLocation(t, shapesPath, shapes, "Square", 3), // String receiver
+			textLocation(t, shapesPath, shapes, "Square", 4), // Describe parameter
+			textLocation(t, mainPath, moveMainSource, "Square", 0),
+		},
+		Destination: filepath.Join(root, destination),
+	}
+}
+
+// applyMovePlan applies the edit of a move plan to an edit session, returning the edited content of each file relative to root
+func applyMovePlan(t *testing.T, session *EditSession, root string, plan *SymbolMovePlan) map[string]string {
+	require.NoError(t, session.ApplyWorkspaceEdit(plan.Edit))
+	contents := make(map[string]string)
+	for _, path := range plan.EditedFiles {
+		content, err := session.Content(path)
+		require.NoError(t, err)
+		contents[GetRelativePath(path, root)] = content
+	}
+	return contents
+}
+
+func TestPlanSymbolMoveToOtherPackage(t *testing.T) {
+	root := writeMoveModule(t, moveShapesSource)
+	session := NewEditSession(root)
+
+	plan, err := PlanSymbolMove(session, squareMove(t, root, moveShapesSource, "geo/square.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/m/shapes", plan.FromPackage)
+	assert.Equal(t, "example.com/m/geo", plan.ToPackage)
+	assert.Equal(t, []string{"Square", "(Square).Area", "(*Square).String"}, plan.MovedDeclarations)
+	assert.Equal(t, 2, plan.UpdatedReferences)
+	require.NotEmpty(t, plan.Edit.DocumentChanges)
+
+	contents := applyMovePlan(t, session, root, plan)
+	// Unused imports are removed when organizing imports afterwards
+	assert.Equal(t, `package shapes
+
+import "fmt"
+import "example.com/m/geo"
+
+// Describe describes a square
+func Describe(s geo.Square) string {
+	return s.String()
+}
+`, contents["shapes/shapes.go"])
+	assert.Equal(t, `package main
+
+import "example.com/m/shapes"
+import "example.com/m/geo"
+
+func main() {
+	println(shapes.Describe(geo.Square{Side: 2}))
+}
+`, contents["main.go"])
+	assert.Equal(t, `package geo
+
+import (
+	"fmt"
+)
+
+// Square is a square
+type Square struct {
+	Side float64
+}
+
+// Area returns the area of the square
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+func (s *Square) String() string {
+	return fmt.Sprintf("square %v", s.Side)
+}
+`, contents["geo/square.go"])
+}
+
+func TestPlanSymbolMoveToSamePackage(t *testing.T) {
+	root := writeMoveModule(t, moveShapesSource)
+	square := "package shapes\n\nconst Sides = 4\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, "shapes", "square.go"), []byte(square), 0o644))
+	session := NewEditSession(root)
+
+	plan, err := PlanSymbolMove(session, squareMove(t, root, moveShapesSource, "shapes/square.go"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, plan.UpdatedReferences)
+
+	contents := applyMovePlan(t, session, root, plan)
+	assert.Equal(t, `package shapes
+
+import "fmt"
+
+// Describe describes a square
+func Describe(s Square) string {
+	return s.String()
+}
+`, contents["shapes/shapes.go"])
+	assert.Equal(t, `package shapes
+
+import "fmt"
+
+const Sides = 4
+
+// Square is a square
+type Square struct {
+	Side float64
+}
+
+// Area returns the area of the square
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+func (s *Square) String() string {
+	return fmt.Sprintf("square %v", s.Side)
+}
+`, contents["shapes/square.go"])
+	assert.NotContains(t, contents, "main.go")
+}
+
+func TestPlanSymbolMoveProblems(t *testing.T) {
+	tests := []struct {
+		name        string
+		shapes      string
+		destination string
+		error       string
+	}{
+		{
+			name:        "Unexported dependency",
+			shapes:      strings.Replace(moveShapesSource, "s.Side * s.Side", "scale * s.Side * s.Side", 1) + "\nvar scale = 1.0\n",
+			destination: "geo/square.go",
+			error:       "the moved declarations use scale, which is unexported and stays in package shapes",
+		},
+		{
+			name:        "Import cycle",
+			shapes:      strings.Replace(moveShapesSource, "s.Side * s.Side", "Scale * s.Side * s.Side", 1) + "\nvar Scale = 1.0\n",
+			destination: "geo/square.go",
+			error:       "import cycle: example.com/m/geo -> example.com/m/shapes -> example.com/m/geo",
+		},
+		{
+			name:        "Package main",
+			shapes:      moveShapesSource,
+			destination: "cmd/square.go",
+			error:       "package main can't be imported",
+		},
+		{
+			name:        "Already in destination",
+			shapes:      moveShapesSource,
+			destination: "shapes/shapes.go",
+			error:       "already declared in shapes.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeMoveModule(t, tt.shapes)
+			require.NoError(t, os.MkdirAll(filepath.Join(root, "cmd"), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(root, "cmd", "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644))
+
+			_, err := PlanSymbolMove(NewEditSession(root), squareMove(t, root, tt.shapes, tt.destination))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.error)
+		})
+	}
+}
+
+func TestDeclarationBlock(t *testing.T) {
+	content := "package p\n\ntype (\n\t// A is a\n\tA int\n\tB string\n)\n\n// F does nothing\nfunc F() {}\n\nvar x = 1\n"
+
+	tests := []struct {
+		name         string
+		decl         TopLevelDeclaration
+		expectedText string
+		expectedLeft string
+	}{
+		{
+			name:         "Grouped type",
+			decl:         TopLevelDeclaration{Name: "A", Range: textRange(t, content, "A int", "int"), Keyword: "type"},
+			expectedText: "// A is a\ntype A int",
+			expectedLeft: "package p\n\ntype (\n\tB string\n)\n\n// F does nothing\nfunc F() {}\n\nvar x = 1\n",
+		},
+		{
+			name:         "Function with doc comment",
+			decl:         TopLevelDeclaration{Name: "F", Range: textRange(t, content, "func F", "}")},
+			expectedText: "// F does nothing\nfunc F() {}",
+			expectedLeft: "package p\n\ntype (\n\t// A is a\n\tA int\n\tB string\n)\n\nvar x = 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block, err := declarationBlock(content, tt.decl)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedText, block.text)
+			assert.Equal(t, tt.expectedLeft, content[:block.start]+content[block.end:])
+		})
+	}
+}
+
+func TestReferenceQualifier(t *testing.T) {
+	content := "x := calc.Add(y) + Sub(z)"
+
+	qualifier, start := referenceQualifier(content, strings.Index(content, "Add"))
+	assert.Equal(t, "calc", qualifier)
+	assert.Equal(t, strings.Index(content, "calc"), start)
+
+	qualifier, start = referenceQualifier(content, strings.Index(content, "Sub"))
+	assert.Empty(t, qualifier)
+	assert.Equal(t, strings.Index(content, "Sub"), start)
+}
+
+func TestFindImportCycle(t *testing.T) {
+	graph := map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+		"d": {"a"},
+		"e": {"e"},
+	}
+	assert.Equal(t, []string{"a", "b", "d", "a"}, FindImportCycle(graph, "a"))
+	assert.Equal(t, []string{"d", "a", "b", "d"}, FindImportCycle(graph, "d"))
+	assert.Equal(t, []string{"e", "e"}, FindImportCycle(graph, "e"))
+	assert.Nil(t, FindImportCycle(map[string][]string{"a": {"b"}}, "a"))
+}
+
+// documentSymbolsClient is a fake client that returns fixed document symbols by URI
+type documentSymbolsClient struct {
+	types.Client
+	symbols map[string][]types.DocumentSymbol
+}
+
+func (c *documentSymbolsClient) GetDocumentSymbols(ctx context.Context, uri string) ([]types.DocumentSymbol, error) {
+	return c.symbols[uri], nil
+}
+
+func TestSymbolMoveTestFiles(t *testing.T) {
+	root := t.TempDir()
+	shapes, shapesTest := filepath.Join(root, "shapes", "shapes.go"), filepath.Join(root, "shapes", "shapes_test.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(shapes), 0o755))
+	for _, path := range []string{shapes, shapesTest} {
+		require.NoError(t, os.WriteFile(path, []byte("package shapes\n"), 0o644))
+	}
+
+	symbol := func(name string, kind int, line int) types.DocumentSymbol {
+		rng := types.Range{Start: types.Position{Line: line, Character: 5}, End: types.Position{Line: line, Character: 5 + len(name)}}
+		return types.DocumentSymbol{Name: name, Kind: kind, Range: rng, SelectionRange: rng}
+	}
+	square, fixture := symbol("Square", 23, 2), symbol("fixture", 23, 2)
+	client := &documentSymbolsClient{symbols: map[string][]types.DocumentSymbol{
+		PathToUri(shapes, ""):     {square},
+		PathToUri(shapesTest, ""): {fixture, symbol("(*Square).scaled", 6, 4)},
+	}}
+	tool := NewMoveSymbolTool(client, types.Config{WorkspaceRoot: root})
+
+	tests := []struct {
+		name        string
+		definition  types.Location
+		destination string
+		error       string
+	}{
+		{
+			name:        "Declared in a test file",
+			definition:  types.Location{URI: PathToUri(shapesTest, ""), Range: fixture.SelectionRange},
+			destination: filepath.Join(root, "shapes", "fixtures.go"),
+			error:       "fixture is declared in the test file shapes_test.go; only symbols declared in non-test files can be moved",
+		},
+		{
+			name:        "Method in a test file moved to another package",
+			definition:  types.Location{URI: PathToUri(shapes, ""), Range: square.SelectionRange},
+			destination: filepath.Join(root, "geo", "square.go"),
+			error:       "method (*Square).scaled is declared in the test file shapes_test.go, and methods must be declared in the package of their type",
+		},
+		{
+			name:        "Method in a test file moved within the package",
+			definition:  types.Location{URI: PathToUri(shapes, ""), Range: square.SelectionRange},
+			destination: filepath.Join(root, "shapes", "square.go"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			move, err := tool.symbolMove(context.Background(), tt.definition, tt.destination)
+			if tt.error == "" {
+				require.NoError(t, err)
+				assert.Len(t, move.Declarations, 1)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.error)
+		})
+	}
+}
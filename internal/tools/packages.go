@@ -0,0 +1,329 @@
+package tools
+
+import (
+	"bufio"
+	"fmt"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// See: https://pkg.go.dev/cmd/go#hdr-Generate_Go_files_by_processing_source
+	generatedFileRegexp = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+)
+
+// FindModule finds the module containing a directory, returning the module path and the module root directory
+func FindModule(dir string) (modulePath string, moduleDir string, err error) {
+	for current := dir; ; current = filepath.Dir(current) {
+		content, err := os.ReadFile(filepath.Join(current, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(content), "\n") {
+				fields := strings.Fields(line)
+				if len(fields) >= 2 && fields[0] == "module" {
+					return strings.Trim(fields[1], `"`), current, nil
+				}
+			}
+			return "", "", fmt.Errorf("no module directive in %s", filepath.Join(current, "go.mod"))
+		}
+
+		if parent := filepath.Dir(current); parent == current {
+			return "", "", fmt.Errorf("no go.mod found for directory: %s", dir)
+		}
+	}
+}
+
+// PackageImportPath returns the import path of the package containing a Go file, or an empty string if it can't be determined
+func PackageImportPath(filePath string) string {
+	dir := filepath.Dir(filePath)
+	modulePath, moduleDir, err := FindModule(dir)
+	if err != nil {
+		return ""
+	}
+
+	rel, err := filepath.Rel(moduleDir, dir)
+	if err != nil {
+		return ""
+	}
+	rel = filepath.ToSlash(rel)
+
+	// Standard library packages live in the "std" module, but aren't prefixed by it
+	if modulePath == "std" {
+		return rel
+	}
+	if rel == "." {
+		return modulePath
+	}
+	return modulePath + "/" + rel
+}
+
+// PackageDir returns the directory of a package in the workspace module from its import path
+func PackageDir(workspaceRoot string, importPath string) (string, error) {
+	modulePath, moduleDir, err := FindModule(workspaceRoot)
+	if err != nil {
+		return "", err
+	}
+
+	if importPath == modulePath {
+		return moduleDir, nil
+	}
+	if rel, found := strings.CutPrefix(importPath, modulePath+"/"); found {
+		return filepath.Join(moduleDir, filepath.FromSlash(rel)), nil
+	}
+	return "", fmt.Errorf("package %s is not in the workspace module %s", importPath, modulePath)
+}
+
+// ImportPackageDir returns the directory of the package with an import path: a package of the workspace module, or else a
+// package found by the go command from the workspace root, like a standard library package or a dependency in the module cache
+func ImportPackageDir(workspaceRoot string, importPath string) (string, error) {
+	if dir, err := PackageDir(workspaceRoot, importPath); err == nil {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, nil
+		}
+	}
+
+	buildPkg, err := build.Default.Import(importPath, workspaceRoot, build.FindOnly)
+	if err != nil {
+		return "", fmt.Errorf("package %s not found: %w", importPath, err)
+	}
+	return buildPkg.Dir, nil
+}
+
+// ResolvePackageDir returns the directory of a package, given either a directory (absolute or relative to the workspace root)
+// or the import path of a package in the workspace module
+func ResolvePackageDir(workspaceRoot string, pkg string) (string, error) {
+	dir := pkg
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(workspaceRoot, dir)
+	}
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		return dir, nil
+	}
+
+	dir, err := PackageDir(workspaceRoot, pkg)
+	if err != nil {
+		return "", fmt.Errorf("%s is neither a directory nor a package in the workspace: %w", pkg, err)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("package directory does not exist: %s", dir)
+	}
+	return dir, nil
+}
+
+// ListPackageFiles returns the sorted absolute paths of the Go files in a package directory, optionally including test files
+func ListPackageFiles(dir string, includeTests bool) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || (!includeTests && IsTestFile(name)) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	return files, nil
+}
+
+// MatchesBuildContext checks if a Go file is included in its package by the build constraints of the current build context,
+// like its GOOS and GOARCH file name suffixes and //go:build lines
+func MatchesBuildContext(file string) bool {
+	match, err := build.Default.MatchFile(filepath.Dir(file), filepath.Base(file))
+	return err == nil && match
+}
+
+// ModuleImportGraph returns the imports of each package of a module that are packages of the same module, by import path.
+// Test files, nested modules, and directories ignored by the go command (like testdata) are skipped.
+func ModuleImportGraph(moduleDir string, modulePath string) (map[string][]string, error) {
+	graph := make(map[string][]string)
+	err := filepath.WalkDir(moduleDir, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if dir != moduleDir {
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+
+		files, err := ListPackageFiles(dir, false)
+		if err != nil || len(files) == 0 {
+			return err
+		}
+		importPath := PackageImportPath(files[0])
+		seen := make(map[string]bool)
+		for _, filePath := range files {
+			file, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.ImportsOnly)
+			if err != nil {
+				continue
+			}
+			for _, spec := range file.Imports {
+				path, err := strconv.Unquote(spec.Path.Value)
+				if err != nil || seen[path] || (path != modulePath && !strings.HasPrefix(path, modulePath+"/")) {
+					continue
+				}
+				seen[path] = true
+				graph[importPath] = append(graph[importPath], path)
+			}
+		}
+		sort.Strings(graph[importPath])
+		return nil
+	})
+	return graph, err
+}
+
+// FindImportCycle finds an import cycle through a package in an import graph, returning the import paths of the cycle
+// starting and ending with the package, or nil if there is none
+func FindImportCycle(graph map[string][]string, start string) []string {
+	visited := make(map[string]bool)
+	var visit func(path []string) []string
+	visit = func(path []string) []string {
+		for _, next := range graph[path[len(path)-1]] {
+			if next == start {
+				return append(append([]string(nil), path...), next)
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			if cycle := visit(append(path, next)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return visit([]string{start})
+}
+
+// PackageDoc returns the package name and doc comment of a package from its files.
+// Test files are skipped, and doc.go is preferred since it conventionally holds the doc comment.
+func PackageDoc(files []string) (name string, doc string) {
+	for _, filePath := range files {
+		if IsTestFile(filePath) {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.PackageClauseOnly|parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		if name == "" {
+			name = file.Name.Name
+		}
+		if file.Doc != nil && (doc == "" || filepath.Base(filePath) == "doc.go") {
+			doc = strings.TrimSpace(file.Doc.Text())
+		}
+	}
+	return name, doc
+}
+
+// MatchPackagePattern checks if an import path matches a package pattern, where "..." matches any string (like go list)
+func MatchPackagePattern(pattern string, importPath string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\.\.\.`, `.*`)
+	// As with go list, "foo/..." also matches "foo" itself
+	if strings.HasSuffix(expr, `/.*`) {
+		expr = strings.TrimSuffix(expr, `/.*`) + `(/.*)?`
+	}
+
+	matched, err := regexp.MatchString("^"+expr+"$", importPath)
+	return err == nil && matched
+}
+
+// MatchFileGlob checks if a relative file path matches a glob pattern.
+// Patterns without a slash match the file name in any directory, "*" matches within a path segment, and "**" matches across segments.
+func MatchFileGlob(pattern string, relPath string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	relPath = filepath.ToSlash(relPath)
+	if !strings.Contains(pattern, "/") {
+		relPath = filepath.Base(relPath)
+	}
+
+	var expr strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	matched, err := regexp.MatchString("^"+expr.String()+"$", relPath)
+	return err == nil && matched
+}
+
+// IsTestFile checks if a file is a Go test file
+func IsTestFile(filePath string) bool {
+	return strings.HasSuffix(filePath, "_test.go")
+}
+
+// IsVendorPath checks if a file is inside a vendor directory
+func IsVendorPath(filePath string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(filePath), "/") {
+		if part == "vendor" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGeneratedFile checks if a Go file has a "Code generated ... DO NOT EDIT." comment before its package clause
+func IsGeneratedFile(filePath string) bool {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if generatedFileRegexp.MatchString(line) {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+	}
+	return false
+}
+
+// IsExportedName checks if a symbol name is exported, using the last component of qualified names like "Calculator.Add" or "(*Calculator).Add"
+func IsExportedName(name string) bool {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
@@ -0,0 +1,263 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindModule(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/project\n\ngo 1.23\n"), 0o644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "pkg", "util"), 0o755))
+
+	modulePath, moduleDir, err := FindModule(filepath.Join(root, "pkg", "util"))
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/project", modulePath)
+	assert.Equal(t, root, moduleDir)
+
+	_, _, err = FindModule(filepath.Dir(root))
+	assert.Error(t, err)
+}
+
+func TestPackageImportPath(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/project\n"), 0o644))
+
+	goroot := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(goroot, "go.mod"), []byte("module std\n"), 0o644))
+
+	tests := []struct {
+		name     string
+		filePath string
+		expected string
+	}{
+		{"File in module root", filepath.Join(root, "main.go"), "example.com/project"},
+		{"File in nested package", filepath.Join(root, "pkg", "util", "util.go"), "example.com/project/pkg/util"},
+		{"Standard library file", filepath.Join(goroot, "net", "http", "client.go"), "net/http"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PackageImportPath(tt.filePath))
+		})
+	}
+}
+
+func TestPackageDir(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/project\n"), 0o644))
+
+	dir, err := PackageDir(root, "example.com/project")
+	assert.NoError(t, err)
+	assert.Equal(t, root, dir)
+
+	dir, err = PackageDir(root, "example.com/project/pkg/util")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "pkg", "util"), dir)
+
+	_, err = PackageDir(root, "example.com/projectile")
+	assert.ErrorContains(t, err, "not in the workspace module")
+}
+
+func TestImportPackageDir(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/project\n\ngo 1.23\n"), 0o644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "pkg", "util"), 0o755))
+
+	dir, err := ImportPackageDir(root, "example.com/project/pkg/util")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "pkg", "util"), dir)
+
+	// Packages outside the workspace module are found by the go command
+	dir, err = ImportPackageDir(root, "fmt")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(runtime.GOROOT(), "src", "fmt"), dir)
+
+	_, err = ImportPackageDir(root, "example.com/project/missing")
+	assert.Error(t, err)
+}
+
+func TestResolvePackageDir(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/project\n"), 0o644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "pkg", "util"), 0o755))
+
+	tests := []struct {
+		name        string
+		pkg         string
+		expected    string
+		expectError bool
+	}{
+		{"Relative directory", "pkg/util", filepath.Join(root, "pkg", "util"), false},
+		{"Absolute directory", filepath.Join(root, "pkg"), filepath.Join(root, "pkg"), false},
+		{"Import path", "example.com/project/pkg/util", filepath.Join(root, "pkg", "util"), false},
+		{"Module root import path", "example.com/project", root, false},
+		{"Missing package in module", "example.com/project/missing", "", true},
+		{"Package outside module", "github.com/other/project", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ResolvePackageDir(root, tt.pkg)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, dir)
+		})
+	}
+}
+
+func TestListPackageFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.go", "a.go", "a_test.go", "README.md"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("package p\n"), 0o644))
+	}
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "sub.go"), 0o755))
+
+	files, err := ListPackageFiles(dir, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.go"), filepath.Join(dir, "b.go")}, files)
+
+	files, err = ListPackageFiles(dir, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.go"), filepath.Join(dir, "a_test.go"), filepath.Join(dir, "b.go")}, files)
+
+	_, err = ListPackageFiles(filepath.Join(dir, "missing"), false)
+	assert.Error(t, err)
+}
+
+func TestMatchesBuildContext(t *testing.T) {
+	dir := t.TempDir()
+	other := "windows"
+	if runtime.GOOS == "windows" {
+		other = "linux"
+	}
+	files := map[string]string{
+		"a.go":                      "package p\n",
+		"a_" + other + ".go":        "package p\n",
+		"ignored.go":                "//go:build ignore\n\npackage p\n",
+		"a_" + runtime.GOOS + ".go": "package p\n",
+	}
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	assert.True(t, MatchesBuildContext(filepath.Join(dir, "a.go")))
+	assert.True(t, MatchesBuildContext(filepath.Join(dir, "a_"+runtime.GOOS+".go")))
+	assert.False(t, MatchesBuildContext(filepath.Join(dir, "a_"+other+".go")))
+	assert.False(t, MatchesBuildContext(filepath.Join(dir, "ignored.go")))
+}
+
+func TestPackageDoc(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"calc.go":      "// Package calc is documented in the wrong file.\npackage calc\n",
+		"doc.go":       "// Package calc performs arithmetic.\npackage calc\n",
+		"other.go":     "package calc\n",
+		"calc_test.go": "// Package calc_test tests calc.\npackage calc_test\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	name, doc := PackageDoc(paths)
+	assert.Equal(t, "calc", name)
+	assert.Equal(t, "Package calc performs arithmetic.", doc)
+
+	name, doc = PackageDoc([]string{filepath.Join(dir, "other.go")})
+	assert.Equal(t, "calc", name)
+	assert.Empty(t, doc)
+}
+
+func TestMatchPackagePattern(t *testing.T) {
+	tests := []struct {
+		name       string
+		pattern    string
+		importPath string
+		expected   bool
+	}{
+		{"Empty pattern", "", "example.com/project", true},
+		{"Exact match", "example.com/project/pkg", "example.com/project/pkg", true},
+		{"Exact mismatch", "example.com/project/pkg", "example.com/project/pkg/util", false},
+		{"Recursive wildcard matches subpackage", "example.com/project/...", "example.com/project/pkg/util", true},
+		{"Recursive wildcard matches root", "example.com/project/...", "example.com/project", true},
+		{"Recursive wildcard rejects sibling", "example.com/project/...", "example.com/projectx", false},
+		{"Wildcard in the middle", "example.com/.../util", "example.com/project/pkg/util", true},
+		{"Dots are literal", "example.com/project", "exampleXcom/project", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MatchPackagePattern(tt.pattern, tt.importPath))
+		})
+	}
+}
+
+func TestMatchFileGlob(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		relPath  string
+		expected bool
+	}{
+		{"Empty pattern", "", "pkg/util/util.go", true},
+		{"Base name pattern", "*.go", "pkg/util/util.go", true},
+		{"Base name mismatch", "*_test.go", "pkg/util/util.go", false},
+		{"Path pattern", "pkg/*/util.go", "pkg/util/util.go", true},
+		{"Single star stays in segment", "pkg/*.go", "pkg/util/util.go", false},
+		{"Double star crosses segments", "pkg/**.go", "pkg/util/util.go", true},
+		{"Question mark", "calc?lator.go", "calculator.go", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MatchFileGlob(tt.pattern, tt.relPath))
+		})
+	}
+}
+
+func TestIsVendorPath(t *testing.T) {
+	assert.True(t, IsVendorPath("vendor/github.com/pkg/errors/errors.go"))
+	assert.True(t, IsVendorPath("/home/user/project/vendor/x/y.go"))
+	assert.False(t, IsVendorPath("pkg/vendors/vendors.go"))
+}
+
+func TestIsGeneratedFile(t *testing.T) {
+	dir := t.TempDir()
+
+	generated := filepath.Join(dir, "generated.go")
+	assert.NoError(t, os.WriteFile(generated, []byte("// Code generated by stringer; DO NOT EDIT.\n\npackage main\n"), 0o644))
+	assert.True(t, IsGeneratedFile(generated))
+
+	// The comment must appear before the package clause
+	late := filepath.Join(dir, "late.go")
+	assert.NoError(t, os.WriteFile(late, []byte("package main\n\n// Code generated by stringer; DO NOT EDIT.\n"), 0o644))
+	assert.False(t, IsGeneratedFile(late))
+
+	handwritten := filepath.Join(dir, "handwritten.go")
+	assert.NoError(t, os.WriteFile(handwritten, []byte("// Package main does things.\npackage main\n"), 0o644))
+	assert.False(t, IsGeneratedFile(handwritten))
+
+	assert.False(t, IsGeneratedFile(filepath.Join(dir, "missing.go")))
+}
+
+func TestIsExportedName(t *testing.T) {
+	assert.True(t, IsExportedName("Calculator"))
+	assert.True(t, IsExportedName("Calculator.Add"))
+	assert.True(t, IsExportedName("(*calculator).Add"))
+	assert.False(t, IsExportedName("calculator"))
+	assert.False(t, IsExportedName("Calculator.reset"))
+	assert.False(t, IsExportedName("_"))
+	assert.False(t, IsExportedName(""))
+}
@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+)
+
+// Page represents a single page of a paginated list of results
+type Page[T any] struct {
+	Items      []T
+	Offset     int            // Offset of the first item in the page
+	Total      int            // Total number of items across all pages
+	Truncated  bool           // Whether there are more items after this page
+	NextCursor results.Cursor // Cursor for the next page, if truncated
+}
+
+// Paginate returns the page of items starting at the cursor (or the first page if the cursor is empty).
+// The query identifies the result set, so that cursors cannot be reused with different arguments.
+func Paginate[T any](items []T, query string, cursor string, limit int) (Page[T], error) {
+	offset := 0
+	if cursor != "" {
+		var err error
+		if offset, err = results.Cursor(cursor).Parse(query); err != nil {
+			return Page[T]{}, err
+		}
+	}
+
+	page := Page[T]{
+		Items:  make([]T, 0),
+		Offset: offset,
+		Total:  len(items),
+	}
+	if offset >= len(items) {
+		return page, nil
+	}
+
+	end := min(offset+limit, len(items))
+	page.Items = items[offset:end]
+	if end < len(items) {
+		page.Truncated = true
+		page.NextCursor = results.NewCursor(query, end)
+	}
+	return page, nil
+}
+
+// PageMessage describes which results a page contains, to be appended to a tool result message
+func PageMessage[T any](page Page[T]) string {
+	if !page.Truncated && page.Offset == 0 {
+		return ""
+	}
+	if len(page.Items) == 0 {
+		return fmt.Sprintf(" No results remain after offset %d.", page.Offset)
+	}
+	message := fmt.Sprintf(" Showing results %d-%d of %d.", page.Offset+1, page.Offset+len(page.Items), page.Total)
+	if page.Truncated {
+		message += " Results were truncated; pass next_cursor as the cursor parameter to get the next page."
+	}
+	return message
+}
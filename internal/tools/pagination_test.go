@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	t.Run("First page", func(t *testing.T) {
+		page, err := Paginate(items, "query", "", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, page.Items)
+		assert.Equal(t, 0, page.Offset)
+		assert.Equal(t, 5, page.Total)
+		assert.True(t, page.Truncated)
+		assert.NotEmpty(t, page.NextCursor)
+	})
+
+	t.Run("Follow cursors to the last page", func(t *testing.T) {
+		var collected []int
+		cursor := ""
+		for {
+			page, err := Paginate(items, "query", cursor, 2)
+			assert.NoError(t, err)
+			collected = append(collected, page.Items...)
+			if !page.Truncated {
+				assert.Empty(t, page.NextCursor)
+				break
+			}
+			cursor = page.NextCursor.String()
+		}
+		assert.Equal(t, items, collected)
+	})
+
+	t.Run("All items fit", func(t *testing.T) {
+		page, err := Paginate(items, "query", "", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, items, page.Items)
+		assert.False(t, page.Truncated)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Cursor past the end", func(t *testing.T) {
+		page, err := Paginate(items, "query", results.NewCursor("query", 10).String(), 2)
+		assert.NoError(t, err)
+		assert.Empty(t, page.Items)
+		assert.Equal(t, 5, page.Total)
+		assert.False(t, page.Truncated)
+	})
+
+	t.Run("Cursor from another query", func(t *testing.T) {
+		_, err := Paginate(items, "query", results.NewCursor("other", 2).String(), 2)
+		assert.Error(t, err)
+	})
+}
+
+func TestPageMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     Page[int]
+		expected string
+	}{
+		{
+			name:     "Single complete page",
+			page:     Page[int]{Items: []int{1, 2}, Total: 2},
+			expected: "",
+		},
+		{
+			name:     "Truncated first page",
+			page:     Page[int]{Items: []int{1, 2}, Total: 5, Truncated: true},
+			expected: " Showing results 1-2 of 5. Results were truncated; pass next_cursor as the cursor parameter to get the next page.",
+		},
+		{
+			name:     "Last page",
+			page:     Page[int]{Items: []int{5}, Offset: 4, Total: 5},
+			expected: " Showing results 5-5 of 5.",
+		},
+		{
+			name:     "Empty page past the end",
+			page:     Page[int]{Items: []int{}, Offset: 10, Total: 5},
+			expected: " No results remain after offset 10.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PageMessage(tt.page))
+		})
+	}
+}
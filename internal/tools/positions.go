@@ -0,0 +1,229 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// positionArguments represents a position in a Go file, given either by a symbol anchor, or by a file path, line, and column
+type positionArguments struct {
+	anchor   string
+	filePath string
+	line     int // Display line
+	column   int // Display column
+}
+
+// positionToolOptions returns the MCP tool parameters for a position, for tools that operate on the identifier at a position
+func positionToolOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString(
+			"symbol_anchor",
+			mcp.Description("Symbol anchor of the identifier, e.g. a reference anchor from find_symbol_references_by_anchor. Either this or file_path, line, and column are required."),
+		),
+		mcp.WithString("file_path", mcp.Description("Path to the Go file containing the identifier")),
+		mcp.WithNumber("line", mcp.Description("Line of the identifier in the file (starts at 1)")),
+		mcp.WithNumber("column", mcp.Description("Column of the identifier in the line (starts at 1), counted in UTF-16 code units like LSP positions, so characters outside the Basic Multilingual Plane (e.g. emoji) count as 2")),
+	}
+}
+
+// parsePositionArguments parses and validates the position parameters of a tool request
+func parsePositionArguments(req mcp.CallToolRequest) (positionArguments, error) {
+	args := positionArguments{
+		anchor:   mcp.ParseString(req, "symbol_anchor", ""),
+		filePath: mcp.ParseString(req, "file_path", ""),
+		line:     mcp.ParseInt(req, "line", 0),
+		column:   mcp.ParseInt(req, "column", 0),
+	}
+
+	switch {
+	case args.anchor == "" && args.filePath == "":
+		return args, errors.New("either symbol_anchor or file_path, line, and column parameters are required")
+	case args.anchor != "" && args.filePath != "":
+		return args, errors.New("symbol_anchor and file_path parameters can't be used together")
+	case args.filePath != "" && (args.line < 1 || args.column < 1):
+		return args, errors.New("line and column parameters must be positive (starting at 1) when file_path is set")
+	}
+	return args, nil
+}
+
+// resolveErrorMessage returns the tool error message for an error resolving the position,
+// which refers to the anchor or to the file position depending on which was given
+func (a positionArguments) resolveErrorMessage(err error) string {
+	if a.anchor != "" {
+		return fmt.Sprintf("Invalid anchor: %v", err)
+	}
+	return fmt.Sprintf("Invalid position: %v", err)
+}
+
+// resolve resolves the position to a file URI and LSP position, relocating a stale anchor if possible
+func (a positionArguments) resolve(ctx context.Context, client types.Client, workspaceRoot string) (ResolvedAnchor, error) {
+	return a.resolveAnchor(ctx, client, workspaceRoot, ResolveSymbolAnchor)
+}
+
+// resolveForEdit resolves the position like resolve, for tools that change the workspace, which never relocate a stale anchor
+func (a positionArguments) resolveForEdit(ctx context.Context, client types.Client, workspaceRoot string) (ResolvedAnchor, error) {
+	return a.resolveAnchor(ctx, client, workspaceRoot, ResolveSymbolAnchorForEdit)
+}
+
+// anchorResolver resolves a symbol anchor, like ResolveSymbolAnchor or ResolveSymbolAnchorForEdit
+type anchorResolver func(ctx context.Context, client types.Client, workspaceRoot string, anchor results.SymbolAnchor) (ResolvedAnchor, error)
+
+// resolveAnchor resolves the position to a file URI and LSP position, using a resolver for the anchor
+func (a positionArguments) resolveAnchor(ctx context.Context, client types.Client, workspaceRoot string, resolveAnchor anchorResolver) (ResolvedAnchor, error) {
+	if a.anchor != "" {
+		return resolveAnchor(ctx, client, workspaceRoot, results.SymbolAnchor(a.anchor))
+	}
+	return ResolvedAnchor{
+		URI: PathToUri(a.filePath, workspaceRoot),
+		Position: types.Position{
+			Line:      a.line - 1,   // Convert display coordinates to LSP coordinates
+			Character: a.column - 1, // Convert display coordinates to LSP coordinates
+		},
+	}, nil
+}
+
+// rangeArguments represents a range in a Go file, given by a start position and an optional end position in the same file.
+// The end position is given either by a line and column, or by the anchor of the last identifier in the range.
+type rangeArguments struct {
+	positionArguments
+	endAnchor string
+	endLine   int // Display line
+	endColumn int // Display column
+}
+
+// rangeToolOptions returns the MCP tool parameters for a range, for tools that operate on a selection or a position
+func rangeToolOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString(
+			"symbol_anchor",
+			mcp.Description("Symbol anchor of the start of the range, e.g. a reference anchor from find_symbol_references_by_anchor. Either this or file_path, line, and column are required."),
+		),
+		mcp.WithString("file_path", mcp.Description("Path to the Go file containing the range")),
+		mcp.WithNumber("line", mcp.Description("Line of the start of the range (starts at 1)")),
+		mcp.WithNumber("column", mcp.Description("Column of the start of the range (starts at 1), counted in UTF-16 code units like LSP positions")),
+		mcp.WithNumber("end_line", mcp.Description("Line of the end of the range (starts at 1). Defaults to an empty range at the start.")),
+		mcp.WithNumber("end_column", mcp.Description("Column just after the end of the range (starts at 1), counted in UTF-16 code units. Required if end_line is set.")),
+		mcp.WithString("end_anchor", mcp.Description("Symbol anchor of the last identifier in the range, as an alternative to end_line and end_column. The range ends just after the identifier.")),
+	}
+}
+
+// parseRangeArguments parses and validates the range parameters of a tool request
+func parseRangeArguments(req mcp.CallToolRequest) (rangeArguments, error) {
+	position, err := parsePositionArguments(req)
+	args := rangeArguments{
+		positionArguments: position,
+		endAnchor:         mcp.ParseString(req, "end_anchor", ""),
+		endLine:           mcp.ParseInt(req, "end_line", 0),
+		endColumn:         mcp.ParseInt(req, "end_column", 0),
+	}
+	if err != nil {
+		return args, err
+	}
+
+	switch {
+	case (args.endLine == 0) != (args.endColumn == 0):
+		return args, errors.New("end_line and end_column parameters must be used together")
+	case args.endLine < 0 || args.endColumn < 0:
+		return args, errors.New("end_line and end_column parameters must be positive (starting at 1)")
+	case args.endAnchor != "" && args.endLine != 0:
+		return args, errors.New("end_anchor and end_line parameters can't be used together")
+	}
+	return args, nil
+}
+
+// resolve resolves the range to a file URI and LSP range, where the start is the resolved position
+func (a rangeArguments) resolve(ctx context.Context, client types.Client, workspaceRoot string) (ResolvedAnchor, types.Range, error) {
+	return a.resolveAnchors(ctx, client, workspaceRoot, ResolveSymbolAnchor)
+}
+
+// resolveForEdit resolves the range like resolve, for tools that change the workspace, which never relocate stale anchors
+func (a rangeArguments) resolveForEdit(ctx context.Context, client types.Client, workspaceRoot string) (ResolvedAnchor, types.Range, error) {
+	return a.resolveAnchors(ctx, client, workspaceRoot, ResolveSymbolAnchorForEdit)
+}
+
+// resolveAnchors resolves the range to a file URI and LSP range, using a resolver for the anchors
+func (a rangeArguments) resolveAnchors(ctx context.Context, client types.Client, workspaceRoot string, resolveAnchor anchorResolver) (ResolvedAnchor, types.Range, error) {
+	resolved, err := a.positionArguments.resolveAnchor(ctx, client, workspaceRoot, resolveAnchor)
+	if err != nil {
+		return resolved, types.Range{}, err
+	}
+
+	rng := types.Range{Start: resolved.Position, End: resolved.Position}
+	switch {
+	case a.endAnchor != "":
+		end, err := resolveAnchor(ctx, client, workspaceRoot, results.SymbolAnchor(a.endAnchor))
+		if err != nil {
+			return resolved, rng, fmt.Errorf("invalid end anchor: %w", err)
+		}
+		if end.URI != resolved.URI {
+			return resolved, rng, errors.New("start and end of range must be in the same file")
+		}
+		rng.End = types.Position{
+			Line:      end.Position.Line,
+			Character: end.Position.Character + utf16Length(end.Name), // End just after the identifier
+		}
+	case a.endLine > 0:
+		rng.End = types.Position{
+			Line:      a.endLine - 1,   // Convert display coordinates to LSP coordinates
+			Character: a.endColumn - 1, // Convert display coordinates to LSP coordinates
+		}
+	}
+	if comparePositions(rng.End, rng.Start) < 0 {
+		return resolved, rng, fmt.Errorf("end of range (%d:%d) is before its start (%d:%d)",
+			rng.End.Line+1, rng.End.Character+1, rng.Start.Line+1, rng.Start.Character+1)
+	}
+	return resolved, rng, nil
+}
+
+// NewPositionDefinition describes a definition location, using document symbols for the kind and name where possible
+func NewPositionDefinition(ctx context.Context, client types.Client, workspaceRoot string, loc types.Location, includeHover bool) results.PositionDefinition {
+	absolutePath := UriToPath(loc.URI)
+	file, external := GetDisplayPath(absolutePath, workspaceRoot)
+	location := results.SymbolLocation{
+		File:        file,
+		DisplayLine: loc.Range.Start.Line + 1,      // Convert LSP coordinates to display line
+		DisplayChar: loc.Range.Start.Character + 1, // Convert LSP coordinates to display character
+	}
+	definition := results.PositionDefinition{
+		Kind:     results.SymbolKindUnknown,
+		Location: location,
+		External: external,
+		ReadOnly: IsReadOnlyFile(absolutePath),
+	}
+
+	// Local symbols aren't document symbols, so fall back to the identifier in the file
+	kind, name, detail := 0, "", ""
+	documentSymbols, _ := client.GetDocumentSymbols(ctx, loc.URI)
+	if docSym := FindDocumentSymbolAt(documentSymbols, loc.Range.Start); docSym != nil {
+		kind, name, detail = docSym.Kind, docSym.Name, docSym.Detail
+		definition.Kind = results.NewSymbolKind(docSym.Kind)
+	} else if lines, err := ReadFileLines(absolutePath); err == nil && loc.Range.Start.Line < len(lines) {
+		name = IdentifierAt(lines[loc.Range.Start.Line], loc.Range.Start.Character)
+	}
+	definition.Name = results.NewSymbolIdentifier(name)
+	definition.Anchor = location.ToAnchor().WithName(definition.Name)
+
+	if symbolPath := SemanticSymbolPath(documentSymbols, loc.Range.Start); symbolPath != "" {
+		if importPath := PackageImportPath(absolutePath); importPath != "" {
+			definition.SemanticAnchor = results.NewSemanticSymbolAnchor(importPath, symbolPath)
+		}
+	}
+
+	// Symbols without document symbols need their hover signature to determine their Go kind
+	var hoverInfo *results.HoverInfo
+	if includeHover || kind == 0 {
+		hoverInfo = GetHoverInfo(ctx, client, loc.URI, loc.Range.Start)
+	}
+	if includeHover {
+		definition.HoverInfo = hoverInfo
+	}
+	definition.GoKind = GetGoSymbolKind(loc.URI, loc.Range.Start, kind, name, detail, hoverInfo)
+
+	return definition
+}
@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePositionArguments(t *testing.T) {
+	tests := []struct {
+		name        string
+		arguments   map[string]any
+		expected    positionArguments
+		expectError bool
+	}{
+		{
+			name:      "Symbol anchor",
+			arguments: map[string]any{"symbol_anchor": "go://main.go#10:2@calc"},
+			expected:  positionArguments{anchor: "go://main.go#10:2@calc"},
+		},
+		{
+			name:      "File position",
+			arguments: map[string]any{"file_path": "main.go", "line": 10, "column": 2},
+			expected:  positionArguments{filePath: "main.go", line: 10, column: 2},
+		},
+		{
+			name:        "Missing position",
+			arguments:   map[string]any{},
+			expectError: true,
+		},
+		{
+			name:        "Both positions",
+			arguments:   map[string]any{"symbol_anchor": "go://main.go#10:2", "file_path": "main.go", "line": 10, "column": 2},
+			expectError: true,
+		},
+		{
+			name:        "File path without column",
+			arguments:   map[string]any{"file_path": "main.go", "line": 10},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{}
+			req.Params.Arguments = tt.arguments
+
+			result, err := parsePositionArguments(req)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestPositionArgumentsResolve(t *testing.T) {
+	resolved, err := positionArguments{filePath: "main.go", line: 10, column: 2}.resolve(context.Background(), nil, "/home/user/project")
+	assert.NoError(t, err)
+	assert.Equal(t, "file:///home/user/project/main.go", resolved.URI)
+	assert.Equal(t, 9, resolved.Position.Line)
+	assert.Equal(t, 1, resolved.Position.Character)
+}
+
+func TestPositionArgumentsResolveErrorMessage(t *testing.T) {
+	err := errors.New("file not found")
+	assert.Equal(t, "Invalid anchor: file not found", positionArguments{anchor: "go://calc.go#4:6@Add"}.resolveErrorMessage(err))
+	assert.Equal(t, "Invalid position: file not found", positionArguments{filePath: "calc.go", line: 4, column: 6}.resolveErrorMessage(err))
+}
+
+func TestParseRangeArguments(t *testing.T) {
+	tests := []struct {
+		name        string
+		arguments   map[string]any
+		expected    rangeArguments
+		expectError bool
+	}{
+		{
+			name:      "Position",
+			arguments: map[string]any{"file_path": "main.go", "line": 14, "column": 12},
+			expected:  rangeArguments{positionArguments: positionArguments{filePath: "main.go", line: 14, column: 12}},
+		},
+		{
+			name:      "Range",
+			arguments: map[string]any{"file_path": "main.go", "line": 14, "column": 12, "end_line": 14, "end_column": 25},
+			expected:  rangeArguments{positionArguments: positionArguments{filePath: "main.go", line: 14, column: 12}, endLine: 14, endColumn: 25},
+		},
+		{
+			name:      "Anchors",
+			arguments: map[string]any{"symbol_anchor": "go://main.go#14:12", "end_anchor": "go://main.go#14:17"},
+			expected:  rangeArguments{positionArguments: positionArguments{anchor: "go://main.go#14:12"}, endAnchor: "go://main.go#14:17"},
+		},
+		{
+			name:        "End anchor with end line",
+			arguments:   map[string]any{"file_path": "main.go", "line": 14, "column": 12, "end_anchor": "go://main.go#14:17", "end_line": 14, "end_column": 25},
+			expectError: true,
+		},
+		{
+			name:        "End line without end column",
+			arguments:   map[string]any{"file_path": "main.go", "line": 14, "column": 12, "end_line": 14},
+			expectError: true,
+		},
+		{
+			name:        "Missing position",
+			arguments:   map[string]any{"end_line": 14, "end_column": 25},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{}
+			req.Params.Arguments = tt.arguments
+
+			result, err := parseRangeArguments(req)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRangeArgumentsResolve(t *testing.T) {
+	position := positionArguments{filePath: "main.go", line: 14, column: 12}
+
+	_, rng, err := rangeArguments{positionArguments: position}.resolve(context.Background(), nil, "/home/user/project")
+	assert.NoError(t, err)
+	assert.Equal(t, types.Range{Start: types.Position{Line: 13, Character: 11}, End: types.Position{Line: 13, Character: 11}}, rng)
+
+	_, rng, err = rangeArguments{positionArguments: position, endLine: 15, endColumn: 3}.resolve(context.Background(), nil, "/home/user/project")
+	assert.NoError(t, err)
+	assert.Equal(t, types.Range{Start: types.Position{Line: 13, Character: 11}, End: types.Position{Line: 14, Character: 2}}, rng)
+
+	_, _, err = rangeArguments{positionArguments: position, endLine: 14, endColumn: 2}.resolve(context.Background(), nil, "/home/user/project")
+	assert.Error(t, err, "End before start should be an error")
+}
+
+func TestRangeArgumentsResolveEndAnchor(t *testing.T) {
+	root := t.TempDir()
+	content := "package main\n\nfunc main() {\n\tresult := calc.Add(5.0)\n}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(content), 0644))
+
+	position := positionArguments{anchor: "go://main.go#4:12"}
+	_, rng, err := rangeArguments{positionArguments: position, endAnchor: "go://main.go#4:17"}.resolve(context.Background(), nil, root)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Range{Start: types.Position{Line: 3, Character: 11}, End: types.Position{Line: 3, Character: 19}}, rng)
+
+	_, _, err = rangeArguments{positionArguments: position, endAnchor: "go://main.go#4:2"}.resolve(context.Background(), nil, root)
+	assert.Error(t, err, "End before start should be an error")
+
+	_, _, err = rangeArguments{positionArguments: position, endAnchor: "go://other.go#4:17"}.resolve(context.Background(), nil, root)
+	assert.Error(t, err, "End in another file should be an error")
+}
@@ -33,7 +33,7 @@ func (t *RenameSymbolByAnchorTool) GetTool() mcp.Tool {
 		mcp.WithString(
 			"symbol_anchor",
 			mcp.Required(),
-			mcp.Description("Symbol anchor, which is included in tool responses. Don't try to parse or generate this yourself."),
+			mcp.Description("Symbol anchor, which is included in tool responses as 'anchor' or 'semantic_anchor'. Prefer semantic anchors (go://IMPORTPATH#Type.Method), which stay valid when the code is edited. Don't try to parse these yourself."),
 		),
 		mcp.WithString(
 			"new_name",
@@ -71,23 +71,23 @@ func (t *RenameSymbolByAnchorTool) Handle(ctx context.Context, req mcp.CallToolR
 		"new_name", newName)
 
 	anchor := results.SymbolAnchor(anchorStr)
-	file, position, err := anchor.ToFilePosition()
+	resolved, err := ResolveSymbolAnchorForEdit(ctx, t.client, t.config.WorkspaceRoot, anchor)
 	if err != nil {
-		slog.Debug("Invalid anchor format",
+		slog.Debug("Failed to resolve symbol anchor",
 			"tool", "rename_symbol_by_anchor",
 			"symbol_anchor", anchorStr,
 			"error", err)
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid anchor format: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid anchor: %v", err)), nil
 	}
 
-	slog.Debug("Parsed symbol anchor",
+	slog.Debug("Resolved symbol anchor",
 		"tool", "rename_symbol_by_anchor",
 		"symbol_anchor", anchorStr,
-		"file", file,
-		"line", position.Line,
-		"character", position.Character)
+		"uri", resolved.URI,
+		"line", resolved.Position.Line,
+		"character", resolved.Position.Character)
+	uri, position := resolved.URI, resolved.Position
 
-	uri := PathToUri(file, t.config.WorkspaceRoot)
 	prepareResult, err := t.client.PrepareRename(ctx, uri, position)
 	if err != nil {
 		slog.Debug("Failed to prepare rename",
@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SafeDeleteTool handles safe delete requests
+type SafeDeleteTool struct {
+	client types.Client
+	config types.Config
+}
+
+// NewSafeDeleteTool creates a new safe delete tool
+func NewSafeDeleteTool(client types.Client, config types.Config) *SafeDeleteTool {
+	return &SafeDeleteTool{
+		client: client,
+		config: config,
+	}
+}
+
+// GetTool returns the MCP tool definition
+func (t *SafeDeleteTool) GetTool() mcp.Tool {
+	options := append(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Delete a top-level function, method, type, variable or constant, but only if nothing outside test files references it. The whole declaration is removed with its doc comment, along with the methods of a type and any imports that only the deleted code used. References in test files don't prevent the deletion, but are reported so that the tests can be updated. Note that methods may be needed to implement interfaces even when nothing references them directly. Use dry_run to preview the changes without writing them."),
+		},
+		positionToolOptions()...,
+	)
+	options = append(options,
+		mcp.WithBoolean("list_references", mcp.Description("Whether to list the locations of the remaining references, including those in test files (default: false)")),
+		mcp.WithBoolean("dry_run", mcp.Description("Whether to only preview the changes, without writing them to disk (default: false)")),
+	)
+	tool := mcp.NewTool("safe_delete", options...)
+	return tool
+}
+
+// Handle processes the tool request
+func (t *SafeDeleteTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	position, err := parsePositionArguments(req)
+	if err != nil {
+		slog.Debug("MCP tool called with invalid position parameters", "tool", "safe_delete", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	listReferences := mcp.ParseBoolean(req, "list_references", false)
+	dryRun := mcp.ParseBoolean(req, "dry_run", false)
+
+	slog.Debug("MCP tool called",
+		"tool", "safe_delete",
+		"symbol_anchor", position.anchor,
+		"file_path", position.filePath,
+		"line", position.line,
+		"column", position.column,
+		"list_references", listReferences,
+		"dry_run", dryRun)
+
+	resolved, err := position.resolveForEdit(ctx, t.client, t.config.WorkspaceRoot)
+	if err != nil {
+		slog.Debug("Failed to resolve position",
+			"tool", "safe_delete",
+			"symbol_anchor", position.anchor,
+			"error", err)
+		return mcp.NewToolResultError(position.resolveErrorMessage(err)), nil
+	}
+
+	definitions, err := t.client.GoToDefinition(ctx, resolved.URI, resolved.Position)
+	if err != nil || len(definitions) == 0 {
+		slog.Debug("Failed to find declaration",
+			"tool", "safe_delete",
+			"uri", resolved.URI,
+			"error", err)
+		return mcp.NewToolResultError("No declaration found. The position should be on the name of a top-level declaration."), nil
+	}
+	definition := definitions[0]
+	if IsReadOnlyFile(UriToPath(definition.URI)) {
+		return mcp.NewToolResultError(fmt.Sprintf("The symbol is declared in %s, which is read-only.", UriToPath(definition.URI))), nil
+	}
+
+	decls, err := FindSymbolDeclarations(ctx, t.client, definition, true)
+	if err != nil {
+		slog.Debug("Failed to find deleted declarations",
+			"tool", "safe_delete",
+			"uri", definition.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("%v; only top-level declarations can be deleted", err)), nil
+	}
+
+	references, err := t.client.FindReferences(ctx, definition.URI, definition.Range.Start)
+	if err != nil {
+		slog.Error("Failed to find references",
+			"tool", "safe_delete",
+			"uri", definition.URI,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to find references of %s: %v", decls.Name, err)), nil
+	}
+
+	session := NewEditSession(t.config.WorkspaceRoot)
+	deletion, err := DeleteSymbol(session, SymbolDeletion{Name: decls.Name, Declarations: decls.Declarations, References: references})
+	if err != nil {
+		slog.Debug("Failed to delete symbol",
+			"tool", "safe_delete",
+			"symbol", decls.Name,
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete %s: %v", decls.Name, err)), nil
+	}
+
+	changes := session.Changes()
+	if !dryRun && len(changes) > 0 {
+		if err := WriteEditSession(ctx, t.client, session); err != nil {
+			slog.Error("Failed to write deletion",
+				"tool", "safe_delete",
+				"error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write the deletion: %v", err)), nil
+		}
+	}
+
+	toolResult := results.SafeDeleteToolResult{
+		Arguments: results.SafeDeleteToolArgs{
+			SymbolAnchor:   position.anchor,
+			FilePath:       position.filePath,
+			Line:           position.line,
+			Column:         position.column,
+			ListReferences: listReferences,
+			DryRun:         dryRun,
+		},
+		Symbol:              decls.Name,
+		Deleted:             deletion.Deleted,
+		DeletedDeclarations: deletion.DeletedDeclarations,
+		ReferenceCount:      len(deletion.References),
+		TestReferenceCount:  len(deletion.TestReferences),
+		RemovedImports:      deletion.RemovedImports,
+		Applied:             !dryRun && len(changes) > 0,
+		FileChanges:         make([]results.FileChange, 0, len(changes)),
+	}
+	toolResult.FileChanges = append(toolResult.FileChanges, changes...)
+	if listReferences {
+		toolResult.References = append(t.safeDeleteReferences(deletion.References, false), t.safeDeleteReferences(deletion.TestReferences, true)...)
+	}
+
+	switch {
+	case !deletion.Deleted:
+		toolResult.Message = fmt.Sprintf("%s can't be deleted, since it has %d references outside test files.", decls.Name, len(deletion.References))
+		if !listReferences {
+			toolResult.Message += " Use list_references to list them."
+		}
+	case dryRun:
+		toolResult.Message = fmt.Sprintf("Deleting %s (%d declarations) would remove %d unused imports and change %d files. No changes were written (dry run).", decls.Name, len(deletion.DeletedDeclarations), len(deletion.RemovedImports), len(changes))
+	default:
+		toolResult.Message = fmt.Sprintf("Deleted %s (%d declarations), removing %d unused imports and changing %d files.", decls.Name, len(deletion.DeletedDeclarations), len(deletion.RemovedImports), len(changes))
+	}
+	if deletion.Deleted && len(deletion.TestReferences) > 0 {
+		toolResult.Message += fmt.Sprintf(" %d references in test files must be updated.", len(deletion.TestReferences))
+	}
+
+	jsonBytes, err := json.Marshal(toolResult)
+	if err != nil {
+		slog.Error("Failed to marshal tool result",
+			"tool", "safe_delete",
+			"error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal tool result into JSON: %v", err)), nil
+	}
+
+	slog.Debug("MCP tool completed successfully",
+		"tool", "safe_delete",
+		"deleted", toolResult.Deleted,
+		"reference_count", toolResult.ReferenceCount,
+		"test_reference_count", toolResult.TestReferenceCount,
+		"file_change_count", len(toolResult.FileChanges),
+		"applied", toolResult.Applied,
+		"response_size_bytes", len(jsonBytes))
+
+	return mcp.NewToolResultText(string(jsonBytes)), nil
+}
+
+// safeDeleteReferences converts remaining references to display locations with anchors
+func (t *SafeDeleteTool) safeDeleteReferences(references []types.Location, test bool) []results.SafeDeleteReference {
+	converted := make([]results.SafeDeleteReference, 0, len(references))
+	for _, reference := range references {
+		file, _ := GetDisplayPath(UriToPath(reference.URI), t.config.WorkspaceRoot)
+		location := results.SymbolLocation{
+			File:        file,
+			DisplayLine: reference.Range.Start.Line + 1,      // Convert LSP coordinates to display line
+			DisplayChar: reference.Range.Start.Character + 1, // Convert LSP coordinates to display character
+		}
+		converted = append(converted, results.SafeDeleteReference{Location: location, Anchor: location.ToAnchor(), Test: test})
+	}
+	return converted
+}
@@ -0,0 +1,510 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+// SignatureParam represents a parameter in a changed function signature
+type SignatureParam struct {
+	Name     string // Empty for unnamed parameters
+	Type     string // Source of the type, without "..." for variadic parameters
+	Variadic bool
+	Default  string // Argument to pass at call sites, for new parameters
+	OldIndex int    // Index of the parameter in the old signature, or -1 for new parameters
+	offset   int    // Byte offset of the name (or type, if unnamed) of an old parameter
+}
+
+// String returns the parameter as it's declared in a signature
+func (p SignatureParam) String() string {
+	typ := p.Type
+	if p.Variadic {
+		typ = "..." + typ
+	}
+	if p.Name == "" {
+		return typ
+	}
+	return p.Name + " " + typ
+}
+
+// FuncDeclaration represents a parsed function or method declaration, whose signature can be changed
+type FuncDeclaration struct {
+	Name    string
+	Params  []SignatureParam
+	content string
+	decl    *ast.FuncDecl
+	fset    *token.FileSet
+}
+
+// ParseFuncDeclaration parses the declaration of the function or method whose name starts at a byte offset in the content of a Go file
+func ParseFuncDeclaration(path string, content string, offset int) (*FuncDeclaration, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || fset.Position(funcDecl.Name.Pos()).Offset != offset {
+			continue
+		}
+
+		d := &FuncDeclaration{Name: funcDecl.Name.Name, content: content, decl: funcDecl, fset: fset}
+		for _, field := range funcDecl.Type.Params.List {
+			typ := field.Type
+			variadic := false
+			if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+				typ, variadic = ellipsis.Elt, true
+			}
+			param := SignatureParam{Type: d.source(typ), Variadic: variadic, OldIndex: len(d.Params), offset: d.offset(field.Type.Pos())}
+			if len(field.Names) == 0 {
+				d.Params = append(d.Params, param)
+				continue
+			}
+			for _, name := range field.Names {
+				param.Name, param.OldIndex, param.offset = name.Name, len(d.Params), d.offset(name.Pos())
+				d.Params = append(d.Params, param)
+			}
+		}
+		return d, nil
+	}
+	return nil, errors.New("no function or method is declared at the position; only declared functions and methods are supported")
+}
+
+// offset returns the byte offset of a position in the file of the declaration
+func (d *FuncDeclaration) offset(pos token.Pos) int {
+	return d.fset.Position(pos).Offset
+}
+
+// source returns the source of a node in the file of the declaration
+func (d *FuncDeclaration) source(node ast.Node) string {
+	return d.content[d.offset(node.Pos()):d.offset(node.End())]
+}
+
+// ParamPosition returns the LSP position of the name (or type, if unnamed) of a parameter of the declaration
+func (d *FuncDeclaration) ParamPosition(index int) types.Position {
+	return offsetPosition(d.content, d.Params[index].offset)
+}
+
+// FormatParams formats a parameter list like in a signature, naming unnamed parameters "_" if other parameters are named
+func FormatParams(params []SignatureParam) string {
+	named := false
+	for _, param := range params {
+		named = named || param.Name != ""
+	}
+
+	parts := make([]string, len(params))
+	for i, param := range params {
+		if named && param.Name == "" {
+			param.Name = "_" // Parameters must be either all named or all unnamed
+		}
+		parts[i] = param.String()
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// ParseParameterSpecs parses the new parameter list of a signature. Each spec is either an existing parameter,
+// given by its name or by its position like "#2", or a new parameter like "name type = default".
+func ParseParameterSpecs(specs []string, oldParams []SignatureParam) ([]SignatureParam, error) {
+	params := make([]SignatureParam, 0, len(specs))
+	used := make(map[int]bool)
+	names := make(map[string]bool)
+	for i, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		param, err := parseParameterSpec(spec, oldParams)
+		if err != nil {
+			return nil, err
+		}
+
+		if param.OldIndex >= 0 {
+			if used[param.OldIndex] {
+				return nil, fmt.Errorf("parameter %q is listed more than once", spec)
+			}
+			used[param.OldIndex] = true
+		}
+		if param.Name != "" && param.Name != "_" {
+			if names[param.Name] {
+				return nil, fmt.Errorf("duplicate parameter name %q", param.Name)
+			}
+			names[param.Name] = true
+		}
+		if param.Variadic && i != len(specs)-1 {
+			return nil, fmt.Errorf("variadic parameter %q must be the last parameter", spec)
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// parseParameterSpec parses a single parameter spec
+func parseParameterSpec(spec string, oldParams []SignatureParam) (SignatureParam, error) {
+	if index, ok := strings.CutPrefix(spec, "#"); ok {
+		n, err := strconv.Atoi(index)
+		if err != nil || n < 1 || n > len(oldParams) {
+			return SignatureParam{}, fmt.Errorf("invalid parameter position %q: the function has %d parameters", spec, len(oldParams))
+		}
+		return oldParams[n-1], nil
+	}
+	if spec != "_" {
+		for _, param := range oldParams {
+			if param.Name == spec {
+				return param, nil
+			}
+		}
+	}
+
+	// New parameters need a default argument, unless they're variadic
+	declaration, defaultValue, _ := strings.Cut(spec, "=")
+	declaration, defaultValue = strings.TrimSpace(declaration), strings.TrimSpace(defaultValue)
+	name, typ, ok := strings.Cut(declaration, " ")
+	typ = strings.TrimSpace(typ)
+	if !ok || typ == "" {
+		return SignatureParam{}, fmt.Errorf("%q is neither an existing parameter nor a new parameter like 'name type = default'", spec)
+	}
+	if name != "_" && !IsValidGoIdentifier(name) {
+		return SignatureParam{}, fmt.Errorf("'%s' is not a valid Go identifier", name)
+	}
+
+	param := SignatureParam{Name: name, Type: typ, Default: defaultValue, OldIndex: -1}
+	if elem, ok := strings.CutPrefix(typ, "..."); ok {
+		param.Type, param.Variadic = strings.TrimSpace(elem), true
+	}
+	if _, err := parser.ParseExpr(param.Type); err != nil {
+		return SignatureParam{}, fmt.Errorf("invalid type %q for parameter %s: %w", typ, name, err)
+	}
+	switch {
+	case defaultValue == "" && !param.Variadic:
+		return SignatureParam{}, fmt.Errorf("new parameter %s needs a default argument for call sites, like '%s %s = value'", name, name, typ)
+	case defaultValue != "":
+		if _, err := parser.ParseExpr(defaultValue); err != nil {
+			return SignatureParam{}, fmt.Errorf("invalid default argument %q for parameter %s: %w", defaultValue, name, err)
+		}
+	}
+	return param, nil
+}
+
+// SignatureCodeAction returns the kind of the gopls code action that changes a signature from oldParams to newParams,
+// and the index of the old parameter to request it for. Only removing one parameter and swapping two adjacent parameters are supported.
+func SignatureCodeAction(oldParams []SignatureParam, newParams []SignatureParam) (types.CodeActionKind, int, bool) {
+	for _, param := range newParams {
+		if param.OldIndex < 0 {
+			return "", 0, false
+		}
+	}
+
+	switch len(newParams) {
+	case len(oldParams) - 1:
+		removed := len(oldParams) - 1
+		for i, param := range newParams {
+			if param.OldIndex != i {
+				removed = i
+				break
+			}
+		}
+		for i, param := range newParams[removed:] {
+			if param.OldIndex != removed+i+1 {
+				return "", 0, false
+			}
+		}
+		return types.CodeActionKindRemoveUnusedParam, removed, true
+	case len(oldParams):
+		swapped := -1
+		for i, param := range newParams {
+			switch {
+			case param.OldIndex == i:
+			case swapped < 0 && param.OldIndex == i+1 && i+1 < len(newParams) && newParams[i+1].OldIndex == i:
+				swapped = i
+			case swapped >= 0 && i == swapped+1:
+			default:
+				return "", 0, false
+			}
+		}
+		if swapped >= 0 {
+			return types.CodeActionKindMoveParamLeft, swapped + 1, true
+		}
+	}
+	return "", 0, false
+}
+
+// CheckRemovedParams checks that the parameters removed from a declaration aren't used in its body
+func (d *FuncDeclaration) CheckRemovedParams(newParams []SignatureParam) error {
+	removed := d.removedParams(newParams)
+	if len(removed) == 0 || d.decl.Body == nil {
+		return nil
+	}
+
+	names := make(map[string]bool)
+	for _, index := range removed {
+		if name := d.Params[index].Name; name != "" && name != "_" {
+			names[name] = true
+		}
+	}
+	var used string
+	ast.Inspect(d.decl.Body, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.Ident); ok && names[ident.Name] && used == "" {
+			used = ident.Name
+		}
+		return used == ""
+	})
+	if used != "" {
+		return fmt.Errorf("parameter %s is used in the body of %s, so it can't be removed", used, d.Name)
+	}
+	return nil
+}
+
+// removedParams returns the indexes of the parameters of the declaration that aren't in the new parameters
+func (d *FuncDeclaration) removedParams(newParams []SignatureParam) []int {
+	kept := make(map[int]bool)
+	for _, param := range newParams {
+		if param.OldIndex >= 0 {
+			kept[param.OldIndex] = true
+		}
+	}
+	var removed []int
+	for i := range d.Params {
+		if !kept[i] {
+			removed = append(removed, i)
+		}
+	}
+	return removed
+}
+
+// ParamsEdit returns the text edit that replaces the parameter list of the declaration
+func (d *FuncDeclaration) ParamsEdit(newParams []SignatureParam) types.TextEdit {
+	params := d.decl.Type.Params
+	return offsetTextEdit(d.content, d.offset(params.Opening), d.offset(params.Closing)+1, FormatParams(newParams))
+}
+
+// CallSiteEdit returns the text edit that rewrites the arguments of the call whose function name starts at a byte offset in a Go file,
+// for a function whose parameters change from oldParams to newParams
+func CallSiteEdit(fset *token.FileSet, file *ast.File, content string, offset int, oldParams []SignatureParam, newParams []SignatureParam) (types.TextEdit, error) {
+	call := findCallAt(fset, file, offset)
+	if call == nil {
+		return types.TextEdit{}, errors.New("the reference isn't a call, e.g. because the function is used as a value")
+	}
+
+	source := func(node ast.Node) string {
+		return content[fset.Position(node.Pos()).Offset:fset.Position(node.End()).Offset]
+	}
+	if len(call.Args) == 1 && len(oldParams) > 1 {
+		if _, ok := ast.Unparen(call.Args[0]).(*ast.CallExpr); ok {
+			return types.TextEdit{}, errors.New("the arguments are the results of a call, which can't be rearranged")
+		}
+	}
+
+	// Group the arguments by the parameter they're passed to, with any variadic arguments grouped together
+	args := make([][]int, len(oldParams)) // Indexes of the arguments in the call
+	for i := range call.Args {
+		index := min(i, len(oldParams)-1)
+		if index < 0 || (i >= len(oldParams) && !oldParams[index].Variadic) {
+			return types.TextEdit{}, errors.New("the call has more arguments than the function has parameters")
+		}
+		args[index] = append(args[index], i)
+	}
+
+	removed := make(map[int]bool, len(oldParams))
+	for i := range oldParams {
+		removed[i] = true
+	}
+	var newArgs []string
+	var order []int // Indexes of the kept arguments in the call, in their new order
+	for _, param := range newParams {
+		if param.OldIndex < 0 {
+			if param.Default != "" {
+				newArgs = append(newArgs, param.Default)
+			}
+			continue
+		}
+		removed[param.OldIndex] = false
+		for _, arg := range args[param.OldIndex] {
+			newArgs = append(newArgs, source(call.Args[arg]))
+			order = append(order, arg)
+		}
+	}
+	if call.Ellipsis.IsValid() && len(newArgs) > 0 && len(oldParams) > 0 && !removed[len(oldParams)-1] {
+		newArgs[len(newArgs)-1] += "..."
+	}
+
+	for index := range oldParams {
+		if !removed[index] {
+			continue
+		}
+		for _, arg := range args[index] {
+			if hasSideEffects(call.Args[arg]) {
+				return types.TextEdit{}, fmt.Errorf("the argument %s of removed parameter #%d may have side effects, so it can't be removed", source(call.Args[arg]), index+1)
+			}
+		}
+	}
+
+	// Calls and receives in arguments happen from left to right, so arguments that both have side effects can't swap places
+	for i := range order {
+		for _, later := range order[i+1:] {
+			if later < order[i] && hasSideEffects(call.Args[order[i]]) && hasSideEffects(call.Args[later]) {
+				return types.TextEdit{}, fmt.Errorf("the arguments %s and %s may have side effects, so they can't be reordered",
+					source(call.Args[later]), source(call.Args[order[i]]))
+			}
+		}
+	}
+
+	start := fset.Position(call.Lparen).Offset + 1
+	end := fset.Position(call.Rparen).Offset
+	return offsetTextEdit(content, start, end, strings.Join(newArgs, ", ")), nil
+}
+
+// findCallAt finds the call expression whose function name starts at a byte offset, looking through selectors, parentheses and instantiations
+func findCallAt(fset *token.FileSet, file *ast.File, offset int) *ast.CallExpr {
+	var found *ast.CallExpr
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || found != nil {
+			return found == nil
+		}
+
+		fun := ast.Unparen(call.Fun)
+		switch index := fun.(type) {
+		case *ast.IndexExpr:
+			fun = index.X
+		case *ast.IndexListExpr:
+			fun = index.X
+		}
+		if selector, ok := fun.(*ast.SelectorExpr); ok {
+			fun = selector.Sel
+		}
+		if ident, ok := fun.(*ast.Ident); ok && fset.Position(ident.Pos()).Offset == offset {
+			found = call
+		}
+		return found == nil
+	})
+	return found
+}
+
+// hasSideEffects conservatively checks if evaluating an expression may have side effects, i.e. if it contains calls or receives
+func hasSideEffects(expr ast.Expr) bool {
+	effects := false
+	ast.Inspect(expr, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			effects = true
+		case *ast.UnaryExpr:
+			effects = effects || n.Op == token.ARROW
+		case *ast.FuncLit:
+			return false // Function literals aren't evaluated until called
+		}
+		return !effects
+	})
+	return effects
+}
+
+// offsetTextEdit creates an LSP text edit replacing a byte range of content
+func offsetTextEdit(content string, start int, end int, newText string) types.TextEdit {
+	return types.TextEdit{
+		Range:   types.Range{Start: offsetPosition(content, start), End: offsetPosition(content, end)},
+		NewText: newText,
+	}
+}
+
+// ChangeSignatureByReferences changes the signature of a declaration in an edit session, rewriting the arguments of each call site.
+// Nothing is edited if the signature can't be changed safely at every call site.
+func ChangeSignatureByReferences(session *EditSession, declPath string, decl *FuncDeclaration, callSites []types.Location, newParams []SignatureParam) error {
+	if err := decl.CheckRemovedParams(newParams); err != nil {
+		return err
+	}
+
+	type parsedFile struct {
+		content string
+		fset    *token.FileSet
+		file    *ast.File
+	}
+	files := make(map[string]*parsedFile)
+	editsByPath := map[string][]types.TextEdit{declPath: {decl.ParamsEdit(newParams)}}
+	paths := []string{declPath}
+	callEdits := make(map[string][]callSiteEdit) // By path, to find call sites nested in the arguments of other call sites
+
+	var failures []string
+	for _, site := range callSites {
+		path := UriToPath(site.URI)
+		parsed, ok := files[path]
+		if !ok {
+			content, err := session.Content(path)
+			if err != nil {
+				return err
+			}
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, path, content, parser.SkipObjectResolution)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			parsed = &parsedFile{content: content, fset: fset, file: file}
+			files[path] = parsed
+		}
+
+		offset, err := positionOffset(parsed.content, lineOffsets(parsed.content), site.Range.Start)
+		if err == nil {
+			var edit types.TextEdit
+			if edit, err = CallSiteEdit(parsed.fset, parsed.file, parsed.content, offset, decl.Params, newParams); err == nil {
+				if _, ok := editsByPath[path]; !ok {
+					paths = append(paths, path)
+				}
+				editsByPath[path] = append(editsByPath[path], edit)
+				callEdits[path] = append(callEdits[path], callSiteEdit{site: site.Range.Start, edit: edit})
+				continue
+			}
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", callSiteName(session, path, site.Range.Start), err))
+	}
+	for _, path := range paths {
+		failures = append(failures, nestedCallSiteFailures(session, path, callEdits[path])...)
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d call sites can't be updated: %s", len(failures), strings.Join(failures, "; "))
+	}
+
+	for _, path := range paths {
+		if err := session.EditFile(path, editsByPath[path]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// callSiteEdit pairs the edit of a call site with the position of the call site
+type callSiteEdit struct {
+	site types.Position
+	edit types.TextEdit
+}
+
+// callSiteName returns the display name of a call site, like "main.go:8:10"
+func callSiteName(session *EditSession, path string, position types.Position) string {
+	file, _ := GetDisplayPath(path, session.workspaceRoot)
+	return fmt.Sprintf("%s:%d:%d", file, position.Line+1, position.Character+1)
+}
+
+// nestedCallSiteFailures reports the call sites of a file that are nested in the arguments of another call site, like the
+// inner call of f(f(1, 2), 3), since the edits of both calls would overlap
+func nestedCallSiteFailures(session *EditSession, path string, edits []callSiteEdit) []string {
+	sorted := slices.Clone(edits)
+	slices.SortFunc(sorted, func(a, b callSiteEdit) int {
+		return comparePositions(a.edit.Range.Start, b.edit.Range.Start)
+	})
+
+	var failures []string
+	for i := range sorted {
+		for _, outer := range sorted[:i] {
+			if comparePositions(sorted[i].edit.Range.Start, outer.edit.Range.End) < 0 {
+				failures = append(failures, fmt.Sprintf("%s: the call is nested in the arguments of the call at %s, so their edits would overlap; update one of them first",
+					callSiteName(session, path, sorted[i].site), callSiteName(session, path, outer.site)))
+				break
+			}
+		}
+	}
+	return failures
+}
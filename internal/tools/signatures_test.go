@@ -0,0 +1,256 @@
+package tools
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const signatureSource = `package main
+
+func add(a int, b int, scale ...float64) int {
+	return a + b
+}
+
+func main() {
+	println(add(1, 2), add(3, next(), 1.5, 2.5))
+	println(add(4, 5, values...))
+	println(add(first(), second()))
+	f := add
+	println(f(6, 7))
+}
+`
+
+// parseSignatureDeclaration parses the declaration of add in signatureSource
+func parseSignatureDeclaration(t *testing.T) *FuncDeclaration {
+	decl, err := ParseFuncDeclaration("main.go", signatureSource, strings.Index(signatureSource, "add"))
+	require.NoError(t, err)
+	return decl
+}
+
+func TestParseFuncDeclaration(t *testing.T) {
+	decl := parseSignatureDeclaration(t)
+	assert.Equal(t, "add", decl.Name)
+	assert.Equal(t, "(a int, b int, scale ...float64)", FormatParams(decl.Params))
+	assert.Equal(t, types.Position{Line: 2, Character: 16}, decl.ParamPosition(1))
+
+	_, err := ParseFuncDeclaration("main.go", signatureSource, strings.Index(signatureSource, "main()"))
+	assert.NoError(t, err)
+	_, err = ParseFuncDeclaration("main.go", signatureSource, strings.Index(signatureSource, "println"))
+	assert.Error(t, err)
+}
+
+func TestFormatParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   []SignatureParam
+		expected string
+	}{
+		{name: "Empty", expected: "()"},
+		{name: "Unnamed", params: []SignatureParam{{Type: "int"}, {Type: "string", Variadic: true}}, expected: "(int, ...string)"},
+		{name: "Mixed", params: []SignatureParam{{Type: "int"}, {Name: "s", Type: "string"}}, expected: "(_ int, s string)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatParams(tt.params))
+		})
+	}
+}
+
+func TestParseParameterSpecs(t *testing.T) {
+	oldParams := parseSignatureDeclaration(t).Params
+
+	tests := []struct {
+		name     string
+		specs    []string
+		expected string
+		error    string
+	}{
+		{name: "Reorder by name and position", specs: []string{"b", "#1", "scale"}, expected: "(b int, a int, scale ...float64)"},
+		{name: "Remove", specs: []string{"a"}, expected: "(a int)"},
+		{name: "Add with default", specs: []string{"a", " b ", "name string = \"x\""}, expected: "(a int, b int, name string)"},
+		{name: "Add variadic", specs: []string{"a", "b", "rest ...any"}, expected: "(a int, b int, rest ...any)"},
+		{name: "Missing default", specs: []string{"a", "c int"}, error: "needs a default argument"},
+		{name: "Invalid default", specs: []string{"c int = )"}, error: "invalid default argument"},
+		{name: "Invalid type", specs: []string{"c [int = 1"}, error: "invalid type"},
+		{name: "Unknown parameter", specs: []string{"c"}, error: "neither an existing parameter"},
+		{name: "Invalid position", specs: []string{"#4"}, error: "invalid parameter position"},
+		{name: "Listed twice", specs: []string{"a", "#1"}, error: "more than once"},
+		{name: "Duplicate name", specs: []string{"a", "a string = \"\""}, error: "duplicate parameter name"},
+		{name: "Variadic not last", specs: []string{"scale", "a"}, error: "must be the last parameter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := ParseParameterSpecs(tt.specs, oldParams)
+			if tt.error != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.error)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, FormatParams(params))
+		})
+	}
+}
+
+func TestSignatureCodeAction(t *testing.T) {
+	oldParams := []SignatureParam{{Name: "a", OldIndex: 0}, {Name: "b", OldIndex: 1}, {Name: "c", OldIndex: 2}}
+	params := func(indexes ...int) []SignatureParam {
+		var params []SignatureParam
+		for _, index := range indexes {
+			if index < 0 {
+				params = append(params, SignatureParam{Name: "new", OldIndex: -1})
+			} else {
+				params = append(params, oldParams[index])
+			}
+		}
+		return params
+	}
+
+	tests := []struct {
+		name          string
+		newParams     []SignatureParam
+		expectedKind  types.CodeActionKind
+		expectedIndex int
+		expectedOK    bool
+	}{
+		{name: "Remove first", newParams: params(1, 2), expectedKind: types.CodeActionKindRemoveUnusedParam, expectedIndex: 0, expectedOK: true},
+		{name: "Remove last", newParams: params(0, 1), expectedKind: types.CodeActionKindRemoveUnusedParam, expectedIndex: 2, expectedOK: true},
+		{name: "Swap adjacent", newParams: params(0, 2, 1), expectedKind: types.CodeActionKindMoveParamLeft, expectedIndex: 2, expectedOK: true},
+		{name: "Unchanged", newParams: params(0, 1, 2)},
+		{name: "Rotate", newParams: params(2, 0, 1)},
+		{name: "Remove and reorder", newParams: params(2, 0)},
+		{name: "Remove two", newParams: params(0)},
+		{name: "Add", newParams: params(0, 1, 2, -1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, index, ok := SignatureCodeAction(oldParams, tt.newParams)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedKind, kind)
+			assert.Equal(t, tt.expectedIndex, index)
+		})
+	}
+}
+
+func TestCheckRemovedParams(t *testing.T) {
+	decl := parseSignatureDeclaration(t)
+
+	params, err := ParseParameterSpecs([]string{"a", "b"}, decl.Params)
+	require.NoError(t, err)
+	assert.NoError(t, decl.CheckRemovedParams(params))
+
+	params, err = ParseParameterSpecs([]string{"a", "scale"}, decl.Params)
+	require.NoError(t, err)
+	err = decl.CheckRemovedParams(params)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parameter b is used")
+}
+
+func TestCallSiteEdit(t *testing.T) {
+	decl := parseSignatureDeclaration(t)
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", signatureSource, parser.SkipObjectResolution)
+	require.NoError(t, err)
+
+	// Offsets of the references to add in main
+	var calls []int
+	for offset := strings.Index(signatureSource, "main()"); ; {
+		next := strings.Index(signatureSource[offset:], "add")
+		if next < 0 {
+			break
+		}
+		offset += next
+		calls = append(calls, offset)
+		offset++
+	}
+	require.Len(t, calls, 5)
+
+	tests := []struct {
+		name     string
+		call     int
+		specs    []string
+		expected string
+		error    string
+	}{
+		{name: "Swap", call: 0, specs: []string{"b", "a"}, expected: "2, 1"},
+		{name: "Add default", call: 0, specs: []string{"a", "b", "c bool = true"}, expected: "1, 2, true"},
+		{name: "Move variadic arguments", call: 1, specs: []string{"b", "a", "scale"}, expected: "next(), 3, 1.5, 2.5"},
+		{name: "Remove variadic arguments", call: 1, specs: []string{"a", "b"}, expected: "3, next()"},
+		{name: "Keep ellipsis", call: 2, specs: []string{"b", "a", "scale"}, expected: "5, 4, values..."},
+		{name: "Remove side effect", call: 1, specs: []string{"a", "scale"}, error: "may have side effects"},
+		{name: "Keep order of side effects", call: 3, specs: []string{"a", "b", "c bool = true"}, expected: "first(), second(), true"},
+		{name: "Reorder side effects", call: 3, specs: []string{"b", "a"}, error: "the arguments first() and second() may have side effects, so they can't be reordered"},
+		{name: "Not a call", call: 4, specs: []string{"b", "a"}, error: "isn't a call"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := ParseParameterSpecs(tt.specs, decl.Params)
+			require.NoError(t, err)
+
+			edit, err := CallSiteEdit(fset, file, signatureSource, calls[tt.call], decl.Params, params)
+			if tt.error != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.error)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, edit.NewText)
+		})
+	}
+}
+
+func TestChangeSignatureByReferences(t *testing.T) {
+	content := "package main\n\nfunc sub(a int, b int) int {\n\treturn a - b\n}\n\nfunc main() {\n\tprintln(sub(1, 2))\n}\n"
+	expected := "package main\n\nfunc sub(b int, a int) int {\n\treturn a - b\n}\n\nfunc main() {\n\tprintln(sub(2, 1))\n}\n"
+
+	root := t.TempDir()
+	path := filepath.Join(root, "main.go")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	uri := PathToUri(path, "")
+
+	decl, err := ParseFuncDeclaration(path, content, strings.Index(content, "sub"))
+	require.NoError(t, err)
+	params, err := ParseParameterSpecs([]string{"b", "a"}, decl.Params)
+	require.NoError(t, err)
+
+	callSite := types.Location{URI: uri, Range: types.Range{Start: types.Position{Line: 7, Character: 9}, End: types.Position{Line: 7, Character: 12}}}
+	session := NewEditSession(root)
+	require.NoError(t, ChangeSignatureByReferences(session, path, decl, []types.Location{callSite}, params))
+
+	edited, err := session.Content(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, edited)
+
+	// Nothing is edited if a call site can't be updated
+	badSite := types.Location{URI: uri, Range: types.Range{Start: types.Position{Line: 3, Character: 8}}}
+	session = NewEditSession(root)
+	err = ChangeSignatureByReferences(session, path, decl, []types.Location{callSite, badSite}, params)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 call sites can't be updated")
+	assert.Empty(t, session.Changes())
+
+	// Call sites nested in the arguments of another call site are reported, since their edits would overlap
+	nested := "package main\n\nfunc sub(a int, b int) int {\n\treturn a - b\n}\n\nfunc main() {\n\tprintln(sub(sub(1, 2), 3))\n}\n"
+	require.NoError(t, os.WriteFile(path, []byte(nested), 0644))
+	decl, err = ParseFuncDeclaration(path, nested, strings.Index(nested, "sub"))
+	require.NoError(t, err)
+	outerSite := types.Location{URI: uri, Range: types.Range{Start: types.Position{Line: 7, Character: 9}}}
+	innerSite := types.Location{URI: uri, Range: types.Range{Start: types.Position{Line: 7, Character: 13}}}
+	session = NewEditSession(root)
+	err = ChangeSignatureByReferences(session, path, decl, []types.Location{outerSite, innerSite}, params)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "main.go:8:14: the call is nested in the arguments of the call at main.go:8:10")
+	assert.Empty(t, session.Changes())
+}
@@ -0,0 +1,280 @@
+package tools
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+// InterfaceReference represents an interface that a concrete type should implement
+type InterfaceReference struct {
+	ImportPath  string // Import path of the package declaring the interface, which is empty for the predeclared error interface
+	PackageName string // Name of the package declaring the interface
+	Name        string
+}
+
+// String returns the qualified name of the interface, like "io.Reader"
+func (r InterfaceReference) String() string {
+	if r.ImportPath == "" {
+		return r.Name
+	}
+	return r.ImportPath + "." + r.Name
+}
+
+// ParseInterfaceName parses the qualified name of an interface, like "io.Reader" or "example.com/project/shapes.Shape",
+// into its import path and name. Unqualified names refer to interfaces of the default package, except for the
+// predeclared error interface, which has no import path.
+func ParseInterfaceName(name string, defaultImportPath string) (importPath string, interfaceName string, err error) {
+	name = strings.TrimSpace(name)
+	if name == "error" {
+		return "", name, nil
+	}
+
+	importPath, interfaceName = defaultImportPath, name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		importPath, interfaceName = name[:i], name[i+1:]
+	}
+	if importPath == "" || !IsValidGoIdentifier(interfaceName) || strings.HasSuffix(importPath, "/") {
+		return "", "", fmt.Errorf("invalid interface name %q, expected a qualified name like io.Reader or example.com/project/pkg.Interface", name)
+	}
+	return importPath, interfaceName, nil
+}
+
+// interfaceAssertionEdits returns the text edits that append an assertion that a type implements an interface to the Go file
+// declaring the type, like "var _ io.Reader = (*Buffer)(nil)", along with the assertion. The interface's package is imported
+// if needed. With a value receiver, the assertion uses a value of the type instead of a nil pointer, so that gopls declares
+// the missing methods with value receivers.
+func interfaceAssertionEdits(path string, content string, typeImportPath string, typeName string, iface InterfaceReference, pointer bool) ([]types.TextEdit, string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	typeSpec := findTypeSpec(file, typeName)
+	if typeSpec == nil {
+		return nil, "", fmt.Errorf("no declaration of type %s found in %s", typeName, path)
+	}
+	if typeSpec.TypeParams != nil {
+		return nil, "", fmt.Errorf("%s is a generic type, whose methods can't be generated", typeName)
+	}
+	if _, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+		return nil, "", fmt.Errorf("%s is an interface, not a concrete type", typeName)
+	}
+
+	info, err := parseGoFileInfo(path, content)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var edits []types.TextEdit
+	interfaceType := iface.Name
+	if iface.ImportPath != "" && iface.ImportPath != typeImportPath {
+		if iface.PackageName == "main" {
+			return nil, "", fmt.Errorf("%s is declared in a main package, which can't be imported", iface)
+		}
+		qualifier := info.qualifierFor(iface.ImportPath, iface.PackageName)
+		if !info.hasImport(iface.ImportPath) {
+			if other, ok := info.importNamed(qualifier); ok {
+				return nil, "", fmt.Errorf("%s already imports %s as %s", path, other.Path, qualifier)
+			}
+			spec := importSpec{Path: iface.ImportPath}
+			if iface.PackageName != importName(iface.ImportPath) {
+				spec.Name = iface.PackageName
+			}
+			edits = append(edits, info.importEdit(spec))
+		}
+		interfaceType = qualifier + "." + iface.Name
+	}
+
+	value := fmt.Sprintf("(*%s)(nil)", typeName)
+	if !pointer {
+		value = fmt.Sprintf("*new(%s)", typeName)
+	}
+	assertion := fmt.Sprintf("var _ %s = %s", interfaceType, value)
+
+	text := "\n" + assertion + "\n"
+	if !strings.HasSuffix(content, "\n") {
+		text = "\n" + text
+	}
+	edits = append(edits, offsetTextEdit(content, len(content), len(content), text))
+	return edits, assertion, nil
+}
+
+// findTypeSpec finds the top-level declaration of a type in a parsed file
+func findTypeSpec(file *ast.File, typeName string) *ast.TypeSpec {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			if typeSpec := spec.(*ast.TypeSpec); typeSpec.Name.Name == typeName {
+				return typeSpec
+			}
+		}
+	}
+	return nil
+}
+
+// removeInterfaceAssertion removes the last occurrence of an interface assertion line from content, with the blank line before it
+func removeInterfaceAssertion(content string, assertion string) (string, bool) {
+	i := strings.LastIndex(content, assertion)
+	if i < 0 {
+		return content, false
+	}
+	start := strings.LastIndex(content[:i], "\n") + 1
+	end := len(content)
+	if j := strings.IndexByte(content[i:], '\n'); j >= 0 {
+		end = i + j + 1
+	}
+	start, end = collapseBlankLines(content, start, end)
+	return content[:start] + content[end:], true
+}
+
+// typeMethod represents a method declaration of a type in a parsed file
+type typeMethod struct {
+	name       string
+	decl       TopLevelDeclaration
+	start, end int // Byte range of the declaration, without its doc comment
+}
+
+// typeMethods returns the methods of a type declared in a Go file, in the order they are declared
+func typeMethods(path string, content string, typeName string) ([]typeMethod, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var methods []typeMethod
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 || receiverBaseName(funcDecl.Recv.List[0].Type) != typeName {
+			continue
+		}
+		start, end := fset.Position(funcDecl.Pos()).Offset, fset.Position(funcDecl.End()).Offset
+		methods = append(methods, typeMethod{
+			name: funcDecl.Name.Name,
+			decl: TopLevelDeclaration{
+				Name:  funcDecl.Name.Name,
+				Path:  path,
+				Range: types.Range{Start: offsetPosition(content, start), End: offsetPosition(content, end)},
+			},
+			start: start,
+			end:   end,
+		})
+	}
+	return methods, nil
+}
+
+// receiverBaseName returns the name of the type of a method receiver, without any pointer or type arguments
+func receiverBaseName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// PlaceStubMethods finds the methods of a type that were added to a Go file, comparing its content before and after the
+// stubs were generated, and moves those declared before the last existing method of the type to after it. Returns the
+// new content, with the names of the added methods and their code.
+func PlaceStubMethods(path string, before string, after string, typeName string) (string, []string, string, error) {
+	existing, err := typeMethods(path, before, typeName)
+	if err != nil {
+		return "", nil, "", err
+	}
+	isExisting := make(map[string]bool, len(existing))
+	for _, method := range existing {
+		isExisting[method.name] = true
+	}
+
+	methods, err := typeMethods(path, after, typeName)
+	if err != nil {
+		return "", nil, "", err
+	}
+	var stubs []typeMethod
+	var last *typeMethod // Last existing method of the type
+	for i, method := range methods {
+		if isExisting[method.name] {
+			last = &methods[i]
+		} else {
+			stubs = append(stubs, method)
+		}
+	}
+
+	names := make([]string, 0, len(stubs))
+	var code []string
+	var moved []movedBlock
+	for _, stub := range stubs {
+		block, err := declarationBlock(after, stub.decl)
+		if err != nil {
+			return "", nil, "", err
+		}
+		names = append(names, stub.name)
+		code = append(code, block.text)
+		if last != nil && stub.start < last.start {
+			moved = append(moved, block)
+		}
+	}
+	if len(moved) == 0 {
+		return after, names, strings.Join(code, "\n\n"), nil
+	}
+
+	sort.Slice(moved, func(i, j int) bool {
+		return moved[i].start < moved[j].start
+	})
+	// Adjacent blocks may both remove the blank line between them
+	for i := 1; i < len(moved); i++ {
+		if moved[i].start < moved[i-1].end {
+			moved[i].start = moved[i-1].end
+		}
+	}
+
+	var edits []types.TextEdit
+	var texts []string
+	for _, block := range moved {
+		edits = append(edits, offsetTextEdit(after, block.start, block.end, ""))
+		texts = append(texts, block.text)
+	}
+	edits = append(edits, offsetTextEdit(after, last.end, last.end, "\n\n"+strings.Join(texts, "\n\n")))
+	placed, err := ApplyTextEdits(after, edits)
+	if err != nil {
+		return "", nil, "", err
+	}
+	return placed, names, strings.Join(code, "\n\n"), nil
+}
+
+// isStubCodeAction checks if a code action declares the missing methods of an interface, whose title depends on the gopls version
+func isStubCodeAction(action types.CodeAction) bool {
+	if !codeActionKindMatches(action.Kind, types.CodeActionKindQuickFix) {
+		return false
+	}
+	return strings.HasPrefix(action.Title, "Declare missing method") || strings.HasPrefix(action.Title, "Implement ")
+}
+
+// findStubCodeAction finds the code action that declares the missing methods of an interface
+func findStubCodeAction(actions []types.CodeAction) (*types.CodeAction, error) {
+	for i, action := range actions {
+		if isStubCodeAction(action) && action.Disabled == nil {
+			return &actions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no code action declares the missing methods; the type may already implement the interface")
+}
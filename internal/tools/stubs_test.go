@@ -0,0 +1,256 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stubsBufferSource = `package buffer
+
+import "fmt"
+
+// Buffer is a buffer
+type Buffer struct {
+	data []byte
+}
+
+// Len returns the length of the buffer
+func (b *Buffer) Len() int {
+	return len(b.data)
+}
+
+// String returns the buffer as a string
+func (b *Buffer) String() string {
+	return fmt.Sprint(b.data)
+}
+`
+
+func TestParseInterfaceName(t *testing.T) {
+	tests := []struct {
+		name               string
+		input              string
+		expectedImportPath string
+		expectedName       string
+		expectedErr        bool
+	}{
+		{name: "Standard library", input: "io.Reader", expectedImportPath: "io", expectedName: "Reader"},
+		{name: "Nested import path", input: " example.com/m/shapes.Shape ", expectedImportPath: "example.com/m/shapes", expectedName: "Shape"},
+		{name: "Versioned import path", input: "example.com/m/v2.Shape", expectedImportPath: "example.com/m/v2", expectedName: "Shape"},
+		{name: "Unqualified", input: "Shape", expectedImportPath: "example.com/m", expectedName: "Shape"},
+		{name: "Error interface", input: "error", expectedName: "error"},
+		{name: "Missing name", input: "io.", expectedErr: true},
+		{name: "Missing import path", input: ".Reader", expectedErr: true},
+		{name: "Invalid name", input: "io.Read-er", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importPath, name, err := ParseInterfaceName(tt.input, "example.com/m")
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedImportPath, importPath)
+			assert.Equal(t, tt.expectedName, name)
+		})
+	}
+}
+
+func TestInterfaceAssertionEdits(t *testing.T) {
+	tests := []struct {
+		name              string
+		content           string
+		typeName          string
+		iface             InterfaceReference
+		pointer           bool
+		expectedAssertion string
+		expectedImport    string
+		expectedErr       string
+	}{
+		{
+			name:              "Imported interface",
+			content:           stubsBufferSource,
+			typeName:          "Buffer",
+			iface:             InterfaceReference{ImportPath: "io", PackageName: "io", Name: "Reader"},
+			pointer:           true,
+			expectedAssertion: "var _ io.Reader = (*Buffer)(nil)",
+			expectedImport:    "import \"fmt\"\nimport \"io\"\n",
+		},
+		{
+			name:              "Already imported interface",
+			content:           stubsBufferSource,
+			typeName:          "Buffer",
+			iface:             InterfaceReference{ImportPath: "fmt", PackageName: "fmt", Name: "Stringer"},
+			expectedAssertion: "var _ fmt.Stringer = *new(Buffer)",
+		},
+		{
+			name:              "Interface of the same package",
+			content:           stubsBufferSource,
+			typeName:          "Buffer",
+			iface:             InterfaceReference{ImportPath: "example.com/m/buffer", PackageName: "buffer", Name: "Sizer"},
+			pointer:           true,
+			expectedAssertion: "var _ Sizer = (*Buffer)(nil)",
+		},
+		{
+			name:              "Error interface",
+			content:           stubsBufferSource,
+			typeName:          "Buffer",
+			iface:             InterfaceReference{Name: "error"},
+			pointer:           true,
+			expectedAssertion: "var _ error = (*Buffer)(nil)",
+		},
+		{
+			name:              "Package name differs from import path",
+			content:           stubsBufferSource,
+			typeName:          "Buffer",
+			iface:             InterfaceReference{ImportPath: "example.com/go-shapes", PackageName: "geometry", Name: "Shape"},
+			pointer:           true,
+			expectedAssertion: "var _ geometry.Shape = (*Buffer)(nil)",
+			expectedImport:    "import \"fmt\"\nimport geometry \"example.com/go-shapes\"\n",
+		},
+		{
+			name:        "Conflicting import",
+			content:     stubsBufferSource,
+			typeName:    "Buffer",
+			iface:       InterfaceReference{ImportPath: "example.com/fmt", PackageName: "fmt", Name: "Formatter"},
+			expectedErr: "already imports fmt as fmt",
+		},
+		{
+			name:        "Generic type",
+			content:     "package buffer\n\ntype List[T any] struct{}\n",
+			typeName:    "List",
+			iface:       InterfaceReference{Name: "error"},
+			expectedErr: "generic type",
+		},
+		{
+			name:        "Interface type",
+			content:     "package buffer\n\ntype Sizer interface{ Len() int }\n",
+			typeName:    "Sizer",
+			iface:       InterfaceReference{Name: "error"},
+			expectedErr: "not a concrete type",
+		},
+		{
+			name:        "Missing type",
+			content:     stubsBufferSource,
+			typeName:    "Queue",
+			iface:       InterfaceReference{Name: "error"},
+			expectedErr: "no declaration of type Queue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits, assertion, err := interfaceAssertionEdits("buffer.go", tt.content, "example.com/m/buffer", tt.typeName, tt.iface, tt.pointer)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAssertion, assertion)
+
+			edited, err := ApplyTextEdits(tt.content, edits)
+			require.NoError(t, err)
+			assert.True(t, strings.HasSuffix(edited, "}\n\n"+assertion+"\n"))
+			if tt.expectedImport != "" {
+				assert.Contains(t, edited, tt.expectedImport)
+			} else {
+				assert.Equal(t, tt.content+"\n"+assertion+"\n", edited)
+			}
+
+			removed, ok := removeInterfaceAssertion(edited, assertion)
+			assert.True(t, ok)
+			assert.Equal(t, edited[:len(edited)-len(assertion)-2], removed)
+		})
+	}
+}
+
+const stubsGenerated = `// Read implements io.Reader.
+func (b *Buffer) Read(p []byte) (n int, err error) {
+	panic("unimplemented")
+}
+
+// Close implements io.Closer.
+func (b *Buffer) Close() error {
+	panic("unimplemented")
+}`
+
+func TestPlaceStubMethods(t *testing.T) {
+	typeEnd := "type Buffer struct {\n\tdata []byte\n}\n"
+	afterType := strings.Replace(stubsBufferSource, typeEnd, typeEnd+"\n"+stubsGenerated+"\n", 1)
+	atEnd := stubsBufferSource + "\n" + stubsGenerated + "\n"
+	noMethods := "package buffer\n\ntype Buffer struct{}\n"
+
+	tests := []struct {
+		name            string
+		before          string
+		after           string
+		expected        string
+		expectedMethods []string
+	}{
+		{name: "Stubs after the type", before: stubsBufferSource, after: afterType, expected: atEnd, expectedMethods: []string{"Read", "Close"}},
+		{name: "Stubs after the existing methods", before: stubsBufferSource, after: atEnd, expected: atEnd, expectedMethods: []string{"Read", "Close"}},
+		{name: "No existing methods", before: noMethods, after: noMethods + "\n" + stubsGenerated + "\n", expected: noMethods + "\n" + stubsGenerated + "\n", expectedMethods: []string{"Read", "Close"}},
+		{name: "No stubs", before: stubsBufferSource, after: stubsBufferSource, expected: stubsBufferSource, expectedMethods: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placed, methods, code, err := PlaceStubMethods("buffer.go", tt.before, tt.after, "Buffer")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, placed)
+			assert.Equal(t, tt.expectedMethods, methods)
+			if len(tt.expectedMethods) > 0 {
+				assert.Equal(t, stubsGenerated, code)
+			}
+		})
+	}
+}
+
+func TestFindStubCodeAction(t *testing.T) {
+	tests := []struct {
+		name          string
+		actions       []types.CodeAction
+		expectedTitle string
+	}{
+		{
+			name: "Declare missing methods",
+			actions: []types.CodeAction{
+				{Title: "Browse gopls feature documentation", Kind: "gopls.doc.features"},
+				{Title: "Declare missing methods of io.Reader", Kind: types.CodeActionKindQuickFix},
+			},
+			expectedTitle: "Declare missing methods of io.Reader",
+		},
+		{
+			name:          "Older gopls title",
+			actions:       []types.CodeAction{{Title: "Implement io.Reader", Kind: types.CodeActionKindQuickFix}},
+			expectedTitle: "Implement io.Reader",
+		},
+		{
+			name:    "Disabled",
+			actions: []types.CodeAction{{Title: "Declare missing methods of io.Reader", Kind: types.CodeActionKindQuickFix, Disabled: &types.CodeActionDisabled{Reason: "no"}}},
+		},
+		{
+			name:    "Other kind",
+			actions: []types.CodeAction{{Title: "Implement the method later", Kind: types.CodeActionKindRefactorRewrite}},
+		},
+		{name: "No code actions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, err := findStubCodeAction(tt.actions)
+			if tt.expectedTitle == "" {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedTitle, action.Title)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+)
+
+// unusedCandidate represents a top-level declaration of a package whose references are checked
+type unusedCandidate struct {
+	entry packageDocumentSymbol
+	own   []types.Location // Declarations of the symbol and of the methods of a type, whose references don't count as uses
+}
+
+// unusedCandidates returns the top-level declarations of a package, grouped by groupPackageSymbols, whose references should be
+// checked. Entry points like main and init, blank names, and allowlisted symbols are skipped, as are exported symbols unless
+// includeExported is set. Methods are left to the unusedfunc analyzer, since a method that satisfies an interface has no
+// direct references.
+func unusedCandidates(importPath string, packageName string, grouped []packageDocumentSymbol, allowlist []string, includeExported bool) []unusedCandidate {
+	var candidates []unusedCandidate
+	for _, entry := range grouped {
+		name := entry.symbol.Name
+		switch {
+		case isEntryPoint(packageName, entry.symbol):
+		case results.NewSymbolIdentifier(name) == "_":
+		case !includeExported && IsExportedName(name):
+		case IsAllowlisted(allowlist, importPath, name):
+		default:
+			own := []types.Location{{URI: entry.uri, Range: entry.symbol.Range}}
+			for _, method := range entry.methods {
+				own = append(own, types.Location{URI: method.uri, Range: method.symbol.Range})
+			}
+			candidates = append(candidates, unusedCandidate{entry: entry, own: own})
+		}
+	}
+	return candidates
+}
+
+// allowlistedMethods returns the declarations of the allowlisted methods of a package, grouped by groupPackageSymbols, whose
+// unusedfunc diagnostics shouldn't be reported. A method is allowlisted by its own symbol path or by the path of its type.
+func allowlistedMethods(importPath string, grouped []packageDocumentSymbol, allowlist []string) []types.Location {
+	var decls []types.Location
+	for _, entry := range grouped {
+		for _, method := range entry.methods {
+			if IsAllowlisted(allowlist, importPath, entry.symbol.Name) || IsAllowlisted(allowlist, importPath, method.symbol.Name) {
+				decls = append(decls, types.Location{URI: method.uri, Range: method.symbol.Range})
+			}
+		}
+	}
+	return decls
+}
+
+// isEntryPoint checks if a top-level document symbol is called without references: the main function of a main package,
+// or an init function
+func isEntryPoint(packageName string, symbol types.DocumentSymbol) bool {
+	if results.NewSymbolKind(symbol.Kind) != results.SymbolKindFunction {
+		return false
+	}
+	return symbol.Name == "init" || (symbol.Name == "main" && packageName == "main")
+}
+
+// IsAllowlisted checks if a symbol of a package matches any allowlist pattern. Patterns match package import paths
+// (like "example.com/project/api/..."), symbol paths (like "Calculator" or "Calculator.Add"), or symbol paths qualified
+// with their import path (like "example.com/project/calc.Calculator.Add"), where "..." matches any string.
+func IsAllowlisted(allowlist []string, importPath string, name string) bool {
+	symbolPath := results.NewSemanticSymbolPath(name)
+	for _, pattern := range allowlist {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if MatchPackagePattern(pattern, importPath) || MatchPackagePattern(pattern, symbolPath) || MatchPackagePattern(pattern, importPath+"."+symbolPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// countUses counts the references to a symbol outside of its own declarations, separately for test files
+func countUses(references []types.Location, own []types.Location) (uses int, testUses int) {
+	for _, reference := range references {
+		if isOwnReference(reference, own) {
+			continue
+		}
+		if IsTestFile(UriToPath(reference.URI)) {
+			testUses++
+		} else {
+			uses++
+		}
+	}
+	return uses, testUses
+}
+
+// isOwnReference checks if a reference is inside one of the declarations of its symbol
+func isOwnReference(reference types.Location, own []types.Location) bool {
+	for _, decl := range own {
+		if UriToPath(decl.URI) == UriToPath(reference.URI) && rangeContains(decl.Range, reference.Range.Start) {
+			return true
+		}
+	}
+	return false
+}
+
+// unusedDiagnosticSource returns the analyzer of a diagnostic that reports unused code, like unusedparams or unusedfunc
+func unusedDiagnosticSource(diagnostic types.Diagnostic) (results.UnusedSource, bool) {
+	analyzers := []string{diagnostic.Source}
+	if diagnostic.Code != nil {
+		analyzers = append(analyzers, fmt.Sprint(diagnostic.Code))
+	}
+	for _, analyzer := range analyzers {
+		switch source := results.UnusedSource(analyzer); source {
+		case results.UnusedSourceUnusedParams, results.UnusedSourceUnusedFunc:
+			return source, true
+		}
+	}
+	return "", false
+}
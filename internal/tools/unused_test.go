@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnusedCandidates(t *testing.T) {
+	symbol := func(name string, kind results.SymbolKind, line int) packageDocumentSymbol {
+		lspKind := map[results.SymbolKind]int{
+			results.SymbolKindFunction: 12,
+			results.SymbolKindMethod:   6,
+			results.SymbolKindStruct:   23,
+			results.SymbolKindVariable: 13,
+		}[kind]
+		rng := types.Range{Start: types.Position{Line: line}, End: types.Position{Line: line + 2}}
+		return packageDocumentSymbol{uri: "file:///m/p.go", file: "p.go", symbol: types.DocumentSymbol{Name: name, Kind: lspKind, Range: rng, SelectionRange: rng}}
+	}
+	grouped := groupPackageSymbols([]packageDocumentSymbol{
+		symbol("main", results.SymbolKindFunction, 0),
+		symbol("init", results.SymbolKindFunction, 3),
+		symbol("Calculator", results.SymbolKindStruct, 6),
+		symbol("(*Calculator).Add", results.SymbolKindMethod, 9),
+		symbol("helper", results.SymbolKindFunction, 12),
+		symbol("_", results.SymbolKindVariable, 15),
+		symbol("Version", results.SymbolKindVariable, 18),
+	})
+
+	names := func(candidates []unusedCandidate) []string {
+		var names []string
+		for _, candidate := range candidates {
+			names = append(names, candidate.entry.symbol.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name            string
+		packageName     string
+		allowlist       []string
+		includeExported bool
+		expected        []string
+	}{
+		{name: "Main package", packageName: "main", includeExported: true, expected: []string{"Calculator", "helper", "Version"}},
+		{name: "Library package", packageName: "calc", includeExported: true, expected: []string{"main", "Calculator", "helper", "Version"}},
+		{name: "Unexported only", packageName: "main", expected: []string{"helper"}},
+		{name: "Allowlisted type with methods", packageName: "main", allowlist: []string{"Calculator"}, includeExported: true, expected: []string{"helper", "Version"}},
+		{name: "Allowlisted package", packageName: "main", allowlist: []string{"example.com/..."}, includeExported: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := unusedCandidates("example.com/m", tt.packageName, grouped, tt.allowlist, tt.includeExported)
+			assert.Equal(t, tt.expected, names(candidates))
+		})
+	}
+
+	// References from the methods of a type don't count as uses of the type
+	candidates := unusedCandidates("example.com/m", "main", grouped, nil, true)
+	assert.Len(t, candidates[0].own, 2)
+}
+
+func TestAllowlistedMethods(t *testing.T) {
+	rng := func(line int) types.Range {
+		return types.Range{Start: types.Position{Line: line}, End: types.Position{Line: line + 2}}
+	}
+	method := packageDocumentSymbol{uri: "file:///m/p.go", file: "p.go", symbol: types.DocumentSymbol{Name: "(*Calculator).Add", Kind: 6, Range: rng(3)}}
+	grouped := []packageDocumentSymbol{
+		{uri: "file:///m/p.go", file: "p.go", symbol: types.DocumentSymbol{Name: "Calculator", Kind: 23, Range: rng(0)}, methods: []packageDocumentSymbol{method}},
+	}
+
+	tests := []struct {
+		name      string
+		allowlist []string
+		expected  []types.Location
+	}{
+		{name: "Not allowlisted", allowlist: []string{"helper"}},
+		{name: "Allowlisted method", allowlist: []string{"example.com/m.Calculator.Add"}, expected: []types.Location{{URI: "file:///m/p.go", Range: rng(3)}}},
+		{name: "Allowlisted type", allowlist: []string{"Calculator"}, expected: []types.Location{{URI: "file:///m/p.go", Range: rng(3)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, allowlistedMethods("example.com/m", grouped, tt.allowlist))
+		})
+	}
+}
+
+func TestIsAllowlisted(t *testing.T) {
+	tests := []struct {
+		name       string
+		allowlist  []string
+		importPath string
+		symbol     string
+		expected   bool
+	}{
+		{name: "Empty allowlist", importPath: "example.com/m/api", symbol: "Handler"},
+		{name: "Package", allowlist: []string{"example.com/m/api"}, importPath: "example.com/m/api", symbol: "Handler", expected: true},
+		{name: "Package pattern", allowlist: []string{"example.com/m/..."}, importPath: "example.com/m/api/v2", symbol: "Handler", expected: true},
+		{name: "Symbol path", allowlist: []string{" Calculator.Add "}, importPath: "example.com/m", symbol: "(*Calculator).Add", expected: true},
+		{name: "Qualified symbol path", allowlist: []string{"example.com/m/api.Handle..."}, importPath: "example.com/m/api", symbol: "HandleFunc", expected: true},
+		{name: "Other symbol", allowlist: []string{"Calculator.Add"}, importPath: "example.com/m", symbol: "(*Calculator).Subtract"},
+		{name: "Blank pattern", allowlist: []string{""}, importPath: "example.com/m", symbol: "Handler"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsAllowlisted(tt.allowlist, tt.importPath, tt.symbol))
+		})
+	}
+}
+
+func TestCountUses(t *testing.T) {
+	location := func(file string, line int) types.Location {
+		position := types.Position{Line: line, Character: 1}
+		return types.Location{URI: "file:///m/" + file, Range: types.Range{Start: position, End: position}}
+	}
+	own := []types.Location{{URI: "file:///m/p.go", Range: types.Range{Start: types.Position{Line: 2}, End: types.Position{Line: 5}}}}
+
+	uses, testUses := countUses([]types.Location{location("p.go", 2), location("p.go", 4), location("p_test.go", 3)}, own)
+	assert.Equal(t, 0, uses)
+	assert.Equal(t, 1, testUses)
+
+	uses, testUses = countUses([]types.Location{location("p.go", 2), location("p.go", 8), location("q.go", 3)}, own)
+	assert.Equal(t, 2, uses)
+	assert.Equal(t, 0, testUses)
+}
+
+func TestUnusedDiagnosticSource(t *testing.T) {
+	tests := []struct {
+		name       string
+		diagnostic types.Diagnostic
+		expected   results.UnusedSource
+		expectedOK bool
+	}{
+		{name: "Source", diagnostic: types.Diagnostic{Source: "unusedparams", Message: "unused parameter: x"}, expected: results.UnusedSourceUnusedParams, expectedOK: true},
+		{name: "Code", diagnostic: types.Diagnostic{Source: "gopls", Code: "unusedfunc"}, expected: results.UnusedSourceUnusedFunc, expectedOK: true},
+		{name: "Other analyzer", diagnostic: types.Diagnostic{Source: "unusedwrite"}},
+		{name: "Compiler", diagnostic: types.Diagnostic{Source: "compiler", Code: "UnusedVar"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, ok := unusedDiagnosticSource(tt.diagnostic)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expected, source)
+		})
+	}
+}
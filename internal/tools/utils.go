@@ -1,8 +1,20 @@
 package tools
 
 import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+
+	"github.com/mark3labs/mcp-go/mcp"
 )
 
 // PathToUri converts a file path to a file URI
@@ -31,6 +43,236 @@ func GetRelativePath(absolutePath, workspaceRoot string) string {
 	return filepath.Base(absolutePath)
 }
 
+// GetDisplayPath returns the path of a file relative to the workspace root,
+// or its absolute path if it's outside the workspace (e.g. in the module cache or standard library)
+func GetDisplayPath(absolutePath string, workspaceRoot string) (path string, external bool) {
+	rel, err := filepath.Rel(workspaceRoot, absolutePath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return absolutePath, true
+	}
+	return rel, false
+}
+
+// IsReadOnlyFile checks if a file shouldn't be edited, because it's in the standard library or isn't writable (e.g. in the module cache)
+func IsReadOnlyFile(absolutePath string) bool {
+	if goroot := build.Default.GOROOT; goroot != "" && strings.HasPrefix(absolutePath, goroot+string(filepath.Separator)) {
+		return true
+	}
+	info, err := os.Stat(absolutePath)
+	return err == nil && info.Mode().Perm()&0o222 == 0
+}
+
+// ParseStringArray parses an array of strings from a tool request, also accepting a single comma-separated string
+func ParseStringArray(req mcp.CallToolRequest, key string) []string {
+	var values []string
+	switch v := mcp.ParseArgument(req, key, nil).(type) {
+	case []any:
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				values = append(values, str)
+			}
+		}
+	case []string:
+		values = v
+	case string:
+		values = strings.Split(v, ",")
+	}
+
+	trimmed := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+	return trimmed
+}
+
+// GetHoverInfo gets structured hover information for a position, or returns nil if there is none
+func GetHoverInfo(ctx context.Context, client types.Client, uri string, position types.Position) *results.HoverInfo {
+	markdown, err := client.GetHoverInfo(ctx, uri, position)
+	if err != nil || markdown == "" {
+		return nil
+	}
+
+	hoverInfo := results.NewHoverInfo(markdown)
+	// Packages without documentation links (e.g. main packages) can still be identified from the file
+	if hoverInfo.PackagePath == "" {
+		hoverInfo.PackagePath = PackageImportPath(UriToPath(uri))
+	}
+	return &hoverInfo
+}
+
+// GetGoSymbolKind returns the Go symbol kind of a symbol from its LSP kind, name and detail. Types are classified from their
+// declaration in the file, which tells type aliases, generic types and named func types apart; the signature in the hover
+// information, if it's already loaded, is only used when the declaration can't be parsed. Fields that look embedded are checked
+// against their declaration too, since gopls gives embedded fields and fields named after their type (like "Base Base") the same
+// name and detail.
+func GetGoSymbolKind(uri string, position types.Position, kind int, name string, detail string, hoverInfo *results.HoverInfo) results.GoSymbolKind {
+	switch results.NewSymbolKind(kind) {
+	case results.SymbolKindMethod, results.SymbolKindField, results.SymbolKindVariable, results.SymbolKindConstant, results.SymbolKindPackage, results.SymbolKindTypeParameter:
+	default:
+		if goKind := typeSpecKindAt(UriToPath(uri), position); goKind != results.GoSymbolKindUnknown {
+			return goKind
+		}
+	}
+
+	signature := ""
+	if hoverInfo != nil {
+		signature = hoverInfo.Signature
+	}
+
+	goKind := results.NewGoSymbolKind(kind, name, detail, signature)
+	if goKind == results.GoSymbolKindEmbeddedField {
+		if lines, err := ReadFileLines(UriToPath(uri)); err == nil && position.Line < len(lines) && !IsEmbeddedFieldAt(lines[position.Line], position.Character) {
+			goKind = results.GoSymbolKindField
+		}
+	}
+	return goKind
+}
+
+// typeSpecKindAt returns the Go symbol kind of the type declared with its name at a position in a Go file,
+// or GoSymbolKindUnknown if no type is declared there or the file can't be parsed
+func typeSpecKindAt(filePath string, position types.Position) results.GoSymbolKind {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return results.GoSymbolKindUnknown
+	}
+	offset, err := positionOffset(string(content), lineOffsets(string(content)), position)
+	if err != nil {
+		return results.GoSymbolKindUnknown
+	}
+
+	// Files with syntax errors are still partially parsed
+	fset := token.NewFileSet()
+	file, _ := parser.ParseFile(fset, filePath, content, parser.SkipObjectResolution)
+	if file == nil {
+		return results.GoSymbolKindUnknown
+	}
+
+	goKind := results.GoSymbolKindUnknown
+	ast.Inspect(file, func(node ast.Node) bool {
+		if typeSpec, ok := node.(*ast.TypeSpec); ok && fset.Position(typeSpec.Name.Pos()).Offset == offset {
+			goKind = results.NewTypeSpecKind(typeSpec)
+		}
+		return goKind == results.GoSymbolKindUnknown
+	})
+	return goKind
+}
+
+// IsEmbeddedFieldAt checks if the struct field whose name starts at a UTF-16 character offset in a line is embedded.
+// gopls positions embedded fields at the name of their type, which is followed by nothing but type arguments, a tag or a comment,
+// while named fields are followed by their type or by more names.
+func IsEmbeddedFieldAt(line string, character int) bool {
+	name := IdentifierAt(line, character)
+	if name == "" {
+		return false
+	}
+	start, _ := utf16OffsetToByte(line, character)
+	rest := strings.TrimSpace(line[start+len(name):])
+
+	// Skip the type arguments of generic embedded types, like "List[T]"
+	if strings.HasPrefix(rest, "[") {
+		depth := 0
+		for i, r := range rest {
+			if r == '[' {
+				depth++
+			} else if r == ']' {
+				depth--
+			}
+			if depth == 0 {
+				rest = strings.TrimSpace(rest[i+1:])
+				break
+			}
+		}
+	}
+
+	switch {
+	case rest == "", strings.HasPrefix(rest, "//"), strings.HasPrefix(rest, "/*"):
+		return true
+	case strings.HasPrefix(rest, "`"), strings.HasPrefix(rest, `"`), strings.HasPrefix(rest, ";"), strings.HasPrefix(rest, "}"):
+		return true
+	default:
+		return false
+	}
+}
+
+// ReadFileLines reads a file and splits it into lines
+func ReadFileLines(filePath string) ([]string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n"), nil
+}
+
+// GetSourceLines returns the source line at a display line, surrounded by up to contextLines lines on each side
+func GetSourceLines(lines []string, displayLine int, contextLines int) []results.SourceLine {
+	if displayLine < 1 || displayLine > len(lines) {
+		return nil
+	}
+
+	start := max(displayLine-contextLines, 1)
+	end := min(displayLine+contextLines, len(lines))
+
+	sourceLines := make([]results.SourceLine, 0, end-start+1)
+	for line := start; line <= end; line++ {
+		sourceLines = append(sourceLines, results.SourceLine{
+			DisplayLine: line,
+			Text:        lines[line-1],
+		})
+	}
+	return sourceLines
+}
+
+// FindEnclosingFunction returns the innermost function or method symbol containing the position, or nil if there is none
+func FindEnclosingFunction(symbols []types.DocumentSymbol, position types.Position) *types.DocumentSymbol {
+	var enclosing *types.DocumentSymbol
+	for i := range symbols {
+		sym := &symbols[i]
+		if !rangeContains(sym.Range, position) {
+			continue
+		}
+		if kind := results.NewSymbolKind(sym.Kind); kind == results.SymbolKindMethod || kind == results.SymbolKindFunction {
+			enclosing = sym
+		}
+		if inner := FindEnclosingFunction(sym.Children, position); inner != nil {
+			enclosing = inner
+		}
+	}
+	return enclosing
+}
+
+// FindDocumentSymbolAt returns the symbol whose name starts at a position, or nil if there is none
+func FindDocumentSymbolAt(symbols []types.DocumentSymbol, position types.Position) *types.DocumentSymbol {
+	for i := range symbols {
+		sym := &symbols[i]
+		if sym.SelectionRange.Start == position {
+			return sym
+		}
+		if !rangeContains(sym.Range, position) {
+			continue
+		}
+		if child := FindDocumentSymbolAt(sym.Children, position); child != nil {
+			return child
+		}
+	}
+	return nil
+}
+
+// rangeContains checks if a position falls within a range (inclusive of both ends)
+func rangeContains(r types.Range, position types.Position) bool {
+	if position.Line < r.Start.Line || position.Line > r.End.Line {
+		return false
+	}
+	if position.Line == r.Start.Line && position.Character < r.Start.Character {
+		return false
+	}
+	if position.Line == r.End.Line && position.Character > r.End.Character {
+		return false
+	}
+	return true
+}
+
 // IsValidGoIdentifier checks if a string is a valid Go identifier
 func IsValidGoIdentifier(name string) bool {
 	if name == "" {
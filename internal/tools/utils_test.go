@@ -1,9 +1,13 @@
 package tools
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/averycrespi/gopls-mcp/internal/results"
+	"github.com/averycrespi/gopls-mcp/pkg/types"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -151,6 +155,28 @@ func TestGetRelativePath(t *testing.T) {
 	}
 }
 
+func TestGetDisplayPath(t *testing.T) {
+	path, external := GetDisplayPath("/home/user/project/src/helper.go", "/home/user/project")
+	assert.Equal(t, filepath.FromSlash("src/helper.go"), path)
+	assert.False(t, external)
+
+	path, external = GetDisplayPath("/home/user/go/pkg/mod/example.com/lib@v1.0.0/lib.go", "/home/user/project")
+	assert.Equal(t, "/home/user/go/pkg/mod/example.com/lib@v1.0.0/lib.go", path)
+	assert.True(t, external)
+}
+
+func TestIsReadOnlyFile(t *testing.T) {
+	dir := t.TempDir()
+	writable := filepath.Join(dir, "writable.go")
+	readOnly := filepath.Join(dir, "read_only.go")
+	assert.NoError(t, os.WriteFile(writable, []byte("package p\n"), 0o644))
+	assert.NoError(t, os.WriteFile(readOnly, []byte("package p\n"), 0o444))
+
+	assert.False(t, IsReadOnlyFile(writable))
+	assert.True(t, IsReadOnlyFile(readOnly))
+	assert.False(t, IsReadOnlyFile(filepath.Join(dir, "missing.go")))
+}
+
 func TestIsValidGoIdentifier(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -188,3 +214,223 @@ func TestIsValidGoIdentifier(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStringArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected []string
+	}{
+		{"Array of strings", []any{"struct", " function "}, []string{"struct", "function"}},
+		{"Comma-separated string", "struct, function", []string{"struct", "function"}},
+		{"Empty values are dropped", []any{"", "struct", 42}, []string{"struct"}},
+		{"Missing argument", nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{}
+			arguments := map[string]any{}
+			if tt.value != nil {
+				arguments["kinds"] = tt.value
+			}
+			req.Params.Arguments = arguments
+
+			assert.Equal(t, tt.expected, ParseStringArray(req, "kinds"))
+		})
+	}
+}
+
+func TestReadFileLines(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "main.go")
+	err := os.WriteFile(filePath, []byte("package main\r\n\nfunc main() {}\n"), 0o644)
+	assert.NoError(t, err)
+
+	lines, err := ReadFileLines(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"package main", "", "func main() {}", ""}, lines)
+
+	_, err = ReadFileLines(filepath.Join(t.TempDir(), "missing.go"))
+	assert.Error(t, err)
+}
+
+func TestGetSourceLines(t *testing.T) {
+	lines := []string{"line 1", "line 2", "line 3", "line 4", "line 5"}
+
+	tests := []struct {
+		name         string
+		displayLine  int
+		contextLines int
+		expected     []results.SourceLine
+	}{
+		{
+			name:         "Single line",
+			displayLine:  3,
+			contextLines: 0,
+			expected:     []results.SourceLine{{DisplayLine: 3, Text: "line 3"}},
+		},
+		{
+			name:         "With context",
+			displayLine:  3,
+			contextLines: 1,
+			expected: []results.SourceLine{
+				{DisplayLine: 2, Text: "line 2"},
+				{DisplayLine: 3, Text: "line 3"},
+				{DisplayLine: 4, Text: "line 4"},
+			},
+		},
+		{
+			name:         "Context clamped at start",
+			displayLine:  1,
+			contextLines: 2,
+			expected: []results.SourceLine{
+				{DisplayLine: 1, Text: "line 1"},
+				{DisplayLine: 2, Text: "line 2"},
+				{DisplayLine: 3, Text: "line 3"},
+			},
+		},
+		{
+			name:         "Context clamped at end",
+			displayLine:  5,
+			contextLines: 1,
+			expected: []results.SourceLine{
+				{DisplayLine: 4, Text: "line 4"},
+				{DisplayLine: 5, Text: "line 5"},
+			},
+		},
+		{
+			name:         "Line out of range",
+			displayLine:  6,
+			contextLines: 0,
+			expected:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetSourceLines(lines, tt.displayLine, tt.contextLines)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestFindEnclosingFunction(t *testing.T) {
+	symbols := []types.DocumentSymbol{
+		{
+			Name:  "Calculator",
+			Kind:  23, // Struct
+			Range: types.Range{Start: types.Position{Line: 5}, End: types.Position{Line: 7, Character: 1}},
+		},
+		{
+			Name:  "(*Calculator).Add",
+			Kind:  6, // Method
+			Range: types.Range{Start: types.Position{Line: 10}, End: types.Position{Line: 13, Character: 1}},
+		},
+		{
+			Name:  "main",
+			Kind:  12, // Function
+			Range: types.Range{Start: types.Position{Line: 15}, End: types.Position{Line: 25, Character: 1}},
+			Children: []types.DocumentSymbol{
+				{
+					Name:  "helper",
+					Kind:  12, // Function
+					Range: types.Range{Start: types.Position{Line: 17}, End: types.Position{Line: 19, Character: 1}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		position types.Position
+		expected string
+	}{
+		{"Inside method", types.Position{Line: 11, Character: 4}, "(*Calculator).Add"},
+		{"Inside function", types.Position{Line: 20, Character: 2}, "main"},
+		{"Inside nested function", types.Position{Line: 18, Character: 2}, "helper"},
+		{"Inside struct", types.Position{Line: 6, Character: 2}, ""},
+		{"Outside all symbols", types.Position{Line: 30, Character: 0}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FindEnclosingFunction(symbols, tt.position)
+			if tt.expected == "" {
+				assert.Nil(t, result)
+			} else {
+				assert.NotNil(t, result)
+				assert.Equal(t, tt.expected, result.Name)
+			}
+		})
+	}
+}
+
+func TestFindDocumentSymbolAt(t *testing.T) {
+	symbols := []types.DocumentSymbol{
+		{
+			Name:           "Calculator",
+			Range:          types.Range{Start: types.Position{Line: 5}, End: types.Position{Line: 7, Character: 1}},
+			SelectionRange: types.Range{Start: types.Position{Line: 5, Character: 5}, End: types.Position{Line: 5, Character: 15}},
+			Children: []types.DocumentSymbol{
+				{
+					Name:           "value",
+					Range:          types.Range{Start: types.Position{Line: 6, Character: 1}, End: types.Position{Line: 6, Character: 10}},
+					SelectionRange: types.Range{Start: types.Position{Line: 6, Character: 1}, End: types.Position{Line: 6, Character: 6}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, "Calculator", FindDocumentSymbolAt(symbols, types.Position{Line: 5, Character: 5}).Name)
+	assert.Equal(t, "value", FindDocumentSymbolAt(symbols, types.Position{Line: 6, Character: 1}).Name)
+	assert.Nil(t, FindDocumentSymbolAt(symbols, types.Position{Line: 6, Character: 7}))
+	assert.Nil(t, FindDocumentSymbolAt(symbols, types.Position{Line: 9}))
+}
+
+func TestIsEmbeddedFieldAt(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		character int
+		expected  bool
+	}{
+		{name: "Embedded", line: "\tBase", character: 1, expected: true},
+		{name: "Embedded pointer", line: "\t*Base", character: 2, expected: true},
+		{name: "Embedded qualified", line: "\tio.Writer // Output", character: 4, expected: true},
+		{name: "Embedded generic", line: "\tList[T] `json:\"list\"`", character: 1, expected: true},
+		{name: "Embedded on one line", line: "type S struct{ Base }", character: 15, expected: true},
+		{name: "Named after its type", line: "\tBase Base", character: 1, expected: false},
+		{name: "Named", line: "\tvalue int", character: 1, expected: false},
+		{name: "Several names", line: "\ta, b int", character: 1, expected: false},
+		{name: "Array type", line: "\tBase [3]Base", character: 1, expected: false},
+		{name: "No identifier", line: "\t", character: 1, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsEmbeddedFieldAt(tt.line, tt.character))
+		})
+	}
+}
+
+func TestGetGoSymbolKind(t *testing.T) {
+	root := t.TempDir()
+	source := "package shapes\n\ntype Shape struct {\n\tBase\n\tOther Other\n}\n\ntype Alias = Shape\n\ntype List[T any] struct{}\n\ntype Handler func()\n\nfunc handle() {}\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "shapes.go"), []byte(source), 0o644))
+	uri := PathToUri("shapes.go", root)
+
+	// Types are classified from their declaration, without hover information
+	assert.Equal(t, results.GoSymbolKindStruct, GetGoSymbolKind(uri, types.Position{Line: 2, Character: 5}, 23, "Shape", "struct{...}", nil))
+	assert.Equal(t, results.GoSymbolKindTypeAlias, GetGoSymbolKind(uri, types.Position{Line: 7, Character: 5}, 23, "Alias", "struct{...}", nil))
+	assert.Equal(t, results.GoSymbolKindGenericType, GetGoSymbolKind(uri, types.Position{Line: 9, Character: 5}, 23, "List", "struct{}", nil))
+	assert.Equal(t, results.GoSymbolKindNamedType, GetGoSymbolKind(uri, types.Position{Line: 11, Character: 5}, 12, "Handler", "func()", nil))
+	assert.Equal(t, results.GoSymbolKindFunc, GetGoSymbolKind(uri, types.Position{Line: 13, Character: 5}, 12, "handle", "func()", nil))
+
+	// The hover signature is only a fallback when there is no declaration at the position
+	assert.Equal(t, results.GoSymbolKindStruct, GetGoSymbolKind(uri, types.Position{Line: 2, Character: 5}, 23, "Shape", "struct{...}", &results.HoverInfo{Signature: "type Shape = Base"}))
+	assert.Equal(t, results.GoSymbolKindTypeAlias, GetGoSymbolKind(PathToUri("missing.go", root), types.Position{Line: 2, Character: 5}, 23, "Shape", "struct{...}", &results.HoverInfo{Signature: "type Shape = Base"}))
+
+	// Fields named after their type are only embedded if their declaration says so
+	assert.Equal(t, results.GoSymbolKindEmbeddedField, GetGoSymbolKind(uri, types.Position{Line: 3, Character: 1}, 8, "Base", "Base", nil))
+	assert.Equal(t, results.GoSymbolKindField, GetGoSymbolKind(uri, types.Position{Line: 4, Character: 1}, 8, "Other", "Other", nil))
+}
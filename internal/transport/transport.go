@@ -14,27 +14,52 @@ import (
 
 const (
 	receiveTimeout = 10 * time.Second
+
+	// See: https://www.jsonrpc.org/specification#error_object
+	methodNotFoundErrorCode = -32601
+	internalErrorCode       = -32603
 )
 
 var _ types.Transport = &JsonRpcTransport{}
 
 // JsonRpcTransport handles low-level JSON-RPC communication
 type JsonRpcTransport struct {
-	writer    io.Writer
-	reader    io.Reader
-	requestID int64
-	responses map[int64]chan json.RawMessage
-	mu        sync.RWMutex
-	done      chan struct{}
+	writer               io.Writer
+	reader               io.Reader
+	requestID            int64
+	responses            map[int64]chan response
+	handlers             map[string]types.RequestHandler
+	notificationHandlers map[string]types.NotificationHandler
+	mu                   sync.RWMutex
+	writeMu              sync.Mutex
+	done                 chan struct{}
+}
+
+// response represents the result or error of a JSON-RPC request
+type response struct {
+	result json.RawMessage
+	err    *ResponseError
+}
+
+// ResponseError represents a JSON-RPC error response
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
 }
 
 // NewJsonRpcTransport creates a new JSON-RPC transport
 func NewJsonRpcTransport(writer io.Writer, reader io.Reader) *JsonRpcTransport {
 	return &JsonRpcTransport{
-		writer:    writer,
-		reader:    reader,
-		responses: make(map[int64]chan json.RawMessage),
-		done:      make(chan struct{}),
+		writer:               writer,
+		reader:               reader,
+		responses:            make(map[int64]chan response),
+		handlers:             make(map[string]types.RequestHandler),
+		notificationHandlers: make(map[string]types.NotificationHandler),
+		done:                 make(chan struct{}),
 	}
 }
 
@@ -103,13 +128,15 @@ func (t *JsonRpcTransport) readResponses() {
 			slog.Error("Failed to read JSON-RPC response body", "error", err, "content_length", contentLength)
 			return
 		}
-		t.handleResponse(body)
+		t.handleMessage(body)
 	}
 }
 
-func (t *JsonRpcTransport) handleResponse(content []byte) {
+func (t *JsonRpcTransport) handleMessage(content []byte) {
 	var resp struct {
 		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
 		Result json.RawMessage `json:"result"`
 		Error  json.RawMessage `json:"error"`
 	}
@@ -118,8 +145,18 @@ func (t *JsonRpcTransport) handleResponse(content []byte) {
 		return
 	}
 
+	// Notifications from the server have a method, but no ID
 	if resp.ID == nil {
-		return // ignore notifications
+		if resp.Method != "" {
+			t.handleNotification(resp.Method, resp.Params)
+		}
+		return
+	}
+
+	// Requests from the server have a method, while responses do not
+	if resp.Method != "" {
+		go t.handleRequest(resp.ID, resp.Method, resp.Params)
+		return
 	}
 
 	var id int64
@@ -134,14 +171,84 @@ func (t *JsonRpcTransport) handleResponse(content []byte) {
 
 	if ok {
 		if resp.Error != nil {
-			ch <- resp.Error
+			respErr := &ResponseError{Code: internalErrorCode}
+			if err := json.Unmarshal(resp.Error, respErr); err != nil {
+				respErr.Message = string(resp.Error)
+			}
+			ch <- response{err: respErr}
 		} else {
-			ch <- resp.Result
+			ch <- response{result: resp.Result}
 		}
 	}
 }
 
-// SendRequest sends a JSON-RPC request and waits for the response
+func (t *JsonRpcTransport) handleNotification(method string, params json.RawMessage) {
+	t.mu.RLock()
+	handler, ok := t.notificationHandlers[method]
+	t.mu.RUnlock()
+
+	if !ok {
+		return // ignore notifications without handlers
+	}
+
+	slog.Debug("Received JSON-RPC notification from server", "method", method)
+	handler(params)
+}
+
+func (t *JsonRpcTransport) handleRequest(id json.RawMessage, method string, params json.RawMessage) {
+	slog.Debug("Received JSON-RPC request from server", "raw_id", string(id), "method", method)
+
+	t.mu.RLock()
+	handler, ok := t.handlers[method]
+	t.mu.RUnlock()
+
+	response := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      id,
+	}
+	if !ok {
+		slog.Debug("No handler for JSON-RPC request from server", "method", method)
+		response["error"] = map[string]any{
+			"code":    methodNotFoundErrorCode,
+			"message": fmt.Sprintf("method not found: %s", method),
+		}
+	} else if result, err := handler(params); err != nil {
+		slog.Error("Failed to handle JSON-RPC request from server", "method", method, "error", err)
+		response["error"] = map[string]any{
+			"code":    internalErrorCode,
+			"message": err.Error(),
+		}
+	} else {
+		response["result"] = result
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		slog.Error("Failed to marshal JSON-RPC response", "method", method, "error", err)
+		return
+	}
+
+	if err := t.writeMessage(data); err != nil {
+		slog.Error("Failed to write JSON-RPC response", "method", method, "error", err)
+	}
+}
+
+// HandleRequest registers a handler for requests sent from the server to the client
+func (t *JsonRpcTransport) HandleRequest(method string, handler types.RequestHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.handlers[method] = handler
+}
+
+// HandleNotification registers a handler for notifications sent from the server to the client.
+// Handlers are called in the order notifications are received, so they must not block.
+func (t *JsonRpcTransport) HandleNotification(method string, handler types.NotificationHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.notificationHandlers[method] = handler
+}
+
+// SendRequest sends a JSON-RPC request and waits for the response, returning a *ResponseError if the server responds with an error
 func (t *JsonRpcTransport) SendRequest(method string, params any) (json.RawMessage, error) {
 	if t.isClosed() {
 		return nil, fmt.Errorf("cannot send request: transport is closed")
@@ -164,7 +271,7 @@ func (t *JsonRpcTransport) SendRequest(method string, params any) (json.RawMessa
 		return nil, fmt.Errorf("failed to marshal JSON-RPC request: %w", err)
 	}
 
-	ch := make(chan json.RawMessage, 1)
+	ch := make(chan response, 1)
 	t.mu.Lock()
 	t.responses[id] = ch
 	t.mu.Unlock()
@@ -180,13 +287,21 @@ func (t *JsonRpcTransport) SendRequest(method string, params any) (json.RawMessa
 	}
 
 	select {
-	case response := <-ch:
+	case resp := <-ch:
 		duration := time.Since(startTime)
+		if resp.err != nil {
+			slog.Debug("Received JSON-RPC error response",
+				"request_id", id,
+				"method", method,
+				"duration_ms", duration.Milliseconds(),
+				"error", resp.err)
+			return nil, resp.err
+		}
 		slog.Debug("Received JSON-RPC response",
 			"request_id", id,
 			"method", method,
 			"duration_ms", duration.Milliseconds())
-		return response, nil
+		return resp.result, nil
 	case <-time.After(receiveTimeout):
 		duration := time.Since(startTime)
 		slog.Error("Timeout waiting for JSON-RPC response",
@@ -225,6 +340,10 @@ func (t *JsonRpcTransport) SendNotification(method string, params any) error {
 }
 
 func (t *JsonRpcTransport) writeMessage(data []byte) error {
+	// Requests, notifications, and responses may be written concurrently
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+
 	header := fmt.Sprintf("Content-Length: %d\r\n\r\n", len(data))
 	if _, err := t.writer.Write([]byte(header)); err != nil {
 		return fmt.Errorf("failed to write JSON-RPC message header: %w", err)
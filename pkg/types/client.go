@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"encoding/json"
 )
 
 // Client defines the LSP client interface
@@ -10,12 +11,30 @@ type Client interface {
 	Stop(ctx context.Context) error
 
 	GoToDefinition(ctx context.Context, uri string, position Position) ([]Location, error)
+	GoToTypeDefinition(ctx context.Context, uri string, position Position) ([]Location, error)
 	FindReferences(ctx context.Context, uri string, position Position) ([]Location, error)
+	GetDocumentHighlights(ctx context.Context, uri string, position Position) ([]DocumentHighlight, error)
 	GetHoverInfo(ctx context.Context, uri string, position Position) (string, error)
+	GetSignatureHelp(ctx context.Context, uri string, position Position) (*SignatureHelp, error)
+	GetCompletions(ctx context.Context, uri string, position Position) (*CompletionList, error)
+	ResolveCompletionItem(ctx context.Context, item CompletionItem) (*CompletionItem, error)
 	FuzzyFindSymbol(ctx context.Context, query string) ([]SymbolInformation, error)
 	GetDocumentSymbols(ctx context.Context, uri string) ([]DocumentSymbol, error)
 	PrepareRename(ctx context.Context, uri string, position Position) (*PrepareRenameResult, error)
 	RenameSymbol(ctx context.Context, uri string, position Position, newName string) (*WorkspaceEdit, error)
+	FormatDocument(ctx context.Context, uri string) ([]TextEdit, error)
+	OpenDocument(ctx context.Context, uri string, text string) error
+	ChangeDocument(ctx context.Context, uri string, version int, text string) error
+	CloseDocument(ctx context.Context, uri string) error
+	GetDiagnostics(ctx context.Context, uri string) ([]Diagnostic, error)
+	WaitForDiagnostics(ctx context.Context, uri string) ([]Diagnostic, error)
+	GetCodeActions(ctx context.Context, uri string, rng Range, actionContext CodeActionContext) ([]CodeAction, error)
+	ResolveCodeAction(ctx context.Context, action CodeAction) (*CodeAction, error)
+	ExecuteCommand(ctx context.Context, command string, arguments ...any) (json.RawMessage, error)
+	ExecuteCommandEdits(ctx context.Context, command Command) ([]WorkspaceEdit, error)
+	DidChangeWatchedFiles(ctx context.Context, changes []FileEvent) error
+	ListKnownPackages(ctx context.Context, uri string) ([]string, error)
+	GetPackages(ctx context.Context, args PackagesArgs) (*PackagesResult, error)
 }
 
 // Position represents a position in a text document
@@ -36,6 +55,65 @@ type Location struct {
 	Range Range  `json:"range"`
 }
 
+// DocumentHighlightKind represents the kind of a document highlight
+type DocumentHighlightKind int
+
+const (
+	DocumentHighlightKindText  DocumentHighlightKind = 1
+	DocumentHighlightKindRead  DocumentHighlightKind = 2
+	DocumentHighlightKindWrite DocumentHighlightKind = 3
+)
+
+// DocumentHighlight represents a range inside a text document which deserves special attention
+type DocumentHighlight struct {
+	Range Range                 `json:"range"`
+	Kind  DocumentHighlightKind `json:"kind,omitempty"`
+}
+
+// SignatureHelp represents the signatures of the function being called at a position.
+// Documentation is normalized to plain strings, and parameter labels to substrings of the signature label.
+type SignatureHelp struct {
+	Signatures      []SignatureInformation `json:"signatures"`
+	ActiveSignature int                    `json:"activeSignature"`
+	ActiveParameter *int                   `json:"activeParameter,omitempty"`
+}
+
+// SignatureInformation represents the signature of a callable
+type SignatureInformation struct {
+	Label           string                 `json:"label"`
+	Documentation   string                 `json:"documentation,omitempty"`
+	Parameters      []ParameterInformation `json:"parameters,omitempty"`
+	ActiveParameter *int                   `json:"activeParameter,omitempty"` // Overrides the active parameter of the SignatureHelp
+}
+
+// ParameterInformation represents a parameter of a callable signature
+type ParameterInformation struct {
+	Label         string `json:"label"`
+	Documentation string `json:"documentation,omitempty"`
+}
+
+// CompletionList represents the completion candidates at a position.
+// Documentation is normalized to plain strings.
+type CompletionList struct {
+	IsIncomplete bool             `json:"isIncomplete"` // Whether further typing should recompute the list
+	Items        []CompletionItem `json:"items"`
+}
+
+// CompletionItem represents a completion candidate
+type CompletionItem struct {
+	Label               string          `json:"label"`
+	Kind                int             `json:"kind,omitempty"`
+	Detail              string          `json:"detail,omitempty"`
+	Documentation       string          `json:"documentation,omitempty"`
+	Deprecated          bool            `json:"deprecated,omitempty"`
+	SortText            string          `json:"sortText,omitempty"`
+	FilterText          string          `json:"filterText,omitempty"`
+	InsertText          string          `json:"insertText,omitempty"`
+	TextEdit            *TextEdit       `json:"textEdit,omitempty"`
+	AdditionalTextEdits []TextEdit      `json:"additionalTextEdits,omitempty"` // E.g. edits adding the import of an unimported package
+	Data                json.RawMessage `json:"data,omitempty"`                // Preserved for completionItem/resolve requests
+}
+
 // SymbolInformation represents information about a symbol
 type SymbolInformation struct {
 	Name     string   `json:"name"`
@@ -43,6 +121,12 @@ type SymbolInformation struct {
 	Location Location `json:"location"`
 }
 
+// Symbol search scopes, named after the values of the gopls symbolScope setting
+const (
+	SymbolScopeWorkspace = "workspace"
+	SymbolScopeAll       = "all"
+)
+
 // DocumentSymbol represents a symbol within a document with hierarchical structure
 type DocumentSymbol struct {
 	Name           string           `json:"name"`
@@ -71,6 +155,25 @@ type TextDocumentEdit struct {
 	Edits        []TextEdit             `json:"edits"`
 }
 
+// ResourceOperationKind represents the kind of a file resource operation in a workspace edit
+type ResourceOperationKind string
+
+const (
+	ResourceOperationCreate ResourceOperationKind = "create"
+	ResourceOperationRename ResourceOperationKind = "rename"
+	ResourceOperationDelete ResourceOperationKind = "delete"
+)
+
+// DocumentChange represents an entry of the document changes of a workspace edit,
+// which is either a text document edit or (if Kind is set) a file resource operation
+type DocumentChange struct {
+	TextDocumentEdit
+	Kind   ResourceOperationKind `json:"kind,omitempty"`
+	URI    string                `json:"uri,omitempty"`    // File to create or delete
+	OldURI string                `json:"oldUri,omitempty"` // File to rename
+	NewURI string                `json:"newUri,omitempty"` // New name of the renamed file
+}
+
 // TextDocumentIdentifier represents a text document identifier
 type TextDocumentIdentifier struct {
 	URI     string `json:"uri"`
@@ -80,5 +183,144 @@ type TextDocumentIdentifier struct {
 // WorkspaceEdit represents changes to many resources managed in the workspace
 type WorkspaceEdit struct {
 	Changes         map[string][]TextEdit `json:"changes,omitempty"`
-	DocumentChanges []TextDocumentEdit    `json:"documentChanges,omitempty"`
+	DocumentChanges []DocumentChange      `json:"documentChanges,omitempty"`
+}
+
+// DiagnosticSeverity represents the severity of a diagnostic
+type DiagnosticSeverity int
+
+const (
+	DiagnosticSeverityError       DiagnosticSeverity = 1
+	DiagnosticSeverityWarning     DiagnosticSeverity = 2
+	DiagnosticSeverityInformation DiagnosticSeverity = 3
+	DiagnosticSeverityHint        DiagnosticSeverity = 4
+)
+
+// Diagnostic represents a compiler error, vet finding, or other problem published by the language server
+type Diagnostic struct {
+	Range    Range              `json:"range"`
+	Severity DiagnosticSeverity `json:"severity,omitempty"`
+	Code     any                `json:"code,omitempty"` // A string or number, like an analyzer name
+	Source   string             `json:"source,omitempty"`
+	Message  string             `json:"message"`
+	Tags     []int              `json:"tags,omitempty"`
+	Data     json.RawMessage    `json:"data,omitempty"` // Preserved so that gopls can compute the quick fixes of the diagnostic
+}
+
+// CodeActionKind represents a hierarchical kind of code action, like "quickfix" or "refactor.extract.function"
+type CodeActionKind string
+
+const (
+	CodeActionKindQuickFix              CodeActionKind = "quickfix"
+	CodeActionKindRefactor              CodeActionKind = "refactor"
+	CodeActionKindRefactorExtract       CodeActionKind = "refactor.extract"
+	CodeActionKindExtractFunction       CodeActionKind = "refactor.extract.function"
+	CodeActionKindExtractMethod         CodeActionKind = "refactor.extract.method"
+	CodeActionKindExtractVariable       CodeActionKind = "refactor.extract.variable"
+	CodeActionKindExtractVariableAll    CodeActionKind = "refactor.extract.variable-all"
+	CodeActionKindExtractConstant       CodeActionKind = "refactor.extract.constant"
+	CodeActionKindExtractConstantAll    CodeActionKind = "refactor.extract.constant-all"
+	CodeActionKindRefactorInline        CodeActionKind = "refactor.inline"
+	CodeActionKindInlineCall            CodeActionKind = "refactor.inline.call"
+	CodeActionKindRefactorRewrite       CodeActionKind = "refactor.rewrite"
+	CodeActionKindRemoveUnusedParam     CodeActionKind = "refactor.rewrite.removeUnusedParam"
+	CodeActionKindMoveParamLeft         CodeActionKind = "refactor.rewrite.moveParamLeft"
+	CodeActionKindSource                CodeActionKind = "source"
+	CodeActionKindSourceOrganizeImports CodeActionKind = "source.organizeImports"
+	CodeActionKindSourceFixAll          CodeActionKind = "source.fixAll"
+)
+
+// CodeActionContext represents the diagnostics and kinds a code action request is made for
+type CodeActionContext struct {
+	Diagnostics []Diagnostic     `json:"diagnostics"`
+	Only        []CodeActionKind `json:"only,omitempty"`
+	TriggerKind int              `json:"triggerKind,omitempty"` // 1 when invoked explicitly, 2 when automatic
+}
+
+// CodeAction represents a change that can be applied to the code, like a quick fix or a refactoring.
+// Its changes are described by the edit, the command, or (if both are nil) computed by codeAction/resolve.
+type CodeAction struct {
+	Title       string              `json:"title"`
+	Kind        CodeActionKind      `json:"kind,omitempty"`
+	Diagnostics []Diagnostic        `json:"diagnostics,omitempty"` // Diagnostics fixed by the action
+	IsPreferred bool                `json:"isPreferred,omitempty"`
+	Disabled    *CodeActionDisabled `json:"disabled,omitempty"`
+	Edit        *WorkspaceEdit      `json:"edit,omitempty"`
+	Command     *Command            `json:"command,omitempty"`
+	Data        json.RawMessage     `json:"data,omitempty"` // Preserved for codeAction/resolve requests
+}
+
+// CodeActionDisabled represents why a code action can't be applied
+type CodeActionDisabled struct {
+	Reason string `json:"reason"`
+}
+
+// Command represents a command of the language server, executed with workspace/executeCommand
+type Command struct {
+	Title     string            `json:"title"`
+	Command   string            `json:"command"`
+	Arguments []json.RawMessage `json:"arguments,omitempty"`
+}
+
+// FileChangeType represents the type of a file change event
+type FileChangeType int
+
+const (
+	FileChangeTypeCreated FileChangeType = 1
+	FileChangeTypeChanged FileChangeType = 2
+	FileChangeTypeDeleted FileChangeType = 3
+)
+
+// FileEvent represents a change to a file on disk, which the language server must be notified of
+type FileEvent struct {
+	URI  string         `json:"uri"`
+	Type FileChangeType `json:"type"`
+}
+
+// PackagesMode is a bit set of the optional information to include in a gopls.packages command result
+type PackagesMode uint64
+
+const (
+	PackagesModeNeedTests PackagesMode = 1 << iota // Include test variants and the tests in each test file
+)
+
+// PackagesArgs represents the arguments of the gopls.packages command.
+// See: https://github.com/golang/tools/blob/master/gopls/internal/protocol/command/interface.go
+type PackagesArgs struct {
+	Files     []string     `json:"Files"`     // URIs of files and directories whose packages should be described
+	Recursive bool         `json:"Recursive"` // Whether to include the packages of subdirectories of directories in Files
+	Mode      PackagesMode `json:"Mode"`
+}
+
+// PackagesResult represents the result of the gopls.packages command
+type PackagesResult struct {
+	Packages []Package         `json:"Packages"`
+	Module   map[string]Module `json:"Module"` // Modules of the packages, by module path
+}
+
+// Package represents a package in the result of the gopls.packages command
+type Package struct {
+	Path       string     `json:"Path"`       // Import path of the package
+	ForTest    string     `json:"ForTest"`    // Import path of the package under test, for test variants
+	ModulePath string     `json:"ModulePath"` // Path of the module containing the package, if any
+	TestFiles  []TestFile `json:"TestFiles"`  // Only included with PackagesModeNeedTests
+}
+
+// Module represents a module in the result of the gopls.packages command
+type Module struct {
+	Path    string `json:"Path"`
+	Version string `json:"Version"` // Empty for workspace modules
+	GoMod   string `json:"GoMod"`   // URI of the go.mod file
+}
+
+// TestFile represents a test file and the tests it contains
+type TestFile struct {
+	URI   string     `json:"URI"`
+	Tests []TestCase `json:"Tests"`
+}
+
+// TestCase represents a test, benchmark, example, or fuzz function, or a subtest
+type TestCase struct {
+	Name string   `json:"Name"`
+	Loc  Location `json:"Loc"`
 }
@@ -2,7 +2,8 @@ package types
 
 // Config represents the configuration for the gopls-mcp server
 type Config struct {
-	GoplsPath     string `json:"gopls_path,omitempty"`
-	WorkspaceRoot string `json:"workspace_root"`
-	LogLevel      string `json:"log_level,omitempty"`
+	GoplsPath       string   `json:"gopls_path,omitempty"`
+	WorkspaceRoot   string   `json:"workspace_root"`
+	LogLevel        string   `json:"log_level,omitempty"`
+	UnusedAllowlist []string `json:"unused_allowlist,omitempty"` // Patterns of symbols that find_unused_symbols never reports, like public APIs
 }
@@ -9,4 +9,12 @@ type Transport interface {
 
 	SendRequest(method string, params any) (json.RawMessage, error)
 	SendNotification(method string, params any) error
+	HandleRequest(method string, handler RequestHandler)
+	HandleNotification(method string, handler NotificationHandler)
 }
+
+// RequestHandler handles a request sent from the server to the client, returning the result to respond with
+type RequestHandler func(params json.RawMessage) (any, error)
+
+// NotificationHandler handles a notification sent from the server to the client
+type NotificationHandler func(params json.RawMessage)
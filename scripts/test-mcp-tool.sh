@@ -8,17 +8,17 @@ set -e
 TOOL_NAME="$1"
 if [[ -z "$TOOL_NAME" ]]; then
     echo "Usage: $0 <tool_name>"
-    echo "Available tools: find_symbol_definitions_by_name, find_symbol_references_by_anchor, list_symbols_in_file"
+    echo "Available tools: find_symbol_definitions_by_name, find_symbol_references_by_anchor, list_symbols_in_file, list_symbols_in_package, list_packages, get_documentation, go_to_definition_by_position, go_to_type_definition_by_position, get_signature_help, get_completions, list_code_actions, apply_code_action, format_file, extract_function, extract_variable, inline_call, change_signature, move_symbol, safe_delete, find_unused_symbols, implement_interface"
     exit 1
 fi
 
 # Validate tool name
 case "$TOOL_NAME" in
-    "find_symbol_definitions_by_name"|"find_symbol_references_by_anchor"|"list_symbols_in_file")
+    "find_symbol_definitions_by_name"|"find_symbol_references_by_anchor"|"list_symbols_in_file"|"list_symbols_in_package"|"list_packages"|"get_documentation"|"go_to_definition_by_position"|"go_to_type_definition_by_position"|"get_signature_help"|"get_completions"|"list_code_actions"|"apply_code_action"|"format_file"|"extract_function"|"extract_variable"|"inline_call"|"change_signature"|"move_symbol"|"safe_delete"|"find_unused_symbols"|"implement_interface")
         ;;
     *)
         echo "Error: Unknown tool '$TOOL_NAME'"
-        echo "Available tools: find_symbol_definitions_by_name, find_symbol_references_by_anchor, list_symbols_in_file"
+        echo "Available tools: find_symbol_definitions_by_name, find_symbol_references_by_anchor, list_symbols_in_file, list_symbols_in_package, list_packages, get_documentation, go_to_definition_by_position, go_to_type_definition_by_position, get_signature_help, get_completions, list_code_actions, apply_code_action, format_file, extract_function, extract_variable, inline_call, change_signature, move_symbol, safe_delete, find_unused_symbols, implement_interface"
         exit 1
         ;;
 esac